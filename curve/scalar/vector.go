@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package scalar
+
+// Vector is a contiguous vector of scalars, with the operations commonly
+// required by proof systems (eg: inner-product arguments and range proofs)
+// implemented so as to minimize the number of modular reductions and
+// avoid heap allocation.
+//
+// The destination of each operation may alias any of the inputs.
+type Vector []Scalar
+
+// NewVector returns a vector of n scalars, each set to zero.
+func NewVector(n int) Vector {
+	return make(Vector, n)
+}
+
+// Pointers returns a slice of pointers to each element of v, suitable
+// for use with the multiscalar multiplication routines.
+func (v Vector) Pointers() []*Scalar {
+	ptrs := make([]*Scalar, len(v))
+	for i := range v {
+		ptrs[i] = &v[i]
+	}
+
+	return ptrs
+}
+
+// Add sets `v[i] = a[i] + b[i] (mod l)`, and returns v.
+func (v Vector) Add(a, b Vector) Vector {
+	v.checkLengths(a, b)
+
+	for i := range v {
+		v[i].Add(&a[i], &b[i])
+	}
+
+	return v
+}
+
+// Sub sets `v[i] = a[i] - b[i] (mod l)`, and returns v.
+func (v Vector) Sub(a, b Vector) Vector {
+	v.checkLengths(a, b)
+
+	for i := range v {
+		v[i].Sub(&a[i], &b[i])
+	}
+
+	return v
+}
+
+// Hadamard sets v to the entry-wise product `v[i] = a[i] * b[i] (mod l)`,
+// and returns v.
+func (v Vector) Hadamard(a, b Vector) Vector {
+	v.checkLengths(a, b)
+
+	var ua, ub unpackedScalar
+	for i := range v {
+		ua.SetBytes(a[i].inner[:])
+		ub.SetBytes(b[i].inner[:])
+		v[i].pack(ua.Mul(&ua, &ub))
+	}
+
+	return v
+}
+
+// Scale sets `v[i] = a[i] * x (mod l)`, and returns v.
+func (v Vector) Scale(a Vector, x *Scalar) Vector {
+	v.checkLengths(a)
+
+	// Keeping x in Montgomery form means that each element only
+	// requires a single reduction.
+	var xR, t unpackedScalar
+	xR.ToMontgomery(x.unpack())
+	for i := range v {
+		t.SetBytes(a[i].inner[:])
+		v[i].pack(t.MontgomeryMul(&t, &xR))
+	}
+
+	return v
+}
+
+// Powers sets `v[i] = x^i (mod l)`, and returns v.
+func (v Vector) Powers(x *Scalar) Vector {
+	if len(v) == 0 {
+		return v
+	}
+
+	var xR, acc unpackedScalar
+	xR.ToMontgomery(x.unpack())
+	acc.SetBytes(One().inner[:])
+	v[0].pack(&acc)
+	for i := 1; i < len(v); i++ {
+		v[i].pack(acc.MontgomeryMul(&acc, &xR))
+	}
+
+	return v
+}
+
+// Fold sets `v[i] = x * a[i] + y * a[n/2 + i] (mod l)`, where n is the
+// length of a, and returns v.  The length of a must be twice that of v.
+//
+// This is the vector folding step of inner-product arguments, and v may
+// be the low half of a (eg: `a[:len(a)/2].Fold(a, x, y)`), to fold a
+// in place.
+func (v Vector) Fold(a Vector, x, y *Scalar) Vector {
+	if len(a) != 2*len(v) {
+		panic("curve/scalar: mismatched vector lengths")
+	}
+
+	var xR, yR, lo, hi unpackedScalar
+	xR.ToMontgomery(x.unpack())
+	yR.ToMontgomery(y.unpack())
+	n := len(v)
+	for i := range v {
+		lo.SetBytes(a[i].inner[:])
+		hi.SetBytes(a[n+i].inner[:])
+		lo.MontgomeryMul(&lo, &xR)
+		hi.MontgomeryMul(&hi, &yR)
+		v[i].pack(lo.Add(&lo, &hi))
+	}
+
+	return v
+}
+
+// InnerProduct sets s to the inner product `sum(a[i] * b[i]) (mod l)`,
+// and returns s.
+func (s *Scalar) InnerProduct(a, b Vector) *Scalar {
+	if len(a) != len(b) {
+		panic("curve/scalar: mismatched vector lengths")
+	}
+
+	// Accumulate `sum(a[i] * b[i]) / R`, and fix up the extra factor of
+	// 1/R with a single multiply at the end.
+	var acc, t unpackedScalar
+	for i := range a {
+		t.SetBytes(a[i].inner[:])
+		acc.Add(&acc, t.MontgomeryMul(&t, b[i].unpack()))
+	}
+
+	return s.pack(acc.MontgomeryMul(&acc, &constRR))
+}
+
+func (v Vector) checkLengths(vecs ...Vector) {
+	for _, vec := range vecs {
+		if len(vec) != len(v) {
+			panic("curve/scalar: mismatched vector lengths")
+		}
+	}
+}
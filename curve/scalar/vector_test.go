@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package scalar
+
+import (
+	"crypto/rand"
+	"strconv"
+	"testing"
+)
+
+var benchVectorSizes = []int{16, 64, 256, 1024}
+
+func TestVector(t *testing.T) {
+	t.Run("Add", testVectorAdd)
+	t.Run("Sub", testVectorSub)
+	t.Run("Hadamard", testVectorHadamard)
+	t.Run("Scale", testVectorScale)
+	t.Run("Powers", testVectorPowers)
+	t.Run("Fold", testVectorFold)
+	t.Run("Fold/InPlace", testVectorFoldInPlace)
+	t.Run("InnerProduct", testVectorInnerProduct)
+	t.Run("InnerProduct/Empty", testVectorInnerProductEmpty)
+	t.Run("MismatchedLengths", testVectorMismatchedLengths)
+}
+
+func testVectorAdd(t *testing.T) {
+	a, b := mustRandomVector(t, 17), mustRandomVector(t, 17)
+	v := NewVector(len(a)).Add(a, b)
+	for i := range v {
+		expected := New().Add(&a[i], &b[i])
+		if v[i].Equal(expected) != 1 {
+			t.Fatalf("v[%d] != a[%d] + b[%d] (Got %v)", i, i, i, v[i])
+		}
+	}
+}
+
+func testVectorSub(t *testing.T) {
+	a, b := mustRandomVector(t, 17), mustRandomVector(t, 17)
+	v := NewVector(len(a)).Sub(a, b)
+	for i := range v {
+		expected := New().Sub(&a[i], &b[i])
+		if v[i].Equal(expected) != 1 {
+			t.Fatalf("v[%d] != a[%d] - b[%d] (Got %v)", i, i, i, v[i])
+		}
+	}
+}
+
+func testVectorHadamard(t *testing.T) {
+	a, b := mustRandomVector(t, 17), mustRandomVector(t, 17)
+	a[0].Set(testConstants["LARGEST_ED25519_S"])
+	b[0].Set(testConstants["LARGEST_ED25519_S"])
+
+	v := NewVector(len(a)).Hadamard(a, b)
+	for i := range v {
+		expected := New().Mul(&a[i], &b[i])
+		if v[i].Equal(expected) != 1 {
+			t.Fatalf("v[%d] != a[%d] * b[%d] (Got %v)", i, i, i, v[i])
+		}
+	}
+
+	// Aliased destination.
+	a.Hadamard(a, b)
+	for i := range v {
+		if a[i].Equal(&v[i]) != 1 {
+			t.Fatalf("a[%d] != v[%d] after in-place Hadamard", i, i)
+		}
+	}
+}
+
+func testVectorScale(t *testing.T) {
+	a := mustRandomVector(t, 17)
+	a[0].Set(testConstants["LARGEST_ED25519_S"])
+	x := testConstants["X"]
+
+	v := NewVector(len(a)).Scale(a, x)
+	for i := range v {
+		expected := New().Mul(&a[i], x)
+		if v[i].Equal(expected) != 1 {
+			t.Fatalf("v[%d] != a[%d] * x (Got %v)", i, i, v[i])
+		}
+	}
+}
+
+func testVectorPowers(t *testing.T) {
+	x := testConstants["X"]
+
+	v := NewVector(8).Powers(x)
+	expected := One()
+	for i := range v {
+		if v[i].Equal(expected) != 1 {
+			t.Fatalf("v[%d] != x^%d (Got %v)", i, i, v[i])
+		}
+		expected.Mul(expected, x)
+	}
+
+	if v = NewVector(0).Powers(x); len(v) != 0 {
+		t.Fatalf("Powers on empty vector returned non-empty vector")
+	}
+}
+
+func testVectorFold(t *testing.T) {
+	a := mustRandomVector(t, 16)
+	a[15].Set(testConstants["LARGEST_ED25519_S"])
+	x, y := testConstants["X"], testConstants["XINV"]
+
+	v := NewVector(len(a)/2).Fold(a, x, y)
+	for i := range v {
+		expected := New().Mul(&a[i], x)
+		expected.Add(expected, New().Mul(&a[len(v)+i], y))
+		if v[i].Equal(expected) != 1 {
+			t.Fatalf("v[%d] != x * a[%d] + y * a[%d] (Got %v)", i, i, len(v)+i, v[i])
+		}
+	}
+}
+
+func testVectorFoldInPlace(t *testing.T) {
+	a := mustRandomVector(t, 16)
+	x, y := testConstants["X"], testConstants["Y"]
+
+	expected := NewVector(len(a)/2).Fold(a, x, y)
+	for n := len(a); n > 1; n /= 2 {
+		a = a[:n/2].Fold(a, x, y)
+		if n == 16 {
+			for i := range a {
+				if a[i].Equal(&expected[i]) != 1 {
+					t.Fatalf("a[%d] != expected[%d] after in-place Fold", i, i)
+				}
+			}
+		}
+	}
+	if len(a) != 1 {
+		t.Fatalf("unexpected folded length: %d", len(a))
+	}
+}
+
+func testVectorInnerProduct(t *testing.T) {
+	a, b := mustRandomVector(t, 33), mustRandomVector(t, 33)
+	a[0].Set(testConstants["LARGEST_ED25519_S"])
+	b[0].Set(testConstants["LARGEST_ED25519_S"])
+
+	expected := New()
+	for i := range a {
+		expected.Add(expected, New().Mul(&a[i], &b[i]))
+	}
+
+	s := New().InnerProduct(a, b)
+	if s.Equal(expected) != 1 {
+		t.Fatalf("InnerProduct(a, b) != sum(a[i] * b[i]) (Got %v)", s)
+	}
+
+	// Sanity check against known constants.
+	x, y := Vector{*testConstants["X"]}, Vector{*testConstants["Y"]}
+	if s.InnerProduct(x, y).Equal(testConstants["XY"]) != 1 {
+		t.Fatalf("InnerProduct([x], [y]) != xy (Got %v)", s)
+	}
+}
+
+func testVectorInnerProductEmpty(t *testing.T) {
+	s := One().InnerProduct(nil, nil)
+	if s.Equal(New()) != 1 {
+		t.Fatalf("InnerProduct(nil, nil) != 0 (Got %v)", s)
+	}
+}
+
+func testVectorMismatchedLengths(t *testing.T) {
+	a, b := NewVector(2), NewVector(3)
+	for _, tc := range []struct {
+		name string
+		fn   func()
+	}{
+		{"Add", func() { NewVector(2).Add(a, b) }},
+		{"Sub", func() { NewVector(2).Sub(a, b) }},
+		{"Hadamard", func() { NewVector(2).Hadamard(a, b) }},
+		{"Scale", func() { NewVector(3).Scale(a, One()) }},
+		{"Fold", func() { NewVector(1).Fold(b, One(), One()) }},
+		{"InnerProduct", func() { New().InnerProduct(a, b) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s did not panic on mismatched lengths", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func BenchmarkVector(b *testing.B) {
+	b.Run("InnerProduct", benchVectorInnerProduct)
+	b.Run("InnerProduct/Naive", benchVectorInnerProductNaive)
+	b.Run("Fold", benchVectorFold)
+}
+
+func benchVectorInnerProduct(b *testing.B) {
+	for _, n := range benchVectorSizes {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			x, y := mustRandomVector(b, n), mustRandomVector(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			var s Scalar
+			for i := 0; i < b.N; i++ {
+				s.InnerProduct(x, y)
+			}
+		})
+	}
+}
+
+func benchVectorInnerProductNaive(b *testing.B) {
+	for _, n := range benchVectorSizes {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			x, y := mustRandomVector(b, n), mustRandomVector(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			var s, tmp Scalar
+			for i := 0; i < b.N; i++ {
+				s.Zero()
+				for j := range x {
+					s.Add(&s, tmp.Mul(&x[j], &y[j]))
+				}
+			}
+		})
+	}
+}
+
+func benchVectorFold(b *testing.B) {
+	for _, n := range benchVectorSizes {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			a := mustRandomVector(b, 2*n)
+			v := NewVector(n)
+			x, y := testConstants["X"], testConstants["Y"]
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				v.Fold(a, x, y)
+			}
+		})
+	}
+}
+
+func mustRandomVector(tb testing.TB, n int) Vector {
+	v := NewVector(n)
+	for i := range v {
+		if _, err := v[i].SetRandom(rand.Reader); err != nil {
+			tb.Fatalf("SetRandom(): %v", err)
+		}
+	}
+
+	return v
+}
@@ -91,6 +91,17 @@ func EdwardsFlavor(r *field.Element) *curve.EdwardsPoint {
 	return SetEdwardsFromXY(&p, &x, &y)
 }
 
+// MontgomeryFlavor computes the MontgomeryPoint corresponding to the
+// provided Elligator 2 representative.
+func MontgomeryFlavor(r *field.Element) *curve.MontgomeryPoint {
+	u, _ := montgomeryFlavor(r)
+
+	var p curve.MontgomeryPoint
+	_ = u.ToBytes(p[:])
+
+	return &p
+}
+
 // montgomeryFlavor computes Montgomery u and v coordinates corresponding
 // to the provided Elligator 2 representative.
 func montgomeryFlavor(r *field.Element) (field.Element, field.Element) {
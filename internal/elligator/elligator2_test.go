@@ -50,11 +50,7 @@ func montgomeryFromUniformBytes(in []byte) (*curve.MontgomeryPoint, error) {
 		return nil, fmt.Errorf("curve/montgomery: failed to deserailize r: %w", err)
 	}
 
-	var p curve.MontgomeryPoint
-	u, _ := montgomeryFlavor(&r)
-	_ = u.ToBytes(p[:])
-
-	return &p, nil
+	return MontgomeryFlavor(&r), nil
 }
 
 func TestMontgomeryElligator2(t *testing.T) {
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package polynomial implements polynomial evaluation and Lagrange
+// interpolation over the scalar field, for the purpose of secret sharing.
+package polynomial
+
+import "github.com/oasisprotocol/curve25519-voi/curve/scalar"
+
+// Evaluate returns `coefficients[0] + coefficients[1] * x + ... +
+// coefficients[n] * x^n`.
+func Evaluate(x *scalar.Scalar, coefficients []*scalar.Scalar) *scalar.Scalar {
+	value := scalar.New()
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		value.Mul(value, x)
+		value.Add(value, coefficients[i])
+	}
+	return value
+}
+
+// LagrangeCoefficient returns the Lagrange coefficient at 0 for xs[i],
+// with respect to xs.  The caller is responsible for ensuring that xs
+// are distinct.
+func LagrangeCoefficient(xs []*scalar.Scalar, i int) *scalar.Scalar {
+	xI := xs[i]
+	numerator, denominator := scalar.One(), scalar.One()
+	for j, xJ := range xs {
+		if j == i {
+			continue
+		}
+
+		var diff scalar.Scalar
+		numerator.Mul(numerator, xJ)
+		denominator.Mul(denominator, diff.Sub(xJ, xI))
+	}
+
+	return numerator.Mul(numerator, denominator.Invert(denominator))
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+)
+
+func TestPolynomial(t *testing.T) {
+	// f(x) = 7 + 3x + 5x^2
+	coefficients := []*scalar.Scalar{
+		scalar.NewFromUint64(7),
+		scalar.NewFromUint64(3),
+		scalar.NewFromUint64(5),
+	}
+
+	t.Run("Evaluate", func(t *testing.T) {
+		for _, v := range []struct {
+			x, expected uint64
+		}{
+			{0, 7},
+			{1, 15},
+			{2, 33},
+			{10, 537},
+		} {
+			y := Evaluate(scalar.NewFromUint64(v.x), coefficients)
+			if y.Equal(scalar.NewFromUint64(v.expected)) != 1 {
+				t.Fatalf("Evaluate(%d): mismatch", v.x)
+			}
+		}
+	})
+	t.Run("LagrangeCoefficient", func(t *testing.T) {
+		xs := []*scalar.Scalar{
+			scalar.NewFromUint64(2),
+			scalar.NewFromUint64(5),
+			scalar.NewFromUint64(9),
+		}
+
+		y0 := scalar.New()
+		for i, x := range xs {
+			lambda := LagrangeCoefficient(xs, i)
+			y0.Add(y0, lambda.Mul(lambda, Evaluate(x, coefficients)))
+		}
+		if y0.Equal(coefficients[0]) != 1 {
+			t.Fatalf("interpolation at 0 does not recover the constant term")
+		}
+	})
+}
@@ -31,22 +31,62 @@
 package ed25519
 
 import (
+	"context"
 	cryptorand "crypto/rand"
 	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
 	"io"
 
 	"github.com/oasisprotocol/curve25519-voi/curve"
 	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
 	"github.com/oasisprotocol/curve25519-voi/internal/scalar128"
+	"github.com/oasisprotocol/curve25519-voi/internal/zeroreader"
+	"github.com/oasisprotocol/curve25519-voi/primitives/merlin"
 )
 
-const batchPippengerThreshold = (190 - 1) / 2
+const (
+	batchPippengerThreshold = (190 - 1) / 2
+
+	deterministicBatchLabel = "curve25519-voi/ed25519: deterministic batch verification"
+
+	// bisectLeafSize is the size at which bisection gives up on
+	// batch verification, and verifies each entry serially.
+	bisectLeafSize = 4
+
+	// autoBisectThreshold is the batch size at which FallbackAuto
+	// will use bisection.
+	autoBisectThreshold = 64
+)
+
+// FallbackStrategy is the strategy used to identify the invalid entries
+// when batch verification fails.
+type FallbackStrategy int
+
+const (
+	// FallbackAuto selects the fallback strategy based on the size of
+	// the batch.
+	FallbackAuto FallbackStrategy = iota
+
+	// FallbackSerial verifies each entry serially.
+	FallbackSerial
+
+	// FallbackBisect recursively halves the failing batch, and batch
+	// verifies each half with fresh randomizers, until the failing
+	// sub-batches are small enough to verify each entry serially.  This
+	// is significantly faster than FallbackSerial when there are few
+	// invalid entries in a large batch.
+	FallbackBisect
+)
 
 // BatchVerifier accumulates batch entries with Add, before performing
 // batch verification with Verify.
 type BatchVerifier struct {
 	entries []entry
 
+	fallback      FallbackStrategy
+	deterministic bool
+
 	anyInvalid      bool
 	anyCofactorless bool
 	anyNotExpanded  bool
@@ -74,6 +114,10 @@ type entry struct {
 
 	wantCofactorless bool
 	canBeValid       bool
+
+	// err is the reason why the entry is known to be invalid, if
+	// canBeValid is false.
+	err error
 }
 
 func (e *entry) doInit(publicKey PublicKey, expandedPublicKey *ExpandedPublicKey, message, sig []byte, opts *Options) {
@@ -82,7 +126,7 @@ func (e *entry) doInit(publicKey PublicKey, expandedPublicKey *ExpandedPublicKey
 	e.canBeValid = false
 
 	fBase, context, err := opts.verify()
-	if err != nil {
+	if e.err = err; err != nil {
 		return
 	}
 	vOpts := opts.Verify
@@ -99,21 +143,21 @@ func (e *entry) doInit(publicKey PublicKey, expandedPublicKey *ExpandedPublicKey
 	// Validate A, Deserialize R and S.
 	var compressedA []byte
 	if expandedPublicKey != nil {
-		if ok := vOpts.checkExpandedPublicKey(expandedPublicKey); !ok {
+		if e.err = vOpts.checkExpandedPublicKey(expandedPublicKey); e.err != nil {
 			return
 		}
 		e.expandedA = expandedPublicKey
 		e.negA.SetExpanded(&expandedPublicKey.negA)
 		compressedA = expandedPublicKey.compressed[:]
 	} else {
-		if ok := vOpts.unpackPublicKey(publicKey, &e.negA); !ok {
+		if e.err = vOpts.unpackPublicKey(publicKey, &e.negA); e.err != nil {
 			return
 		}
 		e.expandedA = nil
 		e.negA.Neg(&e.negA)
 		compressedA = publicKey
 	}
-	if ok := vOpts.unpackSignature(sig, &e.R, &e.S); !ok {
+	if e.err = vOpts.unpackSignature(sig, &e.R, &e.S); e.err != nil {
 		return
 	}
 
@@ -125,7 +169,7 @@ func (e *entry) doInit(publicKey PublicKey, expandedPublicKey *ExpandedPublicKey
 		h    = sha512.New()
 	)
 
-	if f, err = checkHash(fBase, message, opts.HashFunc()); err != nil {
+	if f, e.err = checkHash(fBase, message, opts.HashFunc()); e.err != nil {
 		return
 	}
 
@@ -137,6 +181,7 @@ func (e *entry) doInit(publicKey PublicKey, expandedPublicKey *ExpandedPublicKey
 	_, _ = h.Write(message)
 	h.Sum(hash[:0])
 	if _, err = e.hram.SetBytesModOrderWide(hash[:]); err != nil {
+		e.err = fmt.Errorf("ed25519: failed to deserialize H(R,A,m) scalar: %w", err)
 		return
 	}
 
@@ -145,6 +190,19 @@ func (e *entry) doInit(publicKey PublicKey, expandedPublicKey *ExpandedPublicKey
 	e.canBeValid = true
 }
 
+func (e *entry) doInitMaybeExpand(publicKey PublicKey, message, sig []byte, opts *Options, expand bool) {
+	if expand {
+		// The error is explicitly discarded as doInit will do the
+		// right thing if the public key is nil.
+		expandedPublicKey, _ := NewExpandedPublicKey(publicKey)
+		e.doInit(nil, expandedPublicKey, message, sig, opts)
+		return
+	}
+
+	// Initialize the entry without the expanded public key.
+	e.doInit(publicKey, nil, message, sig, opts)
+}
+
 // Add adds a (public key, message, sig) triple to the current batch.
 func (v *BatchVerifier) Add(publicKey PublicKey, message, sig []byte) {
 	v.AddWithOptions(publicKey, message, sig, optionsDefault)
@@ -155,24 +213,12 @@ func (v *BatchVerifier) Add(publicKey PublicKey, message, sig []byte) {
 //
 // WARNING: This routine will panic if opts is nil.
 func (v *BatchVerifier) AddWithOptions(publicKey PublicKey, message, sig []byte, opts *Options) {
-	// If everything added so far has an expanded public key, and the batch
-	// is not too large, then expand the public key for increased performance.
-	if v.precomputeOk() {
-		// The error is explicitly discarded as doInit will do the
-		// right thing if the public key is nil.
-		precomputedPublicKey, _ := NewExpandedPublicKey(publicKey)
-		v.AddExpandedWithOptions(precomputedPublicKey, message, sig, opts)
-		return
-	}
-
-	// Add an entry without the expanded public key.
 	var e entry
 
-	e.doInit(publicKey, nil, message, sig, opts)
-	v.anyInvalid = v.anyInvalid || !e.canBeValid
-	v.anyCofactorless = v.anyCofactorless || e.wantCofactorless
-	v.anyNotExpanded = true
-	v.entries = append(v.entries, e)
+	// If everything added so far has an expanded public key, and the batch
+	// is not too large, then expand the public key for increased performance.
+	e.doInitMaybeExpand(publicKey, message, sig, opts, v.precomputeOk())
+	v.addEntry(&e)
 }
 
 // AddExpanded adds a (expanded public key, message, sig) triple to the
@@ -189,15 +235,20 @@ func (v *BatchVerifier) AddExpandedWithOptions(publicKey *ExpandedPublicKey, mes
 	var e entry
 
 	e.doInit(nil, publicKey, message, sig, opts)
+	v.addEntry(&e)
+}
+
+func (v *BatchVerifier) addEntry(e *entry) {
 	v.anyInvalid = v.anyInvalid || !e.canBeValid
 	v.anyCofactorless = v.anyCofactorless || e.wantCofactorless
-	v.anyNotExpanded = v.anyNotExpanded || e.expandedA == nil // Can be omitted.
-	v.entries = append(v.entries, e)
+	v.anyNotExpanded = v.anyNotExpanded || e.expandedA == nil
+	v.entries = append(v.entries, *e)
 }
 
 // VerifyBatchOnly checks all entries in the current batch using entropy
 // from rand, returning true if all entries are valid and false if any one
 // entry is invalid.  If rand is nil, crypto/rand.Reader will be used.
+// If the batch is deterministic, rand is ignored.
 //
 // If a failure arises it is unknown which entry failed, the caller must
 // verify each entry individually.
@@ -205,17 +256,9 @@ func (v *BatchVerifier) AddExpandedWithOptions(publicKey *ExpandedPublicKey, mes
 // Calling VerifyBatchOnly on an empty batch, or a batch containing any
 // entries that specify cofactor-less verification will return false.
 func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
-	if rand == nil {
-		rand = cryptorand.Reader
-	}
-
-	vl := len(v.entries)
-	numDynamic := 1 + vl
-	numTerms := numDynamic + vl
-
 	// Handle some early aborts.
 	switch {
-	case vl == 0:
+	case len(v.entries) == 0:
 		// Abort early on an empty batch, which probably indicates a bug
 		return false
 	case v.anyInvalid:
@@ -228,10 +271,17 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 		return false
 	}
 
-	zGen, err := scalar128.NewGenerator(rand)
-	if err != nil {
-		panic("ed25519: failed to initialize random scalar generator: " + err.Error())
-	}
+	return verifyBatchEntries(newZGenerator(v.randSource(rand)), v.entries, v.precomputeOk())
+}
+
+// verifyBatchEntries checks entries with the batch verification equation,
+// using z_i from zGen.  All entries MUST be able to be valid, and MUST not
+// request cofactorless verification.  If isPrecomputed is set, the
+// precomputed -A of each entry is used.
+func verifyBatchEntries(zGen *scalar128.Generator, entries []entry, isPrecomputed bool) bool {
+	vl := len(entries)
+	numDynamic := 1 + vl
+	numTerms := numDynamic + vl
 
 	// The batch verification equation is
 	//
@@ -266,8 +316,6 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 		As       []*curve.EdwardsPoint
 	)
 
-	isPrecomputed := v.precomputeOk()
-
 	if isPrecomputed {
 		points = make([]*curve.EdwardsPoint, numDynamic)   // B | Rs
 		staticAs = make([]*curve.ExpandedEdwardsPoint, vl) // As
@@ -278,9 +326,9 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 	points[0] = curve.ED25519_BASEPOINT_POINT // B
 	Rs = points[1 : 1+vl]
 
-	for i := range v.entries {
-		// Avoid range copying each v.entries[i] literal.
-		entry := &v.entries[i]
+	for i := range entries {
+		// Avoid range copying each entries[i] literal.
+		entry := &entries[i]
 		Rs[i] = &entry.R
 		if isPrecomputed {
 			staticAs[i] = &entry.expandedA.negA
@@ -288,7 +336,7 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 			As[i] = &entry.negA
 		}
 
-		if err = zGen.SetScalarVartime(Rcoeffs[i]); err != nil {
+		if err := zGen.SetScalarVartime(Rcoeffs[i]); err != nil {
 			panic("ed25519: failed to generate z_i: " + err.Error())
 		}
 
@@ -320,9 +368,11 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 
 // Verify checks all entries in the current batch using entropy from rand,
 // returning true if all entries in the current bach are valid.  If one or
-// more signature is invalid, each entry in the batch will be verified
-// serially, and the returned bit-vector will provide information about
-// each individual entry.  If rand is nil, crypto/rand.Reader will be used.
+// more signature is invalid, the invalid entries will be identified with
+// the batch's FallbackStrategy, and the returned bit-vector will provide
+// information about each individual entry.  If rand is nil,
+// crypto/rand.Reader will be used.  If the batch is deterministic, rand
+// is ignored.
 //
 // Note: This method is only faster than individually verifying each
 // signature if every signature is valid.  That said, this method will
@@ -334,18 +384,60 @@ func (v *BatchVerifier) Verify(rand io.Reader) (bool, []bool) {
 		return false, nil
 	}
 
-	// Start by assuming everything is valid, unless we know for sure
-	// otherwise (ie: public key/signature/options were malformed).
 	valid := make([]bool, vl)
-	for i := range v.entries {
-		valid[i] = v.entries[i].canBeValid
+	allValid := v.verify(rand, func(i int, err error) {
+		valid[i] = err == nil
+	})
+
+	return allValid, valid
+}
+
+// VerifyWithErrors checks all entries in the current batch using entropy
+// from rand, returning true if all entries in the current batch are valid.
+// If one or more signature is invalid, the invalid entries will be
+// identified with the batch's FallbackStrategy, and the returned slice
+// will contain nil for each valid entry, or the reason why the entry was
+// rejected.  If rand is nil, crypto/rand.Reader will be used.  If the
+// batch is deterministic, rand is ignored.  See VerifyWithError for
+// details on the per-entry errors.
+func (v *BatchVerifier) VerifyWithErrors(rand io.Reader) (bool, []error) {
+	vl := len(v.entries)
+	if vl == 0 {
+		return false, nil
+	}
+
+	errs := make([]error, vl)
+	allValid := v.verify(rand, func(i int, err error) {
+		errs[i] = err
+	})
+
+	return allValid, errs
+}
+
+func (v *BatchVerifier) verify(rand io.Reader, setResult func(int, error)) bool {
+	zGen := newZGenerator(v.randSource(rand))
+	canBatch := !v.anyInvalid && !v.anyCofactorless
+
+	return v.verifyEntries(context.Background(), zGen, v.entries, canBatch, v.precomputeOk(), setResult)
+}
+
+// verifyEntries checks entries, using the batch's FallbackStrategy to
+// identify the invalid entries if any.  If ctx is canceled, verification
+// is abandoned, and false is returned.
+func (v *BatchVerifier) verifyEntries(ctx context.Context, zGen *scalar128.Generator, entries []entry, canBatch, isPrecomputed bool, setResult func(int, error)) bool {
+	// Bisection starts with batch verifying the entire batch.
+	if v.useBisect(len(entries)) {
+		return bisectEntries(ctx, zGen, entries, 0, false, setResult)
 	}
 
 	// If batch verification is possible, do the batch verification.
-	if !v.anyInvalid && !v.anyCofactorless {
-		if v.VerifyBatchOnly(rand) {
+	if canBatch {
+		if verifyBatchEntries(zGen, entries, isPrecomputed) {
 			// Fast-path, the entire batch is valid.
-			return true, valid
+			for i := range entries {
+				setResult(i, nil)
+			}
+			return true
 		}
 	}
 
@@ -355,43 +447,201 @@ func (v *BatchVerifier) Verify(rand io.Reader) (bool, []bool) {
 	// Note: In the case of the latter it is still possible for the
 	// entire batch to be valid, but it is incorrect to trust the
 	// batch verification results.
-	allValid := !v.anyInvalid
-	for i := range v.entries {
-		// If the entry is known to be invalid, skip the serial
-		// verification.
-		if !valid[i] {
-			continue
+	return verifySerialEntries(ctx, entries, 0, setResult)
+}
+
+func (v *BatchVerifier) useBisect(n int) bool {
+	switch v.fallback {
+	case FallbackSerial:
+		return false
+	case FallbackBisect:
+		return true
+	default:
+		return n >= autoBisectThreshold
+	}
+}
+
+// bisectEntries checks entries, by batch verifying the entries, and
+// recursively halving the entries on failure.  If knownBad is set, the
+// entries are known to fail batch verification.
+func bisectEntries(ctx context.Context, zGen *scalar128.Generator, entries []entry, off int, knownBad bool, setResult func(int, error)) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if len(entries) <= bisectLeafSize {
+		return verifySerialEntries(ctx, entries, off, setResult)
+	}
+
+	if !knownBad {
+		if canBatch, isPrecomputed := entriesBatchParams(entries); canBatch {
+			if verifyBatchEntries(zGen, entries, isPrecomputed) {
+				for i := range entries {
+					setResult(off+i, nil)
+				}
+				return true
+			}
+		}
+	}
+
+	// If the left half is entirely valid, then the right half must
+	// be the reason why the entries failed batch verification (or
+	// contain entries that are incompatible with batch verification),
+	// so there is no need to check the right half as a whole.
+	mid := len(entries) / 2
+	leftValid := bisectEntries(ctx, zGen, entries[:mid], off, false, setResult)
+	rightValid := bisectEntries(ctx, zGen, entries[mid:], off+mid, leftValid, setResult)
+
+	return leftValid && rightValid
+}
+
+func verifySerialEntries(ctx context.Context, entries []entry, off int, setResult func(int, error)) bool {
+	allValid := true
+	for i := range entries {
+		if ctx.Err() != nil {
+			return false
 		}
+		err := entries[i].verify()
+		setResult(off+i, err)
+		allValid = allValid && err == nil
+	}
+
+	return allValid
+}
+
+// entriesBatchParams returns if entries can be batch verified, and if
+// the batch verification should use the precomputed -A.
+func entriesBatchParams(entries []entry) (bool, bool) {
+	canBatch, allExpanded := true, true
+	for i := range entries {
+		entry := &entries[i]
+		canBatch = canBatch && entry.canBeValid && !entry.wantCofactorless
+		allExpanded = allExpanded && entry.expandedA != nil
+	}
+
+	return canBatch, allExpanded && len(entries) < batchPippengerThreshold
+}
+
+// randSource returns the source of entropy used to derive the z_i.  If
+// the batch is deterministic, this is a transcript RNG bound to every
+// entry (A, R, S, and H(R,A,m)) in the batch, otherwise it is rand.
+func (v *BatchVerifier) randSource(rand io.Reader) io.Reader {
+	if !v.deterministic {
+		return rand
+	}
 
-		// If the entry has -A in expanded form, use the precomputed
-		// multiplies since it will be faster.
+	var (
+		b [8]byte
+		t = merlin.NewTranscript(deterministicBatchLabel)
+	)
+	binary.LittleEndian.PutUint64(b[:], uint64(len(v.entries)))
+	t.AppendMessage("n", b[:])
+
+	var (
+		aBytes, rBytes    curve.CompressedEdwardsY
+		sBytes, hramBytes [scalar.ScalarSize]byte
+	)
+	for i := range v.entries {
 		entry := &v.entries[i]
-		if entry.expandedA != nil {
-			negA := &entry.expandedA.negA
-			if entry.wantCofactorless {
-				var R curve.EdwardsPoint
-				R.ExpandedDoubleScalarMulBasepointVartime(&entry.hram, negA, &entry.S)
-				valid[i] = cofactorlessVerify(&R, entry.signature)
-			} else {
-				var rDiff curve.EdwardsPoint
-				valid[i] = rDiff.ExpandedTripleScalarMulBasepointVartime(&entry.hram, negA, &entry.S, &entry.R).IsSmallOrder()
-			}
-		} else {
-			negA := &entry.negA
-			if entry.wantCofactorless {
-				var R curve.EdwardsPoint
-				R.DoubleScalarMulBasepointVartime(&entry.hram, negA, &entry.S)
-				valid[i] = cofactorlessVerify(&R, entry.signature)
+
+		// A and R are re-encoded here rather than retained from
+		// when the entry was added, as deterministic verification
+		// is the uncommon case.  Entries that failed to decode
+		// are bound with all-zero encodings.
+		aBytes, rBytes = curve.CompressedEdwardsY{}, curve.CompressedEdwardsY{}
+		if entry.canBeValid {
+			if entry.expandedA != nil {
+				aBytes = entry.expandedA.compressed
 			} else {
-				var rDiff curve.EdwardsPoint
-				valid[i] = rDiff.TripleScalarMulBasepointVartime(&entry.hram, negA, &entry.S, &entry.R).IsSmallOrder()
+				var A curve.EdwardsPoint
+				aBytes.SetEdwardsPoint(A.Neg(&entry.negA))
 			}
+			rBytes.SetEdwardsPoint(&entry.R)
+		}
+		if err := entry.S.ToBytes(sBytes[:]); err != nil {
+			panic("ed25519: failed to serialize S: " + err.Error())
+		}
+		if err := entry.hram.ToBytes(hramBytes[:]); err != nil {
+			panic("ed25519: failed to serialize H(R,A,m): " + err.Error())
 		}
 
-		allValid = allValid && valid[i]
+		t.AppendMessage("A", aBytes[:])
+		t.AppendMessage("R", rBytes[:])
+		t.AppendMessage("S", sBytes[:])
+		t.AppendMessage("k", hramBytes[:])
 	}
 
-	return allValid, valid
+	// The transcript is bound to every entry, so the lack of external
+	// entropy is fine.
+	rng, err := t.BuildRng().Finalize(zeroreader.ZeroReader{})
+	if err != nil {
+		panic("ed25519: failed to instantiate deterministic rng: " + err.Error())
+	}
+
+	return rng
+}
+
+func newZGenerator(rand io.Reader) *scalar128.Generator {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	zGen, err := scalar128.NewGenerator(rand)
+	if err != nil {
+		panic("ed25519: failed to initialize random scalar generator: " + err.Error())
+	}
+
+	return zGen
+}
+
+func (e *entry) verify() error {
+	// If the entry is known to be invalid, skip the serial
+	// verification.
+	if !e.canBeValid {
+		return e.err
+	}
+
+	// If the entry has -A in expanded form, use the precomputed
+	// multiplies since it will be faster.
+	if e.expandedA != nil {
+		negA := &e.expandedA.negA
+		if e.wantCofactorless {
+			var R curve.EdwardsPoint
+			R.ExpandedDoubleScalarMulBasepointVartime(&e.hram, negA, &e.S)
+			return cofactorlessVerify(&R, e.signature)
+		}
+
+		var rDiff curve.EdwardsPoint
+		return cofactoredVerify(rDiff.ExpandedTripleScalarMulBasepointVartime(&e.hram, negA, &e.S, &e.R))
+	}
+
+	negA := &e.negA
+	if e.wantCofactorless {
+		var R curve.EdwardsPoint
+		R.DoubleScalarMulBasepointVartime(&e.hram, negA, &e.S)
+		return cofactorlessVerify(&R, e.signature)
+	}
+
+	var rDiff curve.EdwardsPoint
+	return cofactoredVerify(rDiff.TripleScalarMulBasepointVartime(&e.hram, negA, &e.S, &e.R))
+}
+
+// SetDeterministic sets if batch verification will derive the random
+// scalars solely from a Merlin transcript over every entry (public keys,
+// signatures, and messages via H(R,A,m)), instead of using an external
+// entropy source.  This is intended for environments where an entropy
+// source is unavailable, or where verification must be reproducible.
+// This setting will persist across Reset.
+func (v *BatchVerifier) SetDeterministic(deterministic bool) *BatchVerifier {
+	v.deterministic = deterministic
+	return v
+}
+
+// SetFallbackStrategy sets the strategy used to identify the invalid
+// entries when batch verification fails.  This setting will persist
+// across Reset.
+func (v *BatchVerifier) SetFallbackStrategy(strategy FallbackStrategy) *BatchVerifier {
+	v.fallback = strategy
+	return v
 }
 
 // ForceNoPublicKeyExpansion disables the key expansion for a given batch.
@@ -414,8 +664,8 @@ func (v *BatchVerifier) Reset() *BatchVerifier {
 	// Remove the reference to each expanded -A so that they may be
 	// garbage collected.  The pointer will be overwritten on
 	// subsequent batch verify calls.
-	for _, ent := range v.entries {
-		ent.expandedA = nil
+	for i := range v.entries {
+		v.entries[i].expandedA = nil
 	}
 
 	// Allow re-using the existing entries slice.
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ed25519
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/scalar128"
+)
+
+// minParallelSubBatchSize is the minimum sub-batch size used when the
+// sub-batch size is not explicitly specified, as very small sub-batches
+// do not amortize the multiscalar multiply well.
+const minParallelSubBatchSize = 64
+
+// ParallelOptions can be used to specify the behavior of parallel
+// batch verification.
+type ParallelOptions struct {
+	// Workers is the number of goroutines used to verify sub-batches.
+	// If left unspecified, runtime.GOMAXPROCS(0) will be used.
+	Workers int
+
+	// SubBatchSize is the number of entries in each sub-batch.  If
+	// left unspecified, the entries are split evenly between the
+	// workers.
+	SubBatchSize int
+}
+
+func (opts *ParallelOptions) params(vl int) (int, int) {
+	var workers, subBatchSize int
+	if opts != nil {
+		workers, subBatchSize = opts.Workers, opts.SubBatchSize
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if subBatchSize <= 0 {
+		subBatchSize = (vl + workers - 1) / workers
+		if subBatchSize < minParallelSubBatchSize {
+			subBatchSize = minParallelSubBatchSize
+		}
+	}
+
+	return workers, subBatchSize
+}
+
+type subBatch struct {
+	entries []entry
+	off     int
+	zGen    *scalar128.Generator
+}
+
+// VerifyParallel checks all entries in the current batch using entropy
+// from rand, by partitioning the batch into sub-batches that are verified
+// concurrently, returning true if all entries in the current batch are
+// valid.  If a sub-batch fails, only the entries in that sub-batch will
+// be re-checked, and the returned bit-vector will provide information
+// about each individual entry.  If rand is nil, crypto/rand.Reader will
+// be used.  If the batch is deterministic, rand is ignored.  If opts is
+// nil, the default ParallelOptions will be used.
+//
+// Note: The batch's FallbackStrategy is used to identify the invalid
+// entries in each failed sub-batch.
+//
+// If ctx is canceled before verification completes, the context's error
+// will be returned.
+func (v *BatchVerifier) VerifyParallel(ctx context.Context, rand io.Reader, opts *ParallelOptions) (bool, []bool, error) {
+	vl := len(v.entries)
+	if vl == 0 {
+		return false, nil, nil
+	}
+
+	valid := make([]bool, vl)
+	allValid, err := v.verifyParallel(ctx, rand, opts, func(i int, err error) {
+		valid[i] = err == nil
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	return allValid, valid, nil
+}
+
+// VerifyParallelWithErrors is VerifyParallel, except that the returned
+// slice will contain nil for each valid entry, or the reason why the
+// entry was rejected.  See VerifyWithError for details on the per-entry
+// errors.
+func (v *BatchVerifier) VerifyParallelWithErrors(ctx context.Context, rand io.Reader, opts *ParallelOptions) (bool, []error, error) {
+	vl := len(v.entries)
+	if vl == 0 {
+		return false, nil, nil
+	}
+
+	errs := make([]error, vl)
+	allValid, err := v.verifyParallel(ctx, rand, opts, func(i int, err error) {
+		errs[i] = err
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	return allValid, errs, nil
+}
+
+// verifyParallel verifies the batch as per VerifyParallel.  setResult
+// may be called concurrently, though never for the same entry.
+func (v *BatchVerifier) verifyParallel(ctx context.Context, rand io.Reader, opts *ParallelOptions, setResult func(int, error)) (bool, error) {
+	vl := len(v.entries)
+	workers, subBatchSize := opts.params(vl)
+	rand = v.randSource(rand)
+
+	// Partition the batch.  The random scalar generators are created
+	// here, as rand is not guaranteed to be safe for concurrent use.
+	subBatches := make([]subBatch, 0, (vl+subBatchSize-1)/subBatchSize)
+	for off := 0; off < vl; off += subBatchSize {
+		end := off + subBatchSize
+		if end > vl {
+			end = vl
+		}
+
+		subBatches = append(subBatches, subBatch{
+			entries: v.entries[off:end],
+			off:     off,
+			zGen:    newZGenerator(rand),
+		})
+	}
+	if workers > len(subBatches) {
+		workers = len(subBatches)
+	}
+
+	var (
+		wg sync.WaitGroup
+
+		ch = make(chan *subBatch)
+
+		resultsLock sync.Mutex
+		allValid    = true
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sb := range ch {
+				ok := v.verifySubBatch(ctx, sb, setResult)
+
+				resultsLock.Lock()
+				allValid = allValid && ok
+				resultsLock.Unlock()
+			}
+		}()
+	}
+
+dispatchLoop:
+	for i := range subBatches {
+		select {
+		case <-ctx.Done():
+			break dispatchLoop
+		case ch <- &subBatches[i]:
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return allValid, nil
+}
+
+func (v *BatchVerifier) verifySubBatch(ctx context.Context, sb *subBatch, setResult func(int, error)) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	canBatch, isPrecomputed := entriesBatchParams(sb.entries)
+	return v.verifyEntries(ctx, sb.zGen, sb.entries, canBatch, isPrecomputed, func(i int, err error) {
+		setResult(sb.off+i, err)
+	})
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ed25519
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// defaultStreamingMaxBatchSize is the default number of pending entries
+// that will trigger a flush.
+const defaultStreamingMaxBatchSize = 64
+
+// ErrStreamingClosed is the error returned when adding entries to a
+// StreamingBatchVerifier that has been closed.
+var ErrStreamingClosed = errors.New("ed25519: streaming batch verifier closed")
+
+// StreamingOptions can be used to specify the behavior of a
+// StreamingBatchVerifier.
+type StreamingOptions struct {
+	// MaxBatchSize is the number of pending entries that will trigger
+	// a flush.  If left unspecified, a reasonable default will be used.
+	MaxBatchSize int
+
+	// MaxDelay is the maximum amount of time that an entry will be
+	// pending before triggering a flush.  If left unspecified, flushes
+	// will only be triggered by MaxBatchSize, Flush, or Close.
+	MaxDelay time.Duration
+
+	// Rand is the entropy source used for batch verification.  If left
+	// unspecified, crypto/rand.Reader will be used.
+	//
+	// Note: Flushes may happen concurrently, so this MUST be safe for
+	// concurrent use.
+	Rand io.Reader
+
+	// FallbackStrategy is the strategy used to identify the invalid
+	// entries when batch verification fails.
+	FallbackStrategy FallbackStrategy
+}
+
+// StreamingBatchVerifier accumulates entries over time, and batch
+// verifies them when either the number of pending entries reaches
+// a threshold, or the oldest pending entry has waited too long.  Each
+// entry's result is delivered via a callback or a channel.
+//
+// All methods are safe for concurrent use.
+type StreamingBatchVerifier struct {
+	l sync.Mutex
+
+	maxBatchSize int
+	maxDelay     time.Duration
+	rand         io.Reader
+	fallback     FallbackStrategy
+	expand       bool
+
+	batch     *BatchVerifier
+	callbacks []func(error)
+	timer     *time.Timer
+	gen       uint64
+	closed    bool
+
+	inFlight sync.WaitGroup
+	pool     sync.Pool
+}
+
+// Add adds a (public key, message, sig, opts) quad to the pending batch,
+// and calls callback with the result, which is nil iff the signature is
+// valid.  If opts is nil, the default Options will be used.  See
+// VerifyWithError for details on the result.
+//
+// The entry is deserialized and H(R,A,m) is computed immediately, in the
+// calling goroutine, without holding the verifier's lock.  The callback
+// will be invoked from a different goroutine, and MUST not block for
+// extended periods of time.
+func (sv *StreamingBatchVerifier) Add(publicKey PublicKey, message, sig []byte, opts *Options, callback func(error)) error {
+	if opts == nil {
+		opts = optionsDefault
+	}
+
+	// Build the entry before acquiring the lock, so that concurrent
+	// callers are only serialized on appending to the pending batch.
+	var e entry
+	e.doInitMaybeExpand(publicKey, message, sig, opts, sv.expand)
+
+	sv.l.Lock()
+	defer sv.l.Unlock()
+
+	if sv.closed {
+		return ErrStreamingClosed
+	}
+
+	sv.batch.addEntry(&e)
+	sv.callbacks = append(sv.callbacks, callback)
+
+	switch {
+	case len(sv.callbacks) >= sv.maxBatchSize:
+		sv.flushAsyncLocked()
+	case len(sv.callbacks) == 1 && sv.maxDelay > 0:
+		gen := sv.gen
+		sv.timer = time.AfterFunc(sv.maxDelay, func() {
+			sv.l.Lock()
+			defer sv.l.Unlock()
+
+			// Only flush if the batch that started the timer
+			// is still pending.
+			if sv.gen == gen && !sv.closed {
+				sv.flushAsyncLocked()
+			}
+		})
+	}
+
+	return nil
+}
+
+// AddChan adds a (public key, message, sig, opts) quad to the pending
+// batch, and returns a channel that the result will be written to.
+// See Add for details.
+func (sv *StreamingBatchVerifier) AddChan(publicKey PublicKey, message, sig []byte, opts *Options) (<-chan error, error) {
+	ch := make(chan error, 1)
+	if err := sv.Add(publicKey, message, sig, opts, func(err error) {
+		ch <- err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Flush synchronously verifies all of the pending entries, and delivers
+// the results.
+func (sv *StreamingBatchVerifier) Flush() {
+	sv.l.Lock()
+	batch, callbacks := sv.takePendingLocked()
+	sv.l.Unlock()
+
+	sv.verify(batch, callbacks)
+}
+
+// Close flushes the pending entries, and waits for all in-progress
+// verifications to complete.  Entries may not be added after the
+// verifier has been closed.
+func (sv *StreamingBatchVerifier) Close() {
+	sv.l.Lock()
+	sv.closed = true
+	batch, callbacks := sv.takePendingLocked()
+	sv.l.Unlock()
+
+	sv.verify(batch, callbacks)
+	sv.inFlight.Wait()
+}
+
+func (sv *StreamingBatchVerifier) flushAsyncLocked() {
+	batch, callbacks := sv.takePendingLocked()
+	if batch == nil {
+		return
+	}
+
+	sv.inFlight.Add(1)
+	go func() {
+		defer sv.inFlight.Done()
+		sv.verify(batch, callbacks)
+	}()
+}
+
+func (sv *StreamingBatchVerifier) takePendingLocked() (*BatchVerifier, []func(error)) {
+	if sv.timer != nil {
+		sv.timer.Stop()
+		sv.timer = nil
+	}
+	if len(sv.callbacks) == 0 {
+		return nil, nil
+	}
+
+	batch, callbacks := sv.batch, sv.callbacks
+	sv.batch, sv.callbacks = sv.getBatch(), nil
+	sv.gen++
+
+	return batch, callbacks
+}
+
+func (sv *StreamingBatchVerifier) verify(batch *BatchVerifier, callbacks []func(error)) {
+	if batch == nil {
+		return
+	}
+
+	_, errs := batch.VerifyWithErrors(sv.rand)
+	for i, cb := range callbacks {
+		if cb != nil {
+			cb(errs[i])
+		}
+	}
+
+	sv.pool.Put(batch.Reset())
+}
+
+func (sv *StreamingBatchVerifier) getBatch() *BatchVerifier {
+	if batch, ok := sv.pool.Get().(*BatchVerifier); ok {
+		return batch
+	}
+
+	return NewBatchVerifierWithCapacity(sv.maxBatchSize).SetFallbackStrategy(sv.fallback)
+}
+
+// NewStreamingBatchVerifier creates a new StreamingBatchVerifier.  If
+// opts is nil, the default StreamingOptions will be used.
+func NewStreamingBatchVerifier(opts *StreamingOptions) *StreamingBatchVerifier {
+	if opts == nil {
+		opts = &StreamingOptions{}
+	}
+
+	sv := &StreamingBatchVerifier{
+		maxBatchSize: opts.MaxBatchSize,
+		maxDelay:     opts.MaxDelay,
+		rand:         opts.Rand,
+		fallback:     opts.FallbackStrategy,
+	}
+	if sv.maxBatchSize <= 0 {
+		sv.maxBatchSize = defaultStreamingMaxBatchSize
+	}
+
+	// Flushes are triggered before a batch grows past maxBatchSize,
+	// so whether the public keys should be expanded (as is done by
+	// AddWithOptions) can be decided up front.
+	sv.expand = sv.maxBatchSize < batchPippengerThreshold
+	sv.batch = sv.getBatch()
+
+	return sv
+}
@@ -30,9 +30,18 @@
 package ed25519
 
 import (
+	"bytes"
+	"context"
 	"crypto"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
 )
 
 var benchBatchSizes = []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 384, 512, 768, 1024}
@@ -269,6 +278,88 @@ func checkBatchResult(t *testing.T, v *BatchVerifier, opts *Options, details str
 			t.Fatalf("bit-vector %d incorrect (Got: %v)", i, sigValid)
 		}
 	}
+
+	// Test that each fallback strategy returns identical results.
+	for _, strategy := range []FallbackStrategy{FallbackSerial, FallbackBisect} {
+		allValid2, valid2 := v.SetFallbackStrategy(strategy).Verify(nil)
+		if allValid2 != allValid {
+			t.Fatalf("Verify(%d) returned incorrect summary (Got: %v)", strategy, allValid2)
+		}
+		for i := range valid {
+			if valid[i] != valid2[i] {
+				t.Fatalf("Verify(%d) bit-vector %d incorrect (Got: %v)", strategy, i, valid2[i])
+			}
+		}
+	}
+	v.SetFallbackStrategy(FallbackAuto)
+
+	// Test that parallel verification, with sub-batches small enough
+	// to have both valid and invalid sub-batches, matches.
+	allValid, valid, err := v.VerifyParallel(context.Background(), nil, &ParallelOptions{
+		Workers:      3,
+		SubBatchSize: 5,
+	})
+	if err != nil {
+		t.Fatalf("VerifyParallel: %v", err)
+	}
+	if allValid != expectedVerifyOk {
+		t.Fatalf("VerifyParallel returned incorrect summary (Got: %v)", allValid)
+	}
+	for i, sigValid := range valid {
+		expectedSigOk := i != badIndex
+		if sigValid != expectedSigOk {
+			t.Fatalf("parallel bit-vector %d incorrect (Got: %v)", i, sigValid)
+		}
+	}
+
+	// Test that deterministic batch verification returns the same
+	// results, without touching the entropy source.
+	brokenRand := iotest.ErrReader(errors.New("entropy source used"))
+	v.SetDeterministic(true)
+	if v.VerifyBatchOnly(brokenRand) != expectedBatchOk {
+		t.Fatalf("deterministic: %s", details)
+	}
+	allValid, valid = v.Verify(brokenRand)
+	if allValid != expectedVerifyOk {
+		t.Fatalf("deterministic Verify returned incorrect summary (Got: %v)", allValid)
+	}
+	for i, sigValid := range valid {
+		expectedSigOk := i != badIndex
+		if sigValid != expectedSigOk {
+			t.Fatalf("deterministic bit-vector %d incorrect (Got: %v)", i, sigValid)
+		}
+	}
+	if _, _, err = v.VerifyParallel(context.Background(), brokenRand, nil); err != nil {
+		t.Fatalf("deterministic VerifyParallel: %v", err)
+	}
+	v.SetDeterministic(false)
+
+	// Finally test that the per-entry errors match.
+	allValid, errs := v.VerifyWithErrors(nil)
+	if allValid != expectedVerifyOk {
+		t.Fatalf("VerifyWithErrors returned incorrect summary (Got: %v)", allValid)
+	}
+	for i, err := range errs {
+		expectedSigOk := i != badIndex
+		if (err == nil) != expectedSigOk {
+			t.Fatalf("error %d incorrect (Got: %v)", i, err)
+		}
+	}
+	allValid, parallelErrs, err := v.VerifyParallelWithErrors(context.Background(), nil, &ParallelOptions{
+		Workers:      3,
+		SubBatchSize: 5,
+	})
+	if err != nil {
+		t.Fatalf("VerifyParallelWithErrors: %v", err)
+	}
+	if allValid != expectedVerifyOk {
+		t.Fatalf("VerifyParallelWithErrors returned incorrect summary (Got: %v)", allValid)
+	}
+	for i := range errs {
+		if !errors.Is(parallelErrs[i], errs[i]) {
+			t.Fatalf("parallel error %d incorrect (Got: %v, expected: %v)", i, parallelErrs[i], errs[i])
+		}
+	}
 }
 
 func TestBatchVerifier(t *testing.T) {
@@ -336,12 +427,152 @@ func TestBatchVerifier(t *testing.T) {
 		}, false)
 	})
 
+	t.Run("Errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			tc       *batchVerifierTestCase
+			expected error
+		}{
+			{batchTestCases[1], ErrMalformedSignature},
+			{batchTestCases[5], ErrCofactoredEquation},
+			{&batchVerifierTestCase{"FailsOnMalformedCtx", batchMalformedCtx, 7, ""}, ErrBadContextLength},
+		} {
+			v := tc.tc.makeVerifier(t, nil, false)
+			_, errs := v.VerifyWithErrors(nil)
+			if err := errs[tc.tc.culpritIdx]; !errors.Is(err, tc.expected) {
+				t.Fatalf("%s: expected %v, got %v", tc.tc.n, tc.expected, err)
+			}
+		}
+	})
+
 	t.Run("EmptyBatchFails", func(t *testing.T) {
 		v := NewBatchVerifier()
 
 		if v.VerifyBatchOnly(nil) {
 			t.Error("batch verification should fail on an empty batch")
 		}
+		if ok, _, _ := v.VerifyParallel(context.Background(), nil, nil); ok {
+			t.Error("parallel batch verification should fail on an empty batch")
+		}
+	})
+	t.Run("Bisect", func(t *testing.T) {
+		const n = 300
+
+		pub, priv, err := GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("failed to GenerateKey: %v", err)
+		}
+		msg := []byte("BisectTest")
+		sig := Sign(priv, msg)
+
+		badIndexes := map[int]bool{
+			0:     true,
+			17:    true,
+			18:    true,
+			150:   true,
+			n - 1: true,
+		}
+		cofactorlessIndex := 200
+
+		v := NewBatchVerifier()
+		for i := 0; i < n; i++ {
+			switch {
+			case badIndexes[i] && i%2 == 0:
+				v.Add(pub, []byte("wrong message"), sig)
+			case badIndexes[i]:
+				v.Add(pub, msg, sig[:SignatureSize-1])
+			case i == cofactorlessIndex:
+				v.AddWithOptions(pub, msg, sig, &Options{Verify: VerifyOptionsStdLib})
+			default:
+				v.Add(pub, msg, sig)
+			}
+		}
+
+		allValid, serialValid := v.SetFallbackStrategy(FallbackSerial).Verify(nil)
+		if allValid {
+			t.Fatalf("Verify(FallbackSerial) returned incorrect summary")
+		}
+		for _, strategy := range []FallbackStrategy{FallbackAuto, FallbackBisect} {
+			allValid, valid := v.SetFallbackStrategy(strategy).Verify(nil)
+			if allValid {
+				t.Fatalf("Verify(%d) returned incorrect summary", strategy)
+			}
+			for i := range valid {
+				if valid[i] == badIndexes[i] {
+					t.Fatalf("Verify(%d) bit-vector %d incorrect (Got: %v)", strategy, i, valid[i])
+				}
+				if valid[i] != serialValid[i] {
+					t.Fatalf("Verify(%d) bit-vector %d mismatch with serial", strategy, i)
+				}
+			}
+		}
+	})
+	t.Run("Deterministic", func(t *testing.T) {
+		v := batchTestCases[0].makeVerifier(t, nil, false).SetDeterministic(true)
+
+		readZ := func() []byte {
+			var b [32]byte
+			_, _ = io.ReadFull(v.randSource(nil), b[:])
+			return b[:]
+		}
+
+		z := readZ()
+		if !bytes.Equal(z, readZ()) {
+			t.Fatalf("deterministic randomizers are not reproducible")
+		}
+
+		// Changing any entry must change the randomizers.
+		v.entries[7].S.Add(&v.entries[7].S, scalar.One())
+		if bytes.Equal(z, readZ()) {
+			t.Fatalf("deterministic randomizers are not bound to S")
+		}
+		v.Reset()
+		if !v.deterministic {
+			t.Fatalf("Reset cleared the deterministic flag")
+		}
+	})
+	t.Run("Parallel/Canceled", func(t *testing.T) {
+		v := batchTestCases[0].makeVerifier(t, nil, false)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ok, valid, err := v.VerifyParallel(ctx, nil, nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("VerifyParallel: expected context.Canceled, got %v", err)
+		}
+		if ok || valid != nil {
+			t.Fatalf("VerifyParallel returned results after cancellation")
+		}
+	})
+	t.Run("Parallel/CanceledFallback", func(t *testing.T) {
+		pub, priv, err := GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("failed to GenerateKey: %v", err)
+		}
+		msg := []byte("CanceledFallbackTest")
+		sig := Sign(priv, msg)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// The sub-batch fallback must stop once the context is
+		// canceled, regardless of the strategy.
+		for _, strategy := range []FallbackStrategy{FallbackSerial, FallbackBisect} {
+			v := NewBatchVerifier().SetFallbackStrategy(strategy)
+			for i := 0; i < 2*bisectLeafSize; i++ {
+				v.Add(pub, msg, sig)
+			}
+			v.Add(pub, []byte("wrong message"), sig)
+
+			var n int
+			canBatch, isPrecomputed := entriesBatchParams(v.entries)
+			if v.verifyEntries(ctx, newZGenerator(nil), v.entries, canBatch, isPrecomputed, func(int, error) { n++ }) {
+				t.Fatalf("verifyEntries(%v): accepted invalid batch", strategy)
+			}
+			if n != 0 {
+				t.Fatalf("verifyEntries(%v): checked %d entries after cancellation", strategy, n)
+			}
+		}
 	})
 	t.Run("Reset", func(t *testing.T) {
 		v := NewBatchVerifier()
@@ -363,6 +594,7 @@ func TestBatchVerifier(t *testing.T) {
 		v.AddWithOptions(pub, msg, nil, &Options{
 			Verify: VerifyOptionsStdLib,
 		})
+		n := len(v.entries)
 
 		v.Reset()
 		if len(v.entries) != 0 {
@@ -373,6 +605,11 @@ func TestBatchVerifier(t *testing.T) {
 			// mercy of how stdlib reallocs.
 			t.Fatalf("Reset did not preserve entries backing store")
 		}
+		for i, e := range v.entries[:n] {
+			if e.expandedA != nil {
+				t.Fatalf("Reset did not clear expandedA for entry %d", i)
+			}
+		}
 		if v.anyInvalid != false {
 			t.Fatalf("Reset did not clear anyInvalid")
 		}
@@ -433,12 +670,184 @@ func TestBatchVerifier(t *testing.T) {
 	})
 }
 
+func TestStreamingBatchVerifier(t *testing.T) {
+	pub, priv, err := GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to GenerateKey: %v", err)
+	}
+	msg := []byte("StreamingTest")
+	sig := Sign(priv, msg)
+
+	t.Run("MaxBatchSize", func(t *testing.T) {
+		const n = 50
+
+		sv := NewStreamingBatchVerifier(&StreamingOptions{
+			MaxBatchSize: 8,
+		})
+
+		var (
+			l       sync.Mutex
+			results = make(map[int]error)
+		)
+		for i := 0; i < n; i++ {
+			i := i
+			m := msg
+			if i%7 == 3 {
+				m = []byte("wrong message")
+			}
+			if err := sv.Add(pub, m, sig, nil, func(err error) {
+				l.Lock()
+				defer l.Unlock()
+				results[i] = err
+			}); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		sv.Close()
+
+		if len(results) != n {
+			t.Fatalf("missing results: %d (expected %d)", len(results), n)
+		}
+		for i := 0; i < n; i++ {
+			expected := error(nil)
+			if i%7 == 3 {
+				expected = ErrCofactoredEquation
+			}
+			if err := results[i]; !errors.Is(err, expected) {
+				t.Fatalf("result %d incorrect (Got: %v)", i, err)
+			}
+		}
+
+		if err := sv.Add(pub, msg, sig, nil, nil); !errors.Is(err, ErrStreamingClosed) {
+			t.Fatalf("Add after Close: %v", err)
+		}
+	})
+	t.Run("MaxDelay", func(t *testing.T) {
+		sv := NewStreamingBatchVerifier(&StreamingOptions{
+			MaxDelay: 10 * time.Millisecond,
+		})
+		defer sv.Close()
+
+		ch, err := sv.AddChan(pub, msg, sig, nil)
+		if err != nil {
+			t.Fatalf("AddChan: %v", err)
+		}
+		select {
+		case err = <-ch:
+			if err != nil {
+				t.Fatalf("valid signature rejected: %v", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("entry was not flushed after MaxDelay")
+		}
+	})
+	t.Run("Flush", func(t *testing.T) {
+		sv := NewStreamingBatchVerifier(nil)
+		defer sv.Close()
+
+		ch, err := sv.AddChan(pub, msg, sig[:SignatureSize-1], nil)
+		if err != nil {
+			t.Fatalf("AddChan: %v", err)
+		}
+		sv.Flush()
+		select {
+		case err = <-ch:
+			if !errors.Is(err, ErrMalformedSignature) {
+				t.Fatalf("malformed signature result incorrect (Got: %v)", err)
+			}
+		default:
+			t.Fatalf("entry was not flushed by Flush")
+		}
+	})
+	t.Run("Expand", func(t *testing.T) {
+		// Public keys are only worth expanding if the full batch
+		// will not be verified with Pippenger's method.
+		for _, tc := range []struct {
+			maxBatchSize int
+			expand       bool
+		}{
+			{batchPippengerThreshold - 1, true},
+			{batchPippengerThreshold, false},
+		} {
+			sv := NewStreamingBatchVerifier(&StreamingOptions{
+				MaxBatchSize: tc.maxBatchSize,
+			})
+			if sv.expand != tc.expand {
+				t.Errorf("MaxBatchSize %d: expand %v (expected %v)", tc.maxBatchSize, sv.expand, tc.expand)
+			}
+			sv.Close()
+		}
+	})
+}
+
 func BenchmarkVerifyBatchOnly(b *testing.B) {
 	for _, n := range benchBatchSizes {
 		doBenchVerifyBatchOnly(b, n, false)
 	}
 }
 
+func BenchmarkVerifyParallel(b *testing.B) {
+	pub, priv, _ := GenerateKey(nil)
+	msg := []byte("BatchVerifyTest")
+	sig := Sign(priv, msg)
+
+	for _, n := range []int{1024, 8192} {
+		v := NewBatchVerifierWithCapacity(n)
+		for j := 0; j < n; j++ {
+			v.Add(pub, msg, sig)
+		}
+
+		b.Run(fmt.Sprintf("%d/Serial", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !v.VerifyBatchOnly(nil) {
+					b.Fatal("signature set failed batch verification")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%d/Parallel", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if ok, _, _ := v.VerifyParallel(context.Background(), nil, nil); !ok {
+					b.Fatal("signature set failed parallel batch verification")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyFallback(b *testing.B) {
+	pub, priv, _ := GenerateKey(nil)
+	msg := []byte("BatchVerifyTest")
+	sig := Sign(priv, msg)
+
+	for _, n := range []int{64, 1024} {
+		v := NewBatchVerifierWithCapacity(n)
+		for j := 0; j < n; j++ {
+			if j == n/3 {
+				v.Add(pub, []byte("wrong message"), sig)
+				continue
+			}
+			v.Add(pub, msg, sig)
+		}
+
+		for _, strategy := range []struct {
+			n        string
+			strategy FallbackStrategy
+		}{
+			{"Serial", FallbackSerial},
+			{"Bisect", FallbackBisect},
+		} {
+			b.Run(fmt.Sprintf("%d/%s", n, strategy.n), func(b *testing.B) {
+				v.SetFallbackStrategy(strategy.strategy)
+				for i := 0; i < b.N; i++ {
+					if ok, _ := v.Verify(nil); ok {
+						b.Fatal("invalid signature set passed batch verification")
+					}
+				}
+			})
+		}
+	}
+}
+
 func doBenchVerifyBatchOnly(b *testing.B, n int, expanded bool) {
 	// Note: Comparative benchmarks are kind of hard to do, especially
 	// against ed25519consensus, which excludes building BatchVerifier
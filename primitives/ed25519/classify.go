@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ed25519
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Implementation identifies an Ed25519 implementation, with known
+// signature acceptance behavior.
+type Implementation int
+
+const (
+	// ImplementationDefault is this package's default behavior.
+	ImplementationDefault Implementation = iota
+	// ImplementationStdLib is the Go `crypto/ed25519` package.
+	ImplementationStdLib
+	// ImplementationFIPS_186_5 is FIPS 186-5 (and RFC 8032).
+	ImplementationFIPS_186_5
+	// ImplementationZIP_215 is ZIP-215 (eg: ed25519-zebra).
+	ImplementationZIP_215
+	// ImplementationLibsodium is libsodium.
+	ImplementationLibsodium
+	// ImplementationDalek is ed25519-dalek's `verify`.
+	ImplementationDalek
+	// ImplementationDalekStrict is ed25519-dalek's `verify_strict`.
+	ImplementationDalekStrict
+	// ImplementationBoringSSL is BoringSSL.
+	ImplementationBoringSSL
+
+	numImplementations
+)
+
+var implementations = [numImplementations]struct {
+	name  string
+	vOpts *VerifyOptions
+}{
+	ImplementationDefault:     {"default", VerifyOptionsDefault},
+	ImplementationStdLib:      {"stdlib", VerifyOptionsStdLib},
+	ImplementationFIPS_186_5:  {"FIPS-186-5", VerifyOptionsFIPS_186_5},
+	ImplementationZIP_215:     {"ZIP-215", VerifyOptionsZIP_215},
+	ImplementationLibsodium:   {"libsodium", VerifyOptionsLibsodium},
+	ImplementationDalek:       {"dalek", VerifyOptionsDalek},
+	ImplementationDalekStrict: {"dalek-strict", VerifyOptionsDalekStrict},
+	ImplementationBoringSSL:   {"BoringSSL", VerifyOptionsBoringSSL},
+}
+
+// Implementations returns all of the known implementations.
+func Implementations() []Implementation {
+	impls := make([]Implementation, 0, numImplementations)
+	for impl := Implementation(0); impl < numImplementations; impl++ {
+		impls = append(impls, impl)
+	}
+	return impls
+}
+
+// String returns the name of the implementation.
+func (impl Implementation) String() string {
+	if impl < 0 || impl >= numImplementations {
+		return "[unknown implementation]"
+	}
+	return implementations[impl].name
+}
+
+// VerifyOptions returns the VerifyOptions preset that matches the
+// implementation's behavior, or nil if the implementation is unknown.
+func (impl Implementation) VerifyOptions() *VerifyOptions {
+	if impl < 0 || impl >= numImplementations {
+		return nil
+	}
+	return implementations[impl].vOpts
+}
+
+// AcceptanceReport is the result of classifying a signature against
+// all of the known implementations.
+type AcceptanceReport struct {
+	results [numImplementations]error
+}
+
+// Accepted returns true iff the implementation would accept the
+// signature.
+func (r *AcceptanceReport) Accepted(impl Implementation) bool {
+	return impl >= 0 && impl < numImplementations && r.results[impl] == nil
+}
+
+// Err returns the reason why the implementation would reject the
+// signature, or nil if the signature would be accepted.  See
+// VerifyWithError for details on the returned error.
+func (r *AcceptanceReport) Err(impl Implementation) error {
+	if impl < 0 || impl >= numImplementations {
+		return fmt.Errorf("ed25519: unknown implementation: %d", impl)
+	}
+	return r.results[impl]
+}
+
+// Unanimous returns true iff all of the known implementations agree
+// on the validity of the signature.
+func (r *AcceptanceReport) Unanimous() bool {
+	for _, err := range r.results[1:] {
+		if (err == nil) != (r.results[0] == nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a human readable summary of the report.
+func (r *AcceptanceReport) String() string {
+	var b strings.Builder
+	for impl, err := range r.results {
+		if impl > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(Implementation(impl).String())
+		if err == nil {
+			b.WriteString(": accept")
+		} else {
+			b.WriteString(": reject")
+		}
+	}
+	return b.String()
+}
+
+// ClassifyAcceptance determines which of the known implementations would
+// accept sig as a valid Ed25519 signature by publicKey.  If opts is nil,
+// plain Ed25519 will be used, otherwise opts is used to select the
+// Ed25519 variant, and opts.Verify is ignored.
+//
+// Note: Each implementation's behavior is modeled by the corresponding
+// VerifyOptions preset, which has been validated against the test cases
+// from "Taming the many EdDSAs" by Chalkias, Garillot, and Nikolaenko.
+func ClassifyAcceptance(publicKey PublicKey, message, sig []byte, opts *Options) *AcceptanceReport {
+	var implOpts Options
+	if opts != nil {
+		implOpts = *opts
+	}
+
+	var r AcceptanceReport
+	for impl := range r.results {
+		implOpts.Verify = implementations[impl].vOpts
+		r.results[impl] = VerifyWithError(publicKey, message, sig, &implOpts)
+	}
+
+	return &r
+}
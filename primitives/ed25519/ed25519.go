@@ -47,6 +47,7 @@ import (
 	"crypto"
 	cryptorand "crypto/rand"
 	"crypto/sha512"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -77,6 +78,63 @@ const (
 )
 
 var (
+	// ErrMalformedPublicKey is the error returned when the public key
+	// (A) is the wrong length or fails to decompress.
+	ErrMalformedPublicKey = errors.New("ed25519: malformed public key")
+
+	// ErrSmallOrderPublicKey is the error returned when the public key
+	// (A) is a small order point.
+	ErrSmallOrderPublicKey = errors.New("ed25519: small order public key")
+
+	// ErrNonCanonicalPublicKey is the error returned when the public
+	// key (A) is not canonically encoded.
+	ErrNonCanonicalPublicKey = errors.New("ed25519: non-canonical public key")
+
+	// ErrMalformedSignature is the error returned when the signature
+	// is the wrong length, or R fails to decompress.
+	ErrMalformedSignature = errors.New("ed25519: malformed signature")
+
+	// ErrSmallOrderR is the error returned when the signature's R
+	// component is a small order point.
+	ErrSmallOrderR = errors.New("ed25519: small order R")
+
+	// ErrNonCanonicalR is the error returned when the signature's R
+	// component is not canonically encoded.
+	ErrNonCanonicalR = errors.New("ed25519: non-canonical R")
+
+	// ErrNonCanonicalS is the error returned when the signature's S
+	// component is not less than the group order.
+	ErrNonCanonicalS = errors.New("ed25519: non-canonical S")
+
+	// ErrCofactoredEquation is the error returned when the cofactored
+	// verification equation does not hold.
+	ErrCofactoredEquation = errors.New("ed25519: cofactored verification equation failed")
+
+	// ErrCofactorlessMismatch is the error returned when the
+	// cofactorless verification equation result does not match the
+	// signature's R component.
+	ErrCofactorlessMismatch = errors.New("ed25519: cofactorless verification mismatch")
+
+	// ErrBadContextLength is the error returned when the Ed25519ctx or
+	// Ed25519ph context is longer than ContextMaxSize.
+	ErrBadContextLength = errors.New("ed25519: bad context length")
+
+	errBadHashFunc = errors.New("ed25519: expected opts HashFunc zero (unhashed message, for Ed25519/Ed25519ctx) or SHA-512 (for Ed25519ph)")
+
+	// signatureErrors are the errors that indicate that a signature
+	// is invalid, as opposed to the verification being malformed.
+	signatureErrors = []error{
+		ErrMalformedPublicKey,
+		ErrSmallOrderPublicKey,
+		ErrNonCanonicalPublicKey,
+		ErrMalformedSignature,
+		ErrSmallOrderR,
+		ErrNonCanonicalR,
+		ErrNonCanonicalS,
+		ErrCofactoredEquation,
+		ErrCofactorlessMismatch,
+	}
+
 	// VerifyOptionsDefault specifies verification behavior that is
 	// used by this package by default.
 	VerifyOptionsDefault = &VerifyOptions{
@@ -111,6 +169,39 @@ var (
 		AllowNonCanonicalR: true,
 	}
 
+	// VerifyOptionsLibsodium specifies verification behavior that is
+	// compatible with libsodium's `crypto_sign_verify_detached`.
+	//
+	// Note: This preset is incompatible with batch verification.
+	VerifyOptionsLibsodium = &VerifyOptions{
+		CofactorlessVerify: true,
+	}
+
+	// VerifyOptionsDalek specifies verification behavior that is
+	// compatible with ed25519-dalek's `verify`.  This is the same
+	// behavior as the Go `crypto/ed25519` package, and thus is an
+	// alias of VerifyOptionsStdLib.
+	//
+	// Note: This preset is incompatible with batch verification.
+	VerifyOptionsDalek = VerifyOptionsStdLib
+
+	// VerifyOptionsDalekStrict specifies verification behavior that is
+	// compatible with ed25519-dalek's `verify_strict`.
+	//
+	// Note: This preset is incompatible with batch verification.
+	VerifyOptionsDalekStrict = &VerifyOptions{
+		AllowNonCanonicalA: true,
+		CofactorlessVerify: true,
+	}
+
+	// VerifyOptionsBoringSSL specifies verification behavior that is
+	// compatible with BoringSSL's `ED25519_verify`.  This is the same
+	// behavior as the Go `crypto/ed25519` package, and thus is an
+	// alias of VerifyOptionsStdLib.
+	//
+	// Note: This preset is incompatible with batch verification.
+	VerifyOptionsBoringSSL = VerifyOptionsStdLib
+
 	optionsDefault = &Options{
 		Verify: VerifyOptionsDefault,
 	}
@@ -165,20 +256,24 @@ func (opt *Options) HashFunc() crypto.Hash {
 }
 
 func (opt *Options) verify() (dom2Flag, []byte, error) {
-	var (
-		context []byte
-		f       dom2Flag = fPure
-	)
-
 	if vOpts := opt.Verify; vOpts != nil {
 		if vOpts.AllowNonCanonicalR && vOpts.CofactorlessVerify {
-			return f, nil, fmt.Errorf("ed25519: incompatible verification options")
+			return fPure, nil, fmt.Errorf("ed25519: incompatible verification options")
 		}
 	}
 
+	return opt.dom2Context()
+}
+
+func (opt *Options) dom2Context() (dom2Flag, []byte, error) {
+	var (
+		context []byte
+		f       dom2Flag = fPure
+	)
+
 	if l := len(opt.Context); l > 0 {
 		if l > ContextMaxSize {
-			return f, nil, fmt.Errorf("ed25519: bad context length: %d", l)
+			return f, nil, fmt.Errorf("%w: %d", ErrBadContextLength, l)
 		}
 
 		context = []byte(opt.Context)
@@ -201,7 +296,7 @@ func checkHash(f dom2Flag, message []byte, hashFunc crypto.Hash) (dom2Flag, erro
 		f = fPh
 	case crypto.Hash(0):
 	default:
-		return f, fmt.Errorf("ed25519: expected opts HashFunc zero (unhashed message, for Ed25519/Ed25519ctx) or SHA-512 (for Ed25519ph)")
+		return f, errBadHashFunc
 	}
 
 	return f, nil
@@ -241,53 +336,53 @@ type VerifyOptions struct {
 	CofactorlessVerify bool
 }
 
-func (vOpts *VerifyOptions) unpackPublicKey(publicKey PublicKey, A *curve.EdwardsPoint) bool {
+func (vOpts *VerifyOptions) unpackPublicKey(publicKey PublicKey, A *curve.EdwardsPoint) error {
 	// Unpack A.
 	var aCompressed curve.CompressedEdwardsY
 	if _, err := aCompressed.SetBytes(publicKey); err != nil {
-		return false
+		return ErrMalformedPublicKey
 	}
 	if _, err := A.SetCompressedY(&aCompressed); err != nil {
-		return false
+		return ErrMalformedPublicKey
 	}
 
 	// Check A order (required for strong binding).
 	if !vOpts.AllowSmallOrderA && A.IsSmallOrder() {
-		return false
+		return ErrSmallOrderPublicKey
 	}
 
 	// Check if A is canonical.
 	if !vOpts.AllowNonCanonicalA && !aCompressed.IsCanonicalVartime() {
-		return false
+		return ErrNonCanonicalPublicKey
 	}
 
-	return true
+	return nil
 }
 
-func (vOpts *VerifyOptions) unpackSignature(sig []byte, R *curve.EdwardsPoint, S *scalar.Scalar) bool {
+func (vOpts *VerifyOptions) unpackSignature(sig []byte, R *curve.EdwardsPoint, S *scalar.Scalar) error {
 	if len(sig) != SignatureSize {
-		return false
+		return ErrMalformedSignature
 	}
 
 	// https://tools.ietf.org/html/rfc8032#section-5.1.7 requires that s be in
 	// the range [0, order) in order to prevent signature malleability.
 	if !scalar.ScMinimalVartime(sig[32:]) {
-		return false
+		return ErrNonCanonicalS
 	}
 
 	// Unpack R.
 	var rCompressed curve.CompressedEdwardsY
 	if _, err := rCompressed.SetBytes(sig[:32]); err != nil {
-		return false
+		return ErrMalformedSignature
 	}
 	if vOpts.verifyNeedsDecompressedR() {
 		if _, err := R.SetCompressedY(&rCompressed); err != nil {
-			return false
+			return ErrMalformedSignature
 		}
 
 		// Check R order.
 		if !vOpts.AllowSmallOrderR && R.IsSmallOrder() {
-			return false
+			return ErrSmallOrderR
 		}
 	} else {
 		R.Identity()
@@ -296,15 +391,15 @@ func (vOpts *VerifyOptions) unpackSignature(sig []byte, R *curve.EdwardsPoint, S
 
 	// Check if R is canonical.
 	if !vOpts.AllowNonCanonicalR && !rCompressed.IsCanonicalVartime() {
-		return false
+		return ErrNonCanonicalR
 	}
 
 	// Unpack S.
 	if _, err := S.SetBytesModOrder(sig[32:]); err != nil {
-		return false
+		return ErrMalformedSignature
 	}
 
-	return true
+	return nil
 }
 
 func (vOpts *VerifyOptions) verifyNeedsDecompressedR() bool {
@@ -364,6 +459,22 @@ func (priv PrivateKey) Seed() []byte {
 //
 // Warning: This routine will panic if opts is nil.
 func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOpts) (signature []byte, err error) {
+	if l := len(priv); l != PrivateKeySize {
+		return nil, fmt.Errorf("ed25519: bad private key length: %d", l)
+	}
+
+	var extsk [64]byte
+	expandSeed(&extsk, priv[:SeedSize])
+
+	var a scalar.Scalar
+	if _, err = a.SetBits(extsk[:32]); err != nil {
+		return nil, fmt.Errorf("ed25519: failed to deserialize a scalar: %w", err)
+	}
+
+	return sign(&a, extsk[32:], priv[SeedSize:], rand, &msgSource{b: message}, opts)
+}
+
+func sign(a *scalar.Scalar, prefix, publicKey []byte, rand io.Reader, msg *msgSource, opts crypto.SignerOpts) (signature []byte, err error) {
 	var (
 		context    []byte
 		f          dom2Flag = fPure
@@ -386,29 +497,17 @@ func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOp
 
 	// Now that the Options specific validation is done, see if the caller
 	// wants Ed25519ph instead.
-	f, err = checkHash(f, message, opts.HashFunc())
+	f, err = checkHash(f, msg.b, opts.HashFunc())
 	if err != nil {
 		return nil, err
 	}
 
-	if l := len(priv); l != PrivateKeySize {
-		return nil, fmt.Errorf("ed25519: bad private key length: %d", l)
-	}
-
-	var extsk [64]byte
-	h := sha512.New()
-	_, _ = h.Write(priv[:32])
-	h.Sum(extsk[:0])
-	extsk[0] &= 248
-	extsk[31] &= 127
-	extsk[31] |= 64
-
 	// r = H(dom2, aExt[32..64], m)
 	var (
 		hashr [64]byte
 		r     scalar.Scalar
 	)
-	h.Reset()
+	h := sha512.New()
 	dom2 := makeDom2(f, context)
 	if dom2 != nil {
 		_, _ = h.Write(dom2)
@@ -420,12 +519,14 @@ func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOp
 		}
 		_, _ = h.Write(entropy[:])
 	}
-	_, _ = h.Write(extsk[32:])
+	_, _ = h.Write(prefix)
 	if addedRand {
 		padSize := len(addedRandomnessPadding) - (len(dom2) + addedRandomnessSize + 32)
 		_, _ = h.Write(addedRandomnessPadding[:padSize])
 	}
-	_, _ = h.Write(message)
+	if err = msg.writeTo(func(b []byte) { _, _ = h.Write(b) }); err != nil {
+		return nil, err
+	}
 	h.Sum(hashr[:0])
 	if _, err = r.SetBytesModOrderWide(hashr[:]); err != nil {
 		return nil, fmt.Errorf("ed25519: failed to deserialize r scalar: %w", err)
@@ -448,19 +549,17 @@ func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOp
 		_, _ = h.Write(dom2)
 	}
 	_, _ = h.Write(rCompressed[:])
-	_, _ = h.Write(priv[32:])
-	_, _ = h.Write(message)
+	_, _ = h.Write(publicKey)
+	if err = msg.writeTo(func(b []byte) { _, _ = h.Write(b) }); err != nil {
+		return nil, err
+	}
 	h.Sum(hram[:0])
 	if _, err = S.SetBytesModOrderWide(hram[:]); err != nil {
 		return nil, fmt.Errorf("ed25519: failed to deserialize H(R,A,m) scalar: %w", err)
 	}
 
 	// S = H(R,A,m)a
-	var a scalar.Scalar
-	if _, err = a.SetBits(extsk[:32]); err != nil {
-		return nil, fmt.Errorf("ed25519: failed to deserialize a scalar: %w", err)
-	}
-	S.Mul(&S, &a)
+	S.Mul(&S, a)
 
 	// S = (r + H(R,A,m)a)
 	S.Add(&S, &r)
@@ -474,7 +573,11 @@ func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOp
 
 	// If opts.SelfVerify is set, verify the newly created signature.
 	if selfVerify {
-		if !VerifyWithOptions(PublicKey(priv[32:]), message, RS[:], opts.(*Options)) {
+		ok, err := verifyResult(verifyWithOptionsError(PublicKey(publicKey), msg, RS[:], opts.(*Options)))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
 			return nil, fmt.Errorf("ed25519: failed to self-verify signature")
 		}
 	}
@@ -537,10 +640,37 @@ func VerifyWithOptions(publicKey PublicKey, message, sig []byte, opts *Options)
 	return ok
 }
 
+// VerifyWithError checks if sig is a valid Ed25519 signature by publicKey
+// with the extra Options to support Ed25519ph (pre-hashed by SHA-512) or
+// Ed25519ctx (includes a domain separation context), returning nil iff
+// the signature is valid.  If opts is nil, plain Ed25519 with the default
+// verification behavior will be used.
+//
+// Unlike VerifyWithOptions, this routine will not panic on malformed
+// parameters.  The reason for rejection can be determined by using
+// errors.Is with the ErrMalformedPublicKey, ErrSmallOrderPublicKey,
+// ErrNonCanonicalPublicKey, ErrMalformedSignature, ErrSmallOrderR,
+// ErrNonCanonicalR, ErrNonCanonicalS, ErrCofactoredEquation,
+// ErrCofactorlessMismatch, and ErrBadContextLength sentinel errors.
+func VerifyWithError(publicKey PublicKey, message, sig []byte, opts *Options) error {
+	if opts == nil {
+		opts = optionsDefault
+	}
+	if l := len(publicKey); l != PublicKeySize {
+		return fmt.Errorf("%w: bad length: %d", ErrMalformedPublicKey, l)
+	}
+
+	return verifyWithOptionsError(publicKey, &msgSource{b: message}, sig, opts)
+}
+
 func verifyWithOptionsNoPanic(publicKey PublicKey, message, sig []byte, opts *Options) (bool, error) {
+	return verifyResult(verifyWithOptionsError(publicKey, &msgSource{b: message}, sig, opts))
+}
+
+func verifyWithOptionsError(publicKey PublicKey, msg *msgSource, sig []byte, opts *Options) error {
 	f, context, err := opts.verify()
 	if err != nil {
-		return false, err
+		return err
 	}
 	vOpts := opts.Verify
 	if vOpts == nil {
@@ -549,15 +679,15 @@ func verifyWithOptionsNoPanic(publicKey PublicKey, message, sig []byte, opts *Op
 
 	// Now that the Options specific validation is done, see if the caller
 	// wants Ed25519ph instead.
-	f, err = checkHash(f, message, opts.HashFunc())
+	f, err = checkHash(f, msg.b, opts.HashFunc())
 	if err != nil {
-		return false, err
+		return err
 	}
 
 	// Unpack and ensure the public key is well-formed (A).
 	var A curve.EdwardsPoint
-	if ok := vOpts.unpackPublicKey(publicKey, &A); !ok {
-		return false, nil
+	if err = vOpts.unpackPublicKey(publicKey, &A); err != nil {
+		return err
 	}
 
 	// Unpack and ensure the signature is well-formed (R, S).
@@ -565,8 +695,8 @@ func verifyWithOptionsNoPanic(publicKey PublicKey, message, sig []byte, opts *Op
 		checkR curve.EdwardsPoint
 		S      scalar.Scalar
 	)
-	if ok := vOpts.unpackSignature(sig, &checkR, &S); !ok {
-		return false, nil
+	if err = vOpts.unpackSignature(sig, &checkR, &S); err != nil {
+		return err
 	}
 
 	// hram = H(R,A,m)
@@ -580,10 +710,12 @@ func verifyWithOptionsNoPanic(publicKey PublicKey, message, sig []byte, opts *Op
 	}
 	_, _ = h.Write(sig[:32])
 	_, _ = h.Write(publicKey[:])
-	_, _ = h.Write(message)
+	if err = msg.writeTo(func(b []byte) { _, _ = h.Write(b) }); err != nil {
+		return err
+	}
 	h.Sum(hash[:0])
 	if _, err = hram.SetBytesModOrderWide(hash[:]); err != nil {
-		return false, fmt.Errorf("ed25519: failed to deserialize H(R,A,m) scalar: %w", err)
+		return fmt.Errorf("ed25519: failed to deserialize H(R,A,m) scalar: %w", err)
 	}
 
 	// A = -A (Since we want SB - H(R,A,m)A)
@@ -598,7 +730,7 @@ func verifyWithOptionsNoPanic(publicKey PublicKey, message, sig []byte, opts *Op
 		// SB - H(R,A,m)A ?= R
 		var R curve.EdwardsPoint
 		R.DoubleScalarMulBasepointVartime(&hram, &A, &S)
-		return cofactorlessVerify(&R, sig), nil
+		return cofactorlessVerify(&R, sig)
 	}
 
 	// Check that [8]R == [8](SB - H(R,A,m)A)), by computing
@@ -608,7 +740,22 @@ func verifyWithOptionsNoPanic(publicKey PublicKey, message, sig []byte, opts *Op
 	//
 	// Note: IsSmallOrder includes a cofactor multiply.
 	var rDiff curve.EdwardsPoint
-	return rDiff.TripleScalarMulBasepointVartime(&hram, &A, &S, &checkR).IsSmallOrder(), nil
+	return cofactoredVerify(rDiff.TripleScalarMulBasepointVartime(&hram, &A, &S, &checkR))
+}
+
+// verifyResult splits the result of verification into the form returned
+// by the non-panicking boolean verification routines, where failures
+// that indicate an invalid signature are not treated as errors.
+func verifyResult(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	for _, sigErr := range signatureErrors {
+		if errors.Is(err, sigErr) {
+			return false, nil
+		}
+	}
+	return false, err
 }
 
 // NewKeyFromSeed calculates a private key from a seed. It will panic if
@@ -627,10 +774,8 @@ func newKeyFromSeed(privateKey, seed []byte) {
 		panic("ed25519: bad seed length: " + strconv.Itoa(l))
 	}
 
-	digest := sha512.Sum512(seed)
-	digest[0] &= 248
-	digest[31] &= 127
-	digest[31] |= 64
+	var digest [64]byte
+	expandSeed(&digest, seed)
 
 	var a scalar.Scalar
 	if _, err := a.SetBits(digest[:32]); err != nil {
@@ -647,6 +792,15 @@ func newKeyFromSeed(privateKey, seed []byte) {
 	copy(privateKey[32:], aCompressed[:])
 }
 
+func expandSeed(extsk *[64]byte, seed []byte) {
+	h := sha512.New()
+	_, _ = h.Write(seed)
+	h.Sum(extsk[:0])
+	extsk[0] &= 248
+	extsk[31] &= 127
+	extsk[31] |= 64
+}
+
 // GenerateKey generates a public/private key pair using entropy from rand.
 // If rand is nil, crypto/rand.Reader will be used.
 func GenerateKey(rand io.Reader) (PublicKey, PrivateKey, error) {
@@ -676,6 +830,34 @@ const (
 	dom2Prefix = "SigEd25519 no Ed25519 collisions"
 )
 
+// Dom2 returns the RFC 8032 dom2(F, C) prefix that is prepended to the
+// input of every SHA-512 invocation for the variant specified by opts,
+// or nil for plain Ed25519.  If opts is nil, plain Ed25519 will be used.
+//
+// This is intended for constructions layered on top of Ed25519 (eg:
+// signature aggregation, adaptor signatures) that need to compute
+// H(dom2(F, C) || R || A || M) themselves.  Checking that pre-hashed
+// messages are sha512.Size is left to the caller.
+func Dom2(opts *Options) ([]byte, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
+	f, context, err := opts.dom2Context()
+	if err != nil {
+		return nil, err
+	}
+	switch opts.HashFunc() {
+	case crypto.SHA512:
+		f = fPh
+	case crypto.Hash(0):
+	default:
+		return nil, errBadHashFunc
+	}
+
+	return makeDom2(f, context), nil
+}
+
 func makeDom2(f dom2Flag, c []byte) []byte {
 	if f == fPure {
 		return nil
@@ -697,7 +879,15 @@ func makeDom2(f dom2Flag, c []byte) []byte {
 	return b
 }
 
-func cofactorlessVerify(R *curve.EdwardsPoint, sig []byte) bool {
+func cofactoredVerify(rDiff *curve.EdwardsPoint) error {
+	if !rDiff.IsSmallOrder() {
+		return ErrCofactoredEquation
+	}
+
+	return nil
+}
+
+func cofactorlessVerify(R *curve.EdwardsPoint, sig []byte) error {
 	// This could instead do `R ?= canonicalized sig` to support
 	// AllowNonCanonicalR with CofactorlessVerify.
 	//
@@ -711,5 +901,9 @@ func cofactorlessVerify(R *curve.EdwardsPoint, sig []byte) bool {
 	var RCompressed curve.CompressedEdwardsY
 	RCompressed.SetEdwardsPoint(R)
 
-	return bytes.Equal(RCompressed[:], sig[:32])
+	if !bytes.Equal(RCompressed[:], sig[:32]) {
+		return ErrCofactorlessMismatch
+	}
+
+	return nil
 }
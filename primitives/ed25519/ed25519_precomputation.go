@@ -30,13 +30,18 @@
 package ed25519
 
 import (
+	"crypto"
 	"crypto/sha512"
 	"fmt"
+	"io"
 
 	"github.com/oasisprotocol/curve25519-voi/curve"
 	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/subtle"
 )
 
+var _ crypto.Signer = (*ExpandedPrivateKey)(nil)
+
 // ExpandedPublicKey is a PublicKey stored in an expanded representation
 // for the purpose of accelerating repeated signature verification.
 //
@@ -58,21 +63,21 @@ func (k *ExpandedPublicKey) CompressedY() curve.CompressedEdwardsY {
 	return k.compressed
 }
 
-func (vOpts *VerifyOptions) checkExpandedPublicKey(publicKey *ExpandedPublicKey) bool {
+func (vOpts *VerifyOptions) checkExpandedPublicKey(publicKey *ExpandedPublicKey) error {
 	// This is equivalent to VerifyOptions.unpackPublicKey, but all of
 	// the outcomes are cached at the precompute step.
 	if !publicKey.isValidY {
-		return false
+		return ErrMalformedPublicKey
 	}
 
 	if !vOpts.AllowSmallOrderA && publicKey.isSmallOrder {
-		return false
+		return ErrSmallOrderPublicKey
 	}
 	if !vOpts.AllowNonCanonicalA && !publicKey.isCanonical {
-		return false
+		return ErrNonCanonicalPublicKey
 	}
 
-	return true
+	return nil
 }
 
 // NewExpandedPublicKey creates a new expanded public key from an existing
@@ -123,14 +128,33 @@ func VerifyExpandedWithOptions(publicKey *ExpandedPublicKey, message, sig []byte
 	return ok
 }
 
+// VerifyExpandedWithError checks if sig is a valid Ed25519 signature by
+// publicKey, returning nil iff the signature is valid.  If opts is nil,
+// plain Ed25519 with the default verification behavior will be used.
+// See VerifyWithError for details on the returned error.
+func VerifyExpandedWithError(publicKey *ExpandedPublicKey, message, sig []byte, opts *Options) error {
+	if opts == nil {
+		opts = optionsDefault
+	}
+	if publicKey == nil {
+		return ErrMalformedPublicKey
+	}
+
+	return verifyExpandedWithOptionsError(publicKey, message, sig, opts)
+}
+
 func verifyExpandedWithOptionsNoPanic(publicKey *ExpandedPublicKey, message, sig []byte, opts *Options) (bool, error) {
-	// This is equivalent to verifyWithOptionsNoPanic, but it uses
+	return verifyResult(verifyExpandedWithOptionsError(publicKey, message, sig, opts))
+}
+
+func verifyExpandedWithOptionsError(publicKey *ExpandedPublicKey, message, sig []byte, opts *Options) error {
+	// This is equivalent to verifyWithOptionsError, but it uses
 	// a expanded public key.  The reason why this a separate
 	// routine is because creating an ExpanedPublicKey on the fly
 	// incurs heap allocation overhead.
 	f, context, err := opts.verify()
 	if err != nil {
-		return false, err
+		return err
 	}
 	vOpts := opts.Verify
 	if vOpts == nil {
@@ -139,19 +163,19 @@ func verifyExpandedWithOptionsNoPanic(publicKey *ExpandedPublicKey, message, sig
 
 	f, err = checkHash(f, message, opts.HashFunc())
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	if ok := vOpts.checkExpandedPublicKey(publicKey); !ok {
-		return false, nil
+	if err = vOpts.checkExpandedPublicKey(publicKey); err != nil {
+		return err
 	}
 
 	var (
 		checkR curve.EdwardsPoint
 		S      scalar.Scalar
 	)
-	if ok := vOpts.unpackSignature(sig, &checkR, &S); !ok {
-		return false, nil
+	if err = vOpts.unpackSignature(sig, &checkR, &S); err != nil {
+		return err
 	}
 
 	var (
@@ -167,7 +191,7 @@ func verifyExpandedWithOptionsNoPanic(publicKey *ExpandedPublicKey, message, sig
 	_, _ = h.Write(message)
 	h.Sum(hash[:0])
 	if _, err = hram.SetBytesModOrderWide(hash[:]); err != nil {
-		return false, fmt.Errorf("ed25519: failed to deserialize H(R,A,m) scalar: %w", err)
+		return fmt.Errorf("ed25519: failed to deserialize H(R,A,m) scalar: %w", err)
 	}
 
 	// `-A` is already derived as part of precomputation (For `SB - H(R,A,m)A`)
@@ -176,9 +200,126 @@ func verifyExpandedWithOptionsNoPanic(publicKey *ExpandedPublicKey, message, sig
 	if vOpts.CofactorlessVerify {
 		var R curve.EdwardsPoint
 		R.ExpandedDoubleScalarMulBasepointVartime(&hram, negA, &S)
-		return cofactorlessVerify(&R, sig), nil
+		return cofactorlessVerify(&R, sig)
 	}
 
 	var rDiff curve.EdwardsPoint
-	return rDiff.ExpandedTripleScalarMulBasepointVartime(&hram, negA, &S, &checkR).IsSmallOrder(), nil
+	return cofactoredVerify(rDiff.ExpandedTripleScalarMulBasepointVartime(&hram, negA, &S, &checkR))
+}
+
+// ExpandedPrivateKey is a PrivateKey stored in an expanded representation
+// (the clamped secret scalar, and the nonce prefix), along with the
+// corresponding public key, for the purpose of accelerating repeated
+// signing.  It implements crypto.Signer.
+type ExpandedPrivateKey struct {
+	a         scalar.Scalar
+	prefix    [32]byte
+	publicKey [PublicKeySize]byte
+}
+
+// Public returns the PublicKey corresponding to k.
+func (k *ExpandedPrivateKey) Public() crypto.PublicKey {
+	pub := make([]byte, PublicKeySize)
+	copy(pub, k.publicKey[:])
+	return PublicKey(pub)
+}
+
+// Equal reports whether k and x have the same value. This function will
+// execute in constant time.
+func (k *ExpandedPrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*ExpandedPrivateKey)
+	if !ok {
+		return false
+	}
+
+	var kBytes, xxBytes [64]byte
+	_ = k.a.ToBytes(kBytes[:32])
+	copy(kBytes[32:], k.prefix[:])
+	_ = xx.a.ToBytes(xxBytes[:32])
+	copy(xxBytes[32:], xx.prefix[:])
+
+	return subtle.ConstantTimeCompareBytes(kBytes[:], xxBytes[:]) == 1
+}
+
+// SecretScalar returns a copy of k's secret scalar, reduced modulo the
+// group order.
+func (k *ExpandedPrivateKey) SecretScalar() *scalar.Scalar {
+	return scalar.New().Reduce(&k.a)
+}
+
+// Prefix returns a copy of k's nonce prefix.
+func (k *ExpandedPrivateKey) Prefix() []byte {
+	return append([]byte{}, k.prefix[:]...)
+}
+
+// Sign signs the given message with k.  The semantics of this routine
+// are identical to that of PrivateKey.Sign.
+//
+// Warning: This routine will panic if opts is nil.
+func (k *ExpandedPrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOpts) (signature []byte, err error) {
+	return sign(&k.a, k.prefix[:], k.publicKey[:], rand, &msgSource{b: message}, opts)
+}
+
+// NewExpandedPrivateKey creates a new expanded private key from an
+// existing private key.
+func NewExpandedPrivateKey(privateKey PrivateKey) (*ExpandedPrivateKey, error) {
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("ed25519: bad private key length: %d", l)
+	}
+
+	var (
+		k     ExpandedPrivateKey
+		extsk [64]byte
+	)
+	expandSeed(&extsk, privateKey[:SeedSize])
+	if _, err := k.a.SetBits(extsk[:32]); err != nil {
+		return nil, fmt.Errorf("ed25519: failed to deserialize a scalar: %w", err)
+	}
+	copy(k.prefix[:], extsk[32:])
+	copy(k.publicKey[:], privateKey[SeedSize:])
+
+	return &k, nil
+}
+
+// NewExpandedPrivateKeyFromScalar creates a new expanded private key from
+// a raw secret scalar and nonce prefix, for use with secrets that are not
+// derived from a seed (eg: blinded or hierarchically derived keys).  The
+// scalar must be the canonical little-endian encoding of a non-zero value
+// reduced modulo the group order, and the prefix must be 32 bytes.
+//
+// Note: A clamped scalar (as produced by expanding a seed) is not reduced,
+// and must be reduced modulo the group order first, which will result in
+// identical signatures.
+func NewExpandedPrivateKeyFromScalar(secretScalar, prefix []byte) (*ExpandedPrivateKey, error) {
+	if l := len(prefix); l != 32 {
+		return nil, fmt.Errorf("ed25519: bad prefix length: %d", l)
+	}
+
+	var k ExpandedPrivateKey
+	if _, err := k.a.SetCanonicalBytes(secretScalar); err != nil {
+		return nil, fmt.Errorf("ed25519: invalid secret scalar: %w", err)
+	}
+	if k.a.Equal(scalar.New()) == 1 {
+		return nil, fmt.Errorf("ed25519: invalid secret scalar: zero")
+	}
+	copy(k.prefix[:], prefix)
+
+	var (
+		A           curve.EdwardsPoint
+		aCompressed curve.CompressedEdwardsY
+	)
+	aCompressed.SetEdwardsPoint(A.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &k.a))
+	copy(k.publicKey[:], aCompressed[:])
+
+	return &k, nil
+}
+
+// SignExpanded signs the message with privateKey and returns a signature.
+func SignExpanded(privateKey *ExpandedPrivateKey, message []byte) []byte {
+	signature, err := privateKey.Sign(nil, message, optionsDefault)
+	if err != nil {
+		panic(err)
+	}
+
+	return signature
 }
@@ -0,0 +1,263 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ed25519
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+)
+
+var _ hash.Hash = (*PrehashHash)(nil)
+
+// SignReader signs the message read from message with privateKey.  If
+// opts is nil, plain Ed25519 will be used.
+//
+// As Ed25519 and Ed25519ctx require two passes over the message, message
+// will be read twice (three times if opts.SelfVerify is set), starting
+// from the current offset, which is restored between passes.  An error
+// is returned if the passes do not read the same message.  If
+// opts.HashFunc() is crypto.SHA512, Ed25519ph is used, and message is
+// expected to be the raw (unhashed) message, which will be hashed in
+// a single pass.
+//
+// If opts.AddedRandomness is set, additional entropy from
+// crypto/rand.Reader will be included.
+func SignReader(privateKey PrivateKey, message io.ReadSeeker, opts *Options) ([]byte, error) {
+	if opts == nil {
+		opts = optionsDefault
+	}
+	if l := len(privateKey); l != PrivateKeySize {
+		return nil, fmt.Errorf("ed25519: bad private key length: %d", l)
+	}
+
+	// Ed25519ph only requires a single pass over the message.
+	if opts.HashFunc() == crypto.SHA512 {
+		var digest [sha512.Size]byte
+		if err := prehashReader(&digest, message); err != nil {
+			return nil, err
+		}
+		return privateKey.Sign(nil, digest[:], opts)
+	}
+
+	start, err := message.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("ed25519: failed to query message offset: %w", err)
+	}
+
+	var extsk [64]byte
+	expandSeed(&extsk, privateKey[:SeedSize])
+
+	var a scalar.Scalar
+	if _, err = a.SetBits(extsk[:32]); err != nil {
+		return nil, fmt.Errorf("ed25519: failed to deserialize a scalar: %w", err)
+	}
+
+	return sign(&a, extsk[32:], privateKey[SeedSize:], nil, &msgSource{r: message, start: start}, opts)
+}
+
+// VerifyReader reports whether sig is a valid Ed25519 signature by
+// publicKey of the message read from message, in a single pass.  If opts
+// is nil, plain Ed25519 with the default verification behavior will be
+// used.  If opts.HashFunc() is crypto.SHA512, Ed25519ph is used, and
+// message is expected to be the raw (unhashed) message.
+//
+// Unlike VerifyWithOptions, malformed parameters and failures to read
+// the message are reported via the returned error.
+func VerifyReader(publicKey PublicKey, message io.Reader, sig []byte, opts *Options) (bool, error) {
+	if opts == nil {
+		opts = optionsDefault
+	}
+	if l := len(publicKey); l != PublicKeySize {
+		return false, fmt.Errorf("ed25519: bad public key length: %d", l)
+	}
+
+	if opts.HashFunc() == crypto.SHA512 {
+		var digest [sha512.Size]byte
+		if err := prehashReader(&digest, message); err != nil {
+			return false, err
+		}
+		return verifyWithOptionsNoPanic(publicKey, digest[:], sig, opts)
+	}
+
+	return verifyResult(verifyWithOptionsError(publicKey, &msgSource{r: message}, sig, opts))
+}
+
+// PrehashHash is an incremental hash.Hash for Ed25519ph, that allows
+// the message to be provided in chunks.
+type PrehashHash struct {
+	h hash.Hash
+}
+
+// NewPrehashHash creates a new PrehashHash.
+func NewPrehashHash() *PrehashHash {
+	return &PrehashHash{
+		h: sha512.New(),
+	}
+}
+
+// Write adds more data to the running hash.  It never returns an error.
+func (ph *PrehashHash) Write(p []byte) (int, error) {
+	return ph.h.Write(p)
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (ph *PrehashHash) Sum(b []byte) []byte {
+	return ph.h.Sum(b)
+}
+
+// Reset resets the hash to its initial state.
+func (ph *PrehashHash) Reset() {
+	ph.h.Reset()
+}
+
+// Size returns the number of bytes Sum will return.
+func (ph *PrehashHash) Size() int {
+	return ph.h.Size()
+}
+
+// BlockSize returns the hash's underlying block size.
+func (ph *PrehashHash) BlockSize() int {
+	return ph.h.BlockSize()
+}
+
+// Sign signs the message written so far with signer (a PrivateKey or an
+// *ExpandedPrivateKey), using Ed25519ph.  If opts is nil, Ed25519ph
+// without a context will be used, otherwise opts.Hash is ignored.
+//
+// If opts.AddedRandomness is set, additional entropy from rand will be
+// included.  If rand is nil, crypto/rand.Reader will be used.
+func (ph *PrehashHash) Sign(rand io.Reader, signer crypto.Signer, opts *Options) ([]byte, error) {
+	var digest [sha512.Size]byte
+	ph.h.Sum(digest[:0])
+
+	return signer.Sign(rand, digest[:], ph.options(opts))
+}
+
+// Verify reports whether sig is a valid Ed25519ph signature by publicKey
+// of the message written so far.  If opts is nil, Ed25519ph without a
+// context and with the default verification behavior will be used,
+// otherwise opts.Hash is ignored.  It will panic if len(publicKey) is
+// not PublicKeySize, or len(opts.Context) is greater than ContextMaxSize.
+func (ph *PrehashHash) Verify(publicKey PublicKey, sig []byte, opts *Options) bool {
+	var digest [sha512.Size]byte
+	ph.h.Sum(digest[:0])
+
+	return VerifyWithOptions(publicKey, digest[:], sig, ph.options(opts))
+}
+
+func (ph *PrehashHash) options(opts *Options) *Options {
+	var phOpts Options
+	if opts != nil {
+		phOpts = *opts
+	}
+	phOpts.Hash = crypto.SHA512
+
+	return &phOpts
+}
+
+func prehashReader(digest *[sha512.Size]byte, message io.Reader) error {
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return fmt.Errorf("ed25519: failed to read message: %w", err)
+	}
+	h.Sum(digest[:0])
+
+	return nil
+}
+
+// msgSource is the message being signed or verified, which is either a
+// byte slice, or read from an io.Reader.  Signing requires two passes
+// over the message (three if opts.SelfVerify is set), so the io.Reader
+// is rewound to start between passes, which requires it to also be an
+// io.Seeker.
+//
+// As deriving r from one message and S from another leaks the private
+// key, each pass over an io.Reader is digested, and a pass that does
+// not match the first one is an error.
+type msgSource struct {
+	b []byte
+
+	r      io.Reader
+	start  int64
+	passes int
+	digest [sha512.Size]byte
+}
+
+// writeTo passes the message to write, possibly in multiple chunks.  A
+// callback is used instead of an io.Writer, as the latter forces the
+// caller's hash state to be heap allocated.
+func (m *msgSource) writeTo(write func([]byte)) error {
+	if m.r == nil {
+		write(m.b)
+		return nil
+	}
+
+	if m.passes > 0 {
+		s, ok := m.r.(io.Seeker)
+		if !ok {
+			return fmt.Errorf("ed25519: message reader is not seekable")
+		}
+		if _, err := s.Seek(m.start, io.SeekStart); err != nil {
+			return fmt.Errorf("ed25519: failed to rewind message: %w", err)
+		}
+	}
+	m.passes++
+
+	var digest [sha512.Size]byte
+	h := sha512.New()
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := m.r.Read(buf)
+		write(buf[:n])
+		_, _ = h.Write(buf[:n])
+		switch err {
+		case nil:
+		case io.EOF:
+			h.Sum(digest[:0])
+			if m.passes == 1 {
+				m.digest = digest
+				return nil
+			}
+			if subtle.ConstantTimeCompare(digest[:], m.digest[:]) != 1 {
+				return fmt.Errorf("ed25519: message changed between passes")
+			}
+			return nil
+		default:
+			return fmt.Errorf("ed25519: failed to read message: %w", err)
+		}
+	}
+}
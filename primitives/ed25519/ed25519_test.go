@@ -37,10 +37,13 @@ import (
 	stded "crypto/ed25519"
 	"crypto/rand"
 	"crypto/sha512"
+	"errors"
+	"io"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
 	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
 	"github.com/oasisprotocol/curve25519-voi/internal/zeroreader"
 )
@@ -58,6 +61,489 @@ func TestStdLib(t *testing.T) {
 	t.Run("Malleability", testMalleability)
 }
 
+func TestExpandedPrivateKey(t *testing.T) {
+	t.Run("SignVerify", testExpandedPrivateKeySignVerify)
+	t.Run("SignVerify/Options", testExpandedPrivateKeySignVerifyOptions)
+	t.Run("CryptoSigner", testExpandedPrivateKeyCryptoSigner)
+	t.Run("Equal", testExpandedPrivateKeyEqual)
+	t.Run("BadLength", testExpandedPrivateKeyBadLength)
+	t.Run("FromScalar", testExpandedPrivateKeyFromScalar)
+	t.Run("FromScalar/Invalid", testExpandedPrivateKeyFromScalarInvalid)
+}
+
+func TestVerifyWithError(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	message := []byte("test message")
+	sig := Sign(private, message)
+
+	withR := func(R []byte) []byte {
+		b := append([]byte{}, sig...)
+		copy(b[:32], R)
+		return b
+	}
+	withS := func(S []byte) []byte {
+		b := append([]byte{}, sig...)
+		copy(b[32:], S)
+		return b
+	}
+
+	identity := testhelpers.MustUnhex(t, "0100000000000000000000000000000000000000000000000000000000000000")
+	nonCanonicalIdentity := testhelpers.MustUnhex(t, "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	groupOrder := testhelpers.MustUnhex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+
+	for _, tc := range []struct {
+		name      string
+		publicKey PublicKey
+		message   []byte
+		sig       []byte
+		opts      *Options
+		expected  error
+	}{
+		{"Valid", public, message, sig, nil, nil},
+		{"MalformedPublicKey", public[:31], message, sig, nil, ErrMalformedPublicKey},
+		{"SmallOrderPublicKey", identity, message, sig, nil, ErrSmallOrderPublicKey},
+		{"NonCanonicalPublicKey", nonCanonicalIdentity, message, sig, &Options{Verify: VerifyOptionsFIPS_186_5}, ErrNonCanonicalPublicKey},
+		{"MalformedSignature", public, message, sig[:63], nil, ErrMalformedSignature},
+		{"SmallOrderR", public, message, withR(identity), &Options{Verify: &VerifyOptions{}}, ErrSmallOrderR},
+		{"NonCanonicalR", public, message, withR(nonCanonicalIdentity), nil, ErrNonCanonicalR},
+		{"NonCanonicalS", public, message, withS(groupOrder), nil, ErrNonCanonicalS},
+		{"CofactoredEquation", public, []byte("wrong message"), sig, nil, ErrCofactoredEquation},
+		{"CofactorlessMismatch", public, []byte("wrong message"), sig, &Options{Verify: VerifyOptionsStdLib}, ErrCofactorlessMismatch},
+		{"BadContextLength", public, message, sig, &Options{Context: strings.Repeat("a", ContextMaxSize+1)}, ErrBadContextLength},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyWithError(tc.publicKey, tc.message, tc.sig, tc.opts)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("VerifyWithError: expected %v, got %v", tc.expected, err)
+			}
+
+			// Malformed public keys can't be expanded.
+			expPub, expErr := NewExpandedPublicKey(tc.publicKey)
+			if expErr != nil {
+				return
+			}
+			err = VerifyExpandedWithError(expPub, tc.message, tc.sig, tc.opts)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("VerifyExpandedWithError: expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestDom2(t *testing.T) {
+	const prefix = "SigEd25519 no Ed25519 collisions"
+
+	for _, tc := range []struct {
+		name     string
+		opts     *Options
+		expected []byte
+	}{
+		{"Nil", nil, nil},
+		{"Ed25519", &Options{}, nil},
+		{"Ed25519ctx", &Options{Context: "foo"}, []byte(prefix + "\x00\x03foo")},
+		{"Ed25519ph", &Options{Hash: crypto.SHA512}, []byte(prefix + "\x01\x00")},
+		{"Ed25519ph/Context", &Options{Hash: crypto.SHA512, Context: "foo"}, []byte(prefix + "\x01\x03foo")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dom2, err := Dom2(tc.opts)
+			if err != nil {
+				t.Fatalf("Dom2: %v", err)
+			}
+			if !bytes.Equal(dom2, tc.expected) {
+				t.Fatalf("Dom2: expected %x, got %x", tc.expected, dom2)
+			}
+		})
+	}
+
+	if _, err := Dom2(&Options{Context: strings.Repeat("a", ContextMaxSize+1)}); !errors.Is(err, ErrBadContextLength) {
+		t.Fatalf("Dom2(long context): expected %v, got %v", ErrBadContextLength, err)
+	}
+	if _, err := Dom2(&Options{Hash: crypto.SHA256}); err == nil {
+		t.Fatalf("Dom2(SHA-256): expected error")
+	}
+}
+
+func TestReader(t *testing.T) {
+	t.Run("SignVerify", testReaderSignVerify)
+	t.Run("Offset", testReaderOffset)
+	t.Run("Mutating", testReaderMutating)
+	t.Run("PrehashHash", testReaderPrehashHash)
+}
+
+func testReaderSignVerify(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	message := bytes.Repeat([]byte("test message "), 1024)
+	hash := sha512.Sum512(message)
+	for _, tc := range []struct {
+		name string
+		opts *Options
+	}{
+		{"Ed25519", nil},
+		{"Ed25519ctx", &Options{Context: "test context"}},
+		{"Ed25519ph", &Options{Hash: crypto.SHA512}},
+		{"Ed25519ph/Context", &Options{Hash: crypto.SHA512, Context: "test context"}},
+		{"AddedRandomness", &Options{AddedRandomness: true}},
+		{"SelfVerify", &Options{SelfVerify: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sig, err := SignReader(private, bytes.NewReader(message), tc.opts)
+			if err != nil {
+				t.Fatalf("SignReader: %v", err)
+			}
+
+			ok, err := VerifyReader(public, bytes.NewReader(message), sig, tc.opts)
+			if err != nil {
+				t.Fatalf("VerifyReader: %v", err)
+			}
+			if !ok {
+				t.Errorf("valid signature rejected")
+			}
+
+			msg := message
+			if tc.opts != nil && tc.opts.HashFunc() == crypto.SHA512 {
+				msg = hash[:]
+			}
+			opts := tc.opts
+			if opts == nil {
+				opts = &Options{}
+			}
+			if !VerifyWithOptions(public, msg, sig, opts) {
+				t.Errorf("VerifyWithOptions rejected streaming signature")
+			}
+
+			if !opts.AddedRandomness {
+				expectedSig, err := private.Sign(nil, msg, opts)
+				if err != nil {
+					t.Fatalf("failed to sign: %v", err)
+				}
+				if !bytes.Equal(sig, expectedSig) {
+					t.Errorf("streaming signature does not match signature")
+				}
+			}
+
+			ok, err = VerifyReader(public, strings.NewReader("wrong message"), sig, tc.opts)
+			if err != nil {
+				t.Fatalf("VerifyReader: %v", err)
+			}
+			if ok {
+				t.Errorf("signature of different message accepted")
+			}
+		})
+	}
+}
+
+func testReaderOffset(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	prefix, message := []byte("header"), []byte("test message")
+	rd := bytes.NewReader(append(append([]byte{}, prefix...), message...))
+	if _, err := rd.Seek(int64(len(prefix)), io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	sig, err := SignReader(private, rd, &Options{SelfVerify: true})
+	if err != nil {
+		t.Fatalf("SignReader: %v", err)
+	}
+	if !bytes.Equal(sig, Sign(private, message)) {
+		t.Errorf("streaming signature does not match signature")
+	}
+	if !Verify(public, message, sig) {
+		t.Errorf("valid signature rejected")
+	}
+}
+
+// mutatingReader is an io.ReadSeeker that returns different contents
+// once it has been seeked more than stableSeeks times.
+type mutatingReader struct {
+	*bytes.Reader
+	seeks       int
+	stableSeeks int
+}
+
+func (rd *mutatingReader) Seek(offset int64, whence int) (int64, error) {
+	rd.seeks++
+	if rd.seeks > rd.stableSeeks {
+		rd.Reader = bytes.NewReader([]byte("different message"))
+	}
+	return rd.Reader.Seek(offset, whence)
+}
+
+func testReaderMutating(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	_, private, _ := GenerateKey(zero)
+
+	// SignReader seeks once to find the start of the message, and once
+	// before each subsequent pass.
+	for _, tc := range []struct {
+		name        string
+		opts        *Options
+		stableSeeks int
+	}{
+		{"Ed25519", nil, 1},
+		{"SelfVerify", &Options{SelfVerify: true}, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rd := &mutatingReader{
+				Reader:      bytes.NewReader([]byte("test message")),
+				stableSeeks: tc.stableSeeks,
+			}
+			sig, err := SignReader(private, rd, tc.opts)
+			if err == nil {
+				t.Fatalf("SignReader signed a changing message: %x", sig)
+			}
+		})
+	}
+}
+
+func testReaderPrehashHash(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	message := []byte("test message")
+	hash := sha512.Sum512(message)
+	opts := &Options{Context: "test context"}
+
+	ph := NewPrehashHash()
+	for _, b := range message {
+		_, _ = ph.Write([]byte{b})
+	}
+	if digest := ph.Sum(nil); !bytes.Equal(digest, hash[:]) {
+		t.Fatalf("PrehashHash digest mismatch: %x vs %x", digest, hash)
+	}
+
+	sig, err := ph.Sign(nil, private, opts)
+	if err != nil {
+		t.Fatalf("PrehashHash.Sign: %v", err)
+	}
+	expectedSig, _ := private.Sign(nil, hash[:], &Options{Hash: crypto.SHA512, Context: opts.Context})
+	if !bytes.Equal(sig, expectedSig) {
+		t.Errorf("PrehashHash signature does not match signature")
+	}
+	if !ph.Verify(public, sig, opts) {
+		t.Errorf("valid signature rejected")
+	}
+	if opts.Hash != 0 {
+		t.Errorf("PrehashHash modified the caller's options")
+	}
+
+	ph.Reset()
+	_, _ = ph.Write([]byte("wrong message"))
+	if ph.Verify(public, sig, opts) {
+		t.Errorf("signature of different message accepted")
+	}
+}
+
+func testExpandedPrivateKeySignVerify(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	expPriv, err := NewExpandedPrivateKey(private)
+	if err != nil {
+		t.Fatalf("NewExpandedPrivateKey: %v", err)
+	}
+
+	message := []byte("test message")
+	sig := SignExpanded(expPriv, message)
+	if !bytes.Equal(sig, Sign(private, message)) {
+		t.Errorf("expanded signature does not match signature")
+	}
+	if !Verify(public, message, sig) {
+		t.Errorf("valid signature rejected")
+	}
+}
+
+func testExpandedPrivateKeySignVerifyOptions(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	expPriv, err := NewExpandedPrivateKey(private)
+	if err != nil {
+		t.Fatalf("NewExpandedPrivateKey: %v", err)
+	}
+
+	message := []byte("test message")
+	hash := sha512.Sum512(message)
+	for _, tc := range []struct {
+		name string
+		msg  []byte
+		opts *Options
+	}{
+		{"Ed25519ctx", message, &Options{Context: "test context"}},
+		{"Ed25519ph", hash[:], &Options{Hash: crypto.SHA512}},
+		{"Ed25519ph/Context", hash[:], &Options{Hash: crypto.SHA512, Context: "test context"}},
+		{"AddedRandomness", message, &Options{AddedRandomness: true}},
+		{"SelfVerify", message, &Options{SelfVerify: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sig, err := expPriv.Sign(rand.Reader, tc.msg, tc.opts)
+			if err != nil {
+				t.Fatalf("failed to sign: %v", err)
+			}
+			if !VerifyWithOptions(public, tc.msg, sig, tc.opts) {
+				t.Errorf("valid signature rejected")
+			}
+
+			if tc.opts.AddedRandomness {
+				return
+			}
+			expectedSig, err := private.Sign(nil, tc.msg, tc.opts)
+			if err != nil {
+				t.Fatalf("failed to sign with private key: %v", err)
+			}
+			if !bytes.Equal(sig, expectedSig) {
+				t.Errorf("expanded signature does not match signature")
+			}
+		})
+	}
+}
+
+func testExpandedPrivateKeyCryptoSigner(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	expPriv, err := NewExpandedPrivateKey(private)
+	if err != nil {
+		t.Fatalf("NewExpandedPrivateKey: %v", err)
+	}
+	signer := crypto.Signer(expPriv)
+
+	publicInterface := signer.Public()
+	public2, ok := publicInterface.(PublicKey)
+	if !ok {
+		t.Fatalf("expected PublicKey from Public() but got %T", publicInterface)
+	}
+	if !bytes.Equal(public, public2) {
+		t.Errorf("public keys do not match: original:%x vs Public():%x", public, public2)
+	}
+
+	message := []byte("message")
+	var noHash crypto.Hash
+	signature, err := signer.Sign(zero, message, noHash)
+	if err != nil {
+		t.Fatalf("error from Sign(): %s", err)
+	}
+	if !Verify(public, message, signature) {
+		t.Errorf("Verify failed on signature from Sign()")
+	}
+}
+
+func testExpandedPrivateKeyEqual(t *testing.T) {
+	_, private, _ := GenerateKey(rand.Reader)
+	_, otherPrivate, _ := GenerateKey(rand.Reader)
+
+	expPriv, _ := NewExpandedPrivateKey(private)
+	expPriv2, _ := NewExpandedPrivateKey(private)
+	otherExpPriv, _ := NewExpandedPrivateKey(otherPrivate)
+
+	if !expPriv.Equal(expPriv2) {
+		t.Errorf("expanded private key is not equal to itself")
+	}
+	if expPriv.Equal(otherExpPriv) {
+		t.Errorf("different expanded private keys are Equal")
+	}
+	if expPriv.Equal(private) {
+		t.Errorf("expanded private key is Equal to a PrivateKey")
+	}
+}
+
+func testExpandedPrivateKeyFromScalar(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	public, private, _ := GenerateKey(zero)
+
+	// Derive the reduced secret scalar and prefix from the seed.
+	var extsk [64]byte
+	expandSeed(&extsk, private.Seed())
+	a, err := scalar.NewFromBytesModOrder(extsk[:32])
+	if err != nil {
+		t.Fatalf("NewFromBytesModOrder: %v", err)
+	}
+	aBytes, _ := a.MarshalBinary()
+
+	expPriv, err := NewExpandedPrivateKeyFromScalar(aBytes, extsk[32:])
+	if err != nil {
+		t.Fatalf("NewExpandedPrivateKeyFromScalar: %v", err)
+	}
+	if pub := expPriv.Public().(PublicKey); !bytes.Equal(public, pub) {
+		t.Fatalf("public keys do not match: original:%x vs Public():%x", public, pub)
+	}
+
+	message := []byte("test message")
+	for _, opts := range []*Options{
+		{},
+		{Context: "test context"},
+		{SelfVerify: true},
+	} {
+		sig, err := expPriv.Sign(nil, message, opts)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		expectedSig, _ := private.Sign(nil, message, opts)
+		if !bytes.Equal(sig, expectedSig) {
+			t.Errorf("signature does not match signature from seed")
+		}
+		if !VerifyWithOptions(public, message, sig, opts) {
+			t.Errorf("valid signature rejected")
+		}
+	}
+
+	// The seed derived key exposes the same scalar and prefix.
+	seedPriv, err := NewExpandedPrivateKey(private)
+	if err != nil {
+		t.Fatalf("NewExpandedPrivateKey: %v", err)
+	}
+	if seedPriv.SecretScalar().Equal(a) != 1 {
+		t.Errorf("SecretScalar does not match the seed derived scalar")
+	}
+	if !bytes.Equal(seedPriv.Prefix(), extsk[32:]) {
+		t.Errorf("Prefix does not match the seed derived prefix")
+	}
+	seedABytes, _ := seedPriv.SecretScalar().MarshalBinary()
+	roundTrip, err := NewExpandedPrivateKeyFromScalar(seedABytes, seedPriv.Prefix())
+	if err != nil {
+		t.Fatalf("NewExpandedPrivateKeyFromScalar(SecretScalar(), Prefix()): %v", err)
+	}
+	if !roundTrip.Equal(expPriv) {
+		t.Errorf("round-tripped expanded key does not match")
+	}
+}
+
+func testExpandedPrivateKeyFromScalarInvalid(t *testing.T) {
+	var zero zeroreader.ZeroReader
+	_, private, _ := GenerateKey(zero)
+
+	var extsk [64]byte
+	expandSeed(&extsk, private.Seed())
+	prefix := extsk[32:]
+
+	var zeroScalar [scalar.ScalarSize]byte
+	for _, tc := range []struct {
+		name   string
+		scalar []byte
+		prefix []byte
+	}{
+		{"Unreduced", extsk[:32], prefix},
+		{"Zero", zeroScalar[:], prefix},
+		{"ShortScalar", extsk[:31], prefix},
+		{"ShortPrefix", zeroScalar[:], prefix[:31]},
+	} {
+		if _, err := NewExpandedPrivateKeyFromScalar(tc.scalar, tc.prefix); err == nil {
+			t.Errorf("%s: NewExpandedPrivateKeyFromScalar accepted an invalid secret", tc.name)
+		}
+	}
+}
+
+func testExpandedPrivateKeyBadLength(t *testing.T) {
+	if _, err := NewExpandedPrivateKey(make([]byte, PrivateKeySize-1)); err == nil {
+		t.Fatalf("NewExpandedPrivateKey accepted a truncated private key")
+	}
+}
+
 func testSignVerify(t *testing.T) {
 	var zero zeroreader.ZeroReader
 	public, private, _ := GenerateKey(zero)
@@ -278,6 +764,14 @@ func testGolden(t *testing.T) {
 			t.Errorf("signature failed to verify on line %d", lineNo)
 		}
 
+		expPriv, err := NewExpandedPrivateKey(priv[:])
+		if err != nil {
+			t.Fatalf("failed to expand private key on line %d: %v", lineNo, err)
+		}
+		if sig3 := SignExpanded(expPriv, msg); !bytes.Equal(sig, sig3) {
+			t.Errorf("different expanded signature result on line %d: %x vs %x", lineNo, sig, sig3)
+		}
+
 		priv2 := NewKeyFromSeed(priv[:32])
 		if !bytes.Equal(priv[:], priv2) {
 			t.Errorf("recreating key pair gave different private key on line %d: %x vs %x", lineNo, priv[:], priv2)
@@ -426,6 +920,25 @@ func BenchmarkExpanded(b *testing.B) {
 		b.Fatal(err)
 	}
 
+	b.Run("NewExpandedPrivateKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = NewExpandedPrivateKey(priv)
+		}
+	})
+	b.Run("Signing", func(b *testing.B) {
+		message := []byte("Hello, world!")
+
+		expPriv, err := NewExpandedPrivateKey(priv)
+		if err != nil {
+			b.Fatalf("NewExpandedPrivateKey: %v", err)
+		}
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			SignExpanded(expPriv, message)
+		}
+	})
 	b.Run("NewExpandedPublicKey", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
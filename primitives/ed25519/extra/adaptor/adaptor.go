@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package adaptor implements Ed25519 adaptor signatures.
+//
+// An adaptor signature (pre-signature) is a signature that is encrypted
+// under an adaptor point T = [t]B.  Anyone that knows the adaptor secret
+// t can adapt the pre-signature into a valid Ed25519 signature, and
+// anyone that knows the pre-signature and the adapted signature can
+// extract t.  This is the building block of scriptless atomic swaps.
+//
+// Given a nonce r, and R = [r]B + T, a pre-signature is (R, s'), where
+// s' = r + H(dom2, R, A, M) * a.  The adapted signature is (R, s' + t),
+// which is a standard Ed25519 signature.
+package adaptor
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const (
+	// PreSignatureSize is the size, in bytes, of pre-signatures.
+	PreSignatureSize = 64
+
+	// PointSize is the size, in bytes, of adaptor points.
+	PointSize = 32
+
+	// SecretSize is the size, in bytes, of adaptor secrets.
+	SecretSize = 32
+
+	nonceDomainSeparator = "curve25519-voi/ed25519/extra/adaptor: nonce"
+)
+
+// GenerateAdaptor generates an adaptor secret t and the corresponding
+// adaptor point T = [t]B, using entropy from rand.  If rand is nil,
+// crypto/rand.Reader will be used.
+func GenerateAdaptor(rand io.Reader) (secret, point []byte, err error) {
+	var t scalar.Scalar
+	if _, err = t.SetRandom(rand); err != nil {
+		return nil, nil, fmt.Errorf("adaptor: failed to generate secret: %w", err)
+	}
+
+	secret = make([]byte, SecretSize)
+	if err = t.ToBytes(secret); err != nil {
+		return nil, nil, fmt.Errorf("adaptor: failed to serialize secret: %w", err)
+	}
+
+	var (
+		T           curve.EdwardsPoint
+		tCompressed curve.CompressedEdwardsY
+	)
+	tCompressed.SetEdwardsPoint(T.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &t))
+
+	return secret, tCompressed[:], nil
+}
+
+// PreSign creates a pre-signature of the message with privateKey,
+// encrypted under the adaptorPoint.  If opts is nil, plain Ed25519 will
+// be used.
+//
+// Warning: The adaptor point MUST be validated (eg: by the counterparty
+// proving knowledge of the adaptor secret), as the pre-signature is
+// useless if the corresponding secret is unknown.
+func PreSign(privateKey ed25519.PrivateKey, message, adaptorPoint []byte, opts *ed25519.Options) ([]byte, error) {
+	if l := len(privateKey); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("adaptor: bad private key length: %d", l)
+	}
+	dom2, err := makeDom2(opts, message)
+	if err != nil {
+		return nil, err
+	}
+
+	var T curve.EdwardsPoint
+	if err = unpackAdaptorPoint(&T, adaptorPoint); err != nil {
+		return nil, err
+	}
+
+	// Expand the private key.
+	expandedKey, err := ed25519.NewExpandedPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("adaptor: failed to expand private key: %w", err)
+	}
+	a := expandedKey.SecretScalar()
+	publicKey := privateKey[ed25519.SeedSize:]
+
+	// r = H(domain, prefix, T, dom2, M)
+	//
+	// The adaptor point is included so that distinct adaptor points
+	// never share a nonce, and the domain separator ensures that the
+	// nonce never matches that of a regular signature.
+	var (
+		digest [sha512.Size]byte
+		r      scalar.Scalar
+	)
+	h := sha512.New()
+	_, _ = h.Write([]byte(nonceDomainSeparator))
+	_, _ = h.Write(expandedKey.Prefix())
+	_, _ = h.Write(adaptorPoint)
+	_, _ = h.Write(dom2)
+	_, _ = h.Write(message)
+	h.Sum(digest[:0])
+	if _, err = r.SetBytesModOrderWide(digest[:]); err != nil {
+		return nil, fmt.Errorf("adaptor: failed to deserialize nonce: %w", err)
+	}
+
+	// R = [r]B + T
+	var (
+		R           curve.EdwardsPoint
+		rCompressed curve.CompressedEdwardsY
+	)
+	R.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &r)
+	rCompressed.SetEdwardsPoint(R.Add(&R, &T))
+
+	// s' = r + H(dom2, R, A, M) * a
+	var k scalar.Scalar
+	computeHram(&k, dom2, rCompressed[:], publicKey, message)
+	preSig := make([]byte, PreSignatureSize)
+	copy(preSig[:32], rCompressed[:])
+	if err = r.Add(&r, k.Mul(&k, a)).ToBytes(preSig[32:]); err != nil {
+		return nil, fmt.Errorf("adaptor: failed to serialize s': %w", err)
+	}
+
+	return preSig, nil
+}
+
+// PreVerify reports whether preSignature is a valid pre-signature of
+// message by publicKey, encrypted under adaptorPoint.  If opts is nil,
+// plain Ed25519 will be used.  A valid pre-signature, once adapted with
+// the adaptor secret, will yield a signature that is accepted by all of
+// the ed25519 package's verification options presets.
+func PreVerify(publicKey ed25519.PublicKey, message, adaptorPoint, preSignature []byte, opts *ed25519.Options) bool {
+	dom2, err := makeDom2(opts, message)
+	if err != nil {
+		return false
+	}
+	if len(publicKey) != ed25519.PublicKeySize || len(preSignature) != PreSignatureSize {
+		return false
+	}
+
+	var A, R, T curve.EdwardsPoint
+	if !unpackPoint(&A, publicKey) || !unpackPoint(&R, preSignature[:32]) {
+		return false
+	}
+	if unpackAdaptorPoint(&T, adaptorPoint) != nil {
+		return false
+	}
+
+	var sPrime scalar.Scalar
+	if !scalar.ScMinimalVartime(preSignature[32:]) {
+		return false
+	}
+	if _, err = sPrime.SetBytesModOrder(preSignature[32:]); err != nil {
+		return false
+	}
+
+	// [s']B - [k]A + T = R
+	var (
+		k     scalar.Scalar
+		check curve.EdwardsPoint
+	)
+	computeHram(&k, dom2, preSignature[:32], publicKey, message)
+	check.DoubleScalarMulBasepointVartime(k.Neg(&k), &A, &sPrime)
+	check.Add(&check, &T)
+
+	return check.Equal(&R) == 1
+}
+
+// Adapt adapts the preSignature into a valid signature, with the adaptor
+// secret.
+func Adapt(preSignature, adaptorSecret []byte) ([]byte, error) {
+	if l := len(preSignature); l != PreSignatureSize {
+		return nil, fmt.Errorf("adaptor: bad pre-signature length: %d", l)
+	}
+
+	var sPrime, t scalar.Scalar
+	if _, err := sPrime.SetCanonicalBytes(preSignature[32:]); err != nil {
+		return nil, fmt.Errorf("adaptor: invalid s': %w", err)
+	}
+	if _, err := t.SetCanonicalBytes(adaptorSecret); err != nil {
+		return nil, fmt.Errorf("adaptor: invalid adaptor secret: %w", err)
+	}
+
+	// s = s' + t
+	sig := make([]byte, ed25519.SignatureSize)
+	copy(sig[:32], preSignature[:32])
+	if err := sPrime.Add(&sPrime, &t).ToBytes(sig[32:]); err != nil {
+		return nil, fmt.Errorf("adaptor: failed to serialize s: %w", err)
+	}
+
+	return sig, nil
+}
+
+// Extract extracts the adaptor secret from a preSignature and the
+// corresponding adapted signature, and checks that it matches the
+// adaptorPoint.
+func Extract(preSignature, signature, adaptorPoint []byte) ([]byte, error) {
+	if l := len(preSignature); l != PreSignatureSize {
+		return nil, fmt.Errorf("adaptor: bad pre-signature length: %d", l)
+	}
+	if l := len(signature); l != ed25519.SignatureSize {
+		return nil, fmt.Errorf("adaptor: bad signature length: %d", l)
+	}
+	if string(preSignature[:32]) != string(signature[:32]) {
+		return nil, fmt.Errorf("adaptor: pre-signature and signature R mismatch")
+	}
+
+	var T curve.EdwardsPoint
+	if err := unpackAdaptorPoint(&T, adaptorPoint); err != nil {
+		return nil, err
+	}
+
+	var sPrime, s scalar.Scalar
+	if _, err := sPrime.SetCanonicalBytes(preSignature[32:]); err != nil {
+		return nil, fmt.Errorf("adaptor: invalid s': %w", err)
+	}
+	if _, err := s.SetCanonicalBytes(signature[32:]); err != nil {
+		return nil, fmt.Errorf("adaptor: invalid s: %w", err)
+	}
+
+	// t = s - s'
+	t := s.Sub(&s, &sPrime)
+
+	var checkT curve.EdwardsPoint
+	if checkT.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, t).Equal(&T) != 1 {
+		return nil, fmt.Errorf("adaptor: extracted secret does not match adaptor point")
+	}
+
+	secret := make([]byte, SecretSize)
+	if err := t.ToBytes(secret); err != nil {
+		return nil, fmt.Errorf("adaptor: failed to serialize secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+func computeHram(k *scalar.Scalar, dom2, R, publicKey, message []byte) {
+	var digest [sha512.Size]byte
+	h := sha512.New()
+	_, _ = h.Write(dom2)
+	_, _ = h.Write(R)
+	_, _ = h.Write(publicKey)
+	_, _ = h.Write(message)
+	h.Sum(digest[:0])
+	if _, err := k.SetBytesModOrderWide(digest[:]); err != nil {
+		panic("adaptor: failed to deserialize H(R,A,m) scalar: " + err.Error())
+	}
+}
+
+func unpackPoint(p *curve.EdwardsPoint, b []byte) bool {
+	var compressed curve.CompressedEdwardsY
+	if _, err := compressed.SetBytes(b); err != nil {
+		return false
+	}
+	if !compressed.IsCanonicalVartime() {
+		return false
+	}
+	if _, err := p.SetCompressedY(&compressed); err != nil {
+		return false
+	}
+
+	return !p.IsSmallOrder()
+}
+
+func unpackAdaptorPoint(T *curve.EdwardsPoint, b []byte) error {
+	if l := len(b); l != PointSize {
+		return fmt.Errorf("adaptor: bad adaptor point length: %d", l)
+	}
+	if !unpackPoint(T, b) {
+		return fmt.Errorf("adaptor: invalid adaptor point")
+	}
+
+	// A point with a torsion component has no corresponding secret.
+	if !T.IsTorsionFree() {
+		return fmt.Errorf("adaptor: adaptor point has a torsion component")
+	}
+
+	return nil
+}
+
+func makeDom2(opts *ed25519.Options, message []byte) ([]byte, error) {
+	if opts != nil && opts.HashFunc() == crypto.SHA512 {
+		if l := len(message); l != sha512.Size {
+			return nil, fmt.Errorf("adaptor: bad message hash length: %d", l)
+		}
+	}
+
+	return ed25519.Dom2(opts)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package adaptor
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+func TestAdaptor(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	secret, point, err := GenerateAdaptor(nil)
+	if err != nil {
+		t.Fatalf("GenerateAdaptor: %v", err)
+	}
+
+	msg := []byte("atomic swap transaction")
+	msgHash := sha512.Sum512(msg)
+
+	for _, v := range []struct {
+		name string
+		msg  []byte
+		opts *ed25519.Options
+	}{
+		{"Ed25519", msg, nil},
+		{"Ed25519ctx", msg, &ed25519.Options{Context: "test context"}},
+		{"Ed25519ph", msgHash[:], &ed25519.Options{Hash: crypto.SHA512}},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			preSig, err := PreSign(priv, v.msg, point, v.opts)
+			if err != nil {
+				t.Fatalf("PreSign: %v", err)
+			}
+			if !PreVerify(pub, v.msg, point, preSig, v.opts) {
+				t.Fatalf("PreVerify: failed")
+			}
+			if preSig2, _ := PreSign(priv, v.msg, point, v.opts); !bytes.Equal(preSig, preSig2) {
+				t.Fatalf("PreSign: not deterministic")
+			}
+
+			opts := v.opts
+			if opts == nil {
+				opts = &ed25519.Options{}
+			}
+			if ed25519.VerifyWithOptions(pub, v.msg, preSig, opts) {
+				t.Fatalf("ed25519.VerifyWithOptions: accepted pre-signature")
+			}
+
+			sig, err := Adapt(preSig, secret)
+			if err != nil {
+				t.Fatalf("Adapt: %v", err)
+			}
+			for _, vOpts := range []*ed25519.VerifyOptions{
+				ed25519.VerifyOptionsDefault,
+				ed25519.VerifyOptionsFIPS_186_5,
+			} {
+				opts := *opts
+				opts.Verify = vOpts
+				if !ed25519.VerifyWithOptions(pub, v.msg, sig, &opts) {
+					t.Fatalf("ed25519.VerifyWithOptions(%+v): failed", vOpts)
+				}
+			}
+
+			extracted, err := Extract(preSig, sig, point)
+			if err != nil {
+				t.Fatalf("Extract: %v", err)
+			}
+			if !bytes.Equal(extracted, secret) {
+				t.Fatalf("Extract: mismatch (Got: %x, Expected: %x)", extracted, secret)
+			}
+		})
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		preSig, err := PreSign(priv, msg, point, nil)
+		if err != nil {
+			t.Fatalf("PreSign: %v", err)
+		}
+
+		otherSecret, otherPoint, _ := GenerateAdaptor(nil)
+		if PreVerify(pub, msg, otherPoint, preSig, nil) {
+			t.Fatalf("PreVerify: accepted wrong adaptor point")
+		}
+		if PreVerify(pub, []byte("other message"), point, preSig, nil) {
+			t.Fatalf("PreVerify: accepted wrong message")
+		}
+		badPreSig := append([]byte{}, preSig...)
+		badPreSig[40] ^= 1
+		if PreVerify(pub, msg, point, badPreSig, nil) {
+			t.Fatalf("PreVerify: accepted tampered pre-signature")
+		}
+
+		sig, err := Adapt(preSig, otherSecret)
+		if err != nil {
+			t.Fatalf("Adapt: %v", err)
+		}
+		if ed25519.Verify(pub, msg, sig) {
+			t.Fatalf("ed25519.Verify: accepted signature adapted with wrong secret")
+		}
+		if _, err = Extract(preSig, sig, point); err == nil {
+			t.Fatalf("Extract: accepted mismatched adaptor point")
+		}
+		if _, err = Extract(preSig, ed25519.Sign(priv, msg), point); err == nil {
+			t.Fatalf("Extract: accepted unrelated signature")
+		}
+	})
+	t.Run("TorsionAdaptorPoint", func(t *testing.T) {
+		var (
+			T           curve.EdwardsPoint
+			tCompressed curve.CompressedEdwardsY
+		)
+		_, _ = tCompressed.SetBytes(point)
+		_, _ = T.SetCompressedY(&tCompressed)
+		tCompressed.SetEdwardsPoint(T.Add(&T, curve.EIGHT_TORSION[1]))
+
+		if _, err := PreSign(priv, msg, tCompressed[:], nil); err == nil {
+			t.Fatalf("PreSign: accepted adaptor point with torsion component")
+		}
+	})
+}
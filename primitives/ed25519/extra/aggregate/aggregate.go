@@ -0,0 +1,349 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package aggregate implements non-interactive half-aggregation of Ed25519
+// signatures, as presented in the paper "Non-Interactive Half-Aggregation
+// of EdDSA and Variants of Schnorr Signatures" by Chalkias, Garillot,
+// Kondi, and Nikolaenko.
+//
+// An aggregate signature of n signatures consists of the n R components,
+// followed by a single aggregated S component.  The randomizers are
+// derived such that z_i only depends on the first i entries, which allows
+// signatures to be incrementally added to an existing aggregate signature.
+package aggregate
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strconv"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const (
+	// RSize is the size, in bytes, of each R component of an aggregate
+	// signature.
+	RSize = 32
+
+	// SSize is the size, in bytes, of the S component of an aggregate
+	// signature.
+	SSize = 32
+
+	randomizerSize = 16
+
+	randomizerDomainSeparator = "curve25519-voi/ed25519/extra/aggregate: randomizer"
+)
+
+// SignatureSize returns the size, in bytes, of an aggregate signature of
+// n signatures.
+func SignatureSize(n int) int {
+	return n*RSize + SSize
+}
+
+// Aggregator incrementally aggregates signatures.
+type Aggregator struct {
+	h  hash.Hash
+	rs []byte
+	s  scalar.Scalar
+	n  int
+}
+
+// Add adds a (public key, message, sig) triple to the aggregate signature.
+//
+// Note: The signature is not verified.  Aggregating an invalid signature
+// will result in an invalid aggregate signature.
+func (agg *Aggregator) Add(publicKey ed25519.PublicKey, message, sig []byte) error {
+	if l := len(publicKey); l != ed25519.PublicKeySize {
+		return fmt.Errorf("aggregate: bad public key length: %d", l)
+	}
+	if l := len(sig); l != ed25519.SignatureSize {
+		return fmt.Errorf("aggregate: bad signature length: %d", l)
+	}
+
+	var S scalar.Scalar
+	if !scalar.ScMinimalVartime(sig[RSize:]) {
+		return fmt.Errorf("aggregate: non-canonical S")
+	}
+	if _, err := S.SetBytesModOrder(sig[RSize:]); err != nil {
+		return fmt.Errorf("aggregate: failed to deserialize S: %w", err)
+	}
+
+	var z scalar.Scalar
+	agg.nextRandomizer(&z, sig[:RSize], publicKey, message)
+
+	// s = s + z_i * s_i
+	agg.s.Add(&agg.s, S.Mul(&S, &z))
+	agg.rs = append(agg.rs, sig[:RSize]...)
+
+	return nil
+}
+
+// Len returns the number of signatures that have been aggregated.
+func (agg *Aggregator) Len() int {
+	return agg.n
+}
+
+// Signature returns the aggregate signature.
+func (agg *Aggregator) Signature() []byte {
+	aggSig := make([]byte, 0, SignatureSize(agg.n))
+	aggSig = append(aggSig, agg.rs...)
+	aggSig = append(aggSig, make([]byte, SSize)...)
+	if err := agg.s.ToBytes(aggSig[len(agg.rs):]); err != nil {
+		panic("aggregate: failed to serialize S: " + err.Error())
+	}
+
+	return aggSig
+}
+
+func (agg *Aggregator) nextRandomizer(z *scalar.Scalar, R []byte, publicKey ed25519.PublicKey, message []byte) {
+	// z_i = H(R_1 || A_1 || m_1 || ... || R_i || A_i || m_i), with z_1 = 1.
+	var l [8]byte
+	binary.LittleEndian.PutUint64(l[:], uint64(len(message)))
+	_, _ = agg.h.Write(R)
+	_, _ = agg.h.Write(publicKey)
+	_, _ = agg.h.Write(l[:])
+	_, _ = agg.h.Write(message)
+	agg.n++
+
+	if agg.n == 1 {
+		z.Set(scalar.One())
+		return
+	}
+
+	var (
+		digest [sha512.Size]byte
+		zBytes [scalar.ScalarSize]byte
+	)
+	agg.h.Sum(digest[:0])
+	copy(zBytes[:randomizerSize], digest[:])
+	if _, err := z.SetBits(zBytes[:]); err != nil {
+		panic("aggregate: failed to deserialize randomizer: " + err.Error())
+	}
+}
+
+// NewAggregator creates a new empty Aggregator.
+func NewAggregator() *Aggregator {
+	agg := &Aggregator{
+		h: sha512.New(),
+	}
+	_, _ = agg.h.Write([]byte(randomizerDomainSeparator))
+
+	return agg
+}
+
+// Aggregate aggregates the signatures sigs, made by the corresponding
+// publicKeys over the corresponding messages into a single aggregate
+// signature.
+//
+// Note: The signatures are not verified.  Aggregating an invalid
+// signature will result in an invalid aggregate signature.
+func Aggregate(publicKeys []ed25519.PublicKey, messages, sigs [][]byte) ([]byte, error) {
+	return IncrementalAggregate(make([]byte, SSize), publicKeys, messages, sigs)
+}
+
+// IncrementalAggregate adds the signatures sigs to the existing aggregate
+// signature aggSig.  publicKeys and messages MUST contain the public keys
+// and messages of the signatures already in aggSig, followed by those of
+// sigs.
+//
+// Note: The signatures are not verified.  Aggregating an invalid
+// signature will result in an invalid aggregate signature.
+func IncrementalAggregate(aggSig []byte, publicKeys []ed25519.PublicKey, messages, sigs [][]byte) ([]byte, error) {
+	n := len(publicKeys)
+	if len(messages) != n {
+		return nil, fmt.Errorf("aggregate: len(publicKeys) != len(messages)")
+	}
+	v := n - len(sigs)
+	if v < 0 {
+		return nil, fmt.Errorf("aggregate: len(sigs) > len(publicKeys)")
+	}
+	if l := len(aggSig); l != SignatureSize(v) {
+		return nil, fmt.Errorf("aggregate: bad aggregate signature length: %d", l)
+	}
+
+	// Replay the existing aggregate signature.
+	agg := NewAggregator()
+	if !scalar.ScMinimalVartime(aggSig[v*RSize:]) {
+		return nil, fmt.Errorf("aggregate: non-canonical aggregate S")
+	}
+	if _, err := agg.s.SetBytesModOrder(aggSig[v*RSize:]); err != nil {
+		return nil, fmt.Errorf("aggregate: failed to deserialize aggregate S: %w", err)
+	}
+	for i := 0; i < v; i++ {
+		if l := len(publicKeys[i]); l != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("aggregate: bad public key length: %d", l)
+		}
+
+		var z scalar.Scalar
+		R := aggSig[i*RSize : (i+1)*RSize]
+		agg.nextRandomizer(&z, R, publicKeys[i], messages[i])
+		agg.rs = append(agg.rs, R...)
+	}
+
+	// Add the new signatures.
+	for i, sig := range sigs {
+		if err := agg.Add(publicKeys[v+i], messages[v+i], sig); err != nil {
+			return nil, err
+		}
+	}
+
+	return agg.Signature(), nil
+}
+
+// VerifyAggregate reports whether aggSig is a valid aggregate signature
+// by publicKeys of messages.  If opts is nil, plain Ed25519 with the
+// default verification behavior will be used.  It will panic if the
+// number of public keys and messages differ, len(opts.Context) is
+// greater than ed25519.ContextMaxSize, or any message is not sha512.Size
+// (if pre-hashed).
+//
+// The VerifyOptions are applied to each public key and R component, and
+// the cofactored aggregate verification equation is always used.  Unlike
+// batch verification, there is no cofactorless equivalent (the random
+// linear combination of cofactorless equations does not imply that each
+// individual equation holds, and the individual S components are not
+// available to fall back to), so VerifyAggregate always returns false
+// if opts.Verify specifies cofactorless verification (eg: the StdLib,
+// Libsodium, and DalekStrict presets).
+func VerifyAggregate(publicKeys []ed25519.PublicKey, messages [][]byte, aggSig []byte, opts *ed25519.Options) bool {
+	n := len(publicKeys)
+	if len(messages) != n {
+		panic("aggregate: len(publicKeys) != len(messages)")
+	}
+
+	dom2, err := ed25519.Dom2(opts)
+	if err != nil {
+		panic(err)
+	}
+	vOpts := ed25519.VerifyOptionsDefault
+	if opts != nil && opts.Verify != nil {
+		vOpts = opts.Verify
+	}
+	if vOpts.CofactorlessVerify {
+		return false
+	}
+	isPh := opts != nil && opts.HashFunc() == crypto.SHA512
+
+	if n == 0 || len(aggSig) != SignatureSize(n) {
+		return false
+	}
+
+	// Unpack S, and ensure it is canonical.
+	sBytes := aggSig[n*RSize:]
+	if !scalar.ScMinimalVartime(sBytes) {
+		return false
+	}
+	var S scalar.Scalar
+	if _, err := S.SetBytesModOrder(sBytes); err != nil {
+		return false
+	}
+
+	// The aggregate verification equation is
+	//
+	// [-s]B + sum([z_i]R_i) + sum([z_i * k_i]A_i) = 0.
+	scalarVals := make([]scalar.Scalar, 1+2*n)
+	pointVals := make([]curve.EdwardsPoint, 2*n)
+	scalars := make([]*scalar.Scalar, 1+2*n)
+	points := make([]*curve.EdwardsPoint, 1+2*n)
+	for i := range scalars {
+		scalars[i] = &scalarVals[i]
+	}
+	for i := range pointVals {
+		points[1+i] = &pointVals[i]
+	}
+	points[0] = curve.ED25519_BASEPOINT_POINT
+
+	Bcoeff, Acoeffs, Rcoeffs := scalars[0], scalars[1:1+n], scalars[1+n:]
+	As, Rs := points[1:1+n], points[1+n:]
+
+	Bcoeff.Neg(&S)
+
+	agg := NewAggregator()
+	for i := 0; i < n; i++ {
+		publicKey, message := publicKeys[i], messages[i]
+		R := aggSig[i*RSize : (i+1)*RSize]
+
+		if isPh && len(message) != sha512.Size {
+			panic("aggregate: bad message hash length: " + strconv.Itoa(len(message)))
+		}
+		if !unpackPoint(As[i], publicKey, vOpts.AllowSmallOrderA, vOpts.AllowNonCanonicalA) {
+			return false
+		}
+		if !unpackPoint(Rs[i], R, vOpts.AllowSmallOrderR, vOpts.AllowNonCanonicalR) {
+			return false
+		}
+
+		// k_i = H(dom2, R_i, A_i, m_i)
+		var (
+			hash [sha512.Size]byte
+			k    scalar.Scalar
+		)
+		h := sha512.New()
+		_, _ = h.Write(dom2)
+		_, _ = h.Write(R)
+		_, _ = h.Write(publicKey)
+		_, _ = h.Write(message)
+		h.Sum(hash[:0])
+		if _, err := k.SetBytesModOrderWide(hash[:]); err != nil {
+			panic("aggregate: failed to deserialize H(R,A,m) scalar: " + err.Error())
+		}
+
+		agg.nextRandomizer(Rcoeffs[i], R, publicKey, message)
+		Acoeffs[i].Mul(Rcoeffs[i], &k)
+	}
+
+	var shouldBeId curve.EdwardsPoint
+	shouldBeId.MultiscalarMulVartime(scalars, points)
+
+	// Note: IsSmallOrder includes a cofactor multiply.
+	return shouldBeId.IsSmallOrder()
+}
+
+func unpackPoint(p *curve.EdwardsPoint, b []byte, allowSmallOrder, allowNonCanonical bool) bool {
+	var compressed curve.CompressedEdwardsY
+	if _, err := compressed.SetBytes(b); err != nil {
+		return false
+	}
+	if _, err := p.SetCompressedY(&compressed); err != nil {
+		return false
+	}
+	if !allowSmallOrder && p.IsSmallOrder() {
+		return false
+	}
+	if !allowNonCanonical && !compressed.IsCanonicalVartime() {
+		return false
+	}
+
+	return true
+}
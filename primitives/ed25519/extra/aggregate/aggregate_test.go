@@ -0,0 +1,349 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package aggregate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha512"
+	"fmt"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+type testBatch struct {
+	publicKeys []ed25519.PublicKey
+	messages   [][]byte
+	sigs       [][]byte
+}
+
+func newTestBatch(t testing.TB, n int, opts *ed25519.Options) *testBatch {
+	var b testBatch
+	for i := 0; i < n; i++ {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+
+		msg := []byte(fmt.Sprintf("aggregate test message %d", i))
+		if opts != nil && opts.HashFunc() == crypto.SHA512 {
+			h := sha512.Sum512(msg)
+			msg = h[:]
+		}
+
+		var sig []byte
+		switch opts {
+		case nil:
+			sig = ed25519.Sign(priv, msg)
+		default:
+			if sig, err = priv.Sign(nil, msg, opts); err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+		}
+
+		b.publicKeys = append(b.publicKeys, pub)
+		b.messages = append(b.messages, msg)
+		b.sigs = append(b.sigs, sig)
+	}
+
+	return &b
+}
+
+func TestAggregate(t *testing.T) {
+	t.Run("AggregateVerify", testAggregateVerify)
+	t.Run("AggregateVerify/Options", testAggregateVerifyOptions)
+	t.Run("Incremental", testAggregateIncremental)
+	t.Run("Invalid", testAggregateInvalid)
+	t.Run("Cofactored", testAggregateCofactored)
+	t.Run("Presets", testAggregatePresets)
+}
+
+func testAggregateVerify(t *testing.T) {
+	for _, n := range []int{1, 2, 16, 200} {
+		b := newTestBatch(t, n, nil)
+
+		aggSig, err := Aggregate(b.publicKeys, b.messages, b.sigs)
+		if err != nil {
+			t.Fatalf("Aggregate(%d): %v", n, err)
+		}
+		if l := len(aggSig); l != SignatureSize(n) {
+			t.Fatalf("Aggregate(%d): bad aggregate signature length: %d", n, l)
+		}
+		if !VerifyAggregate(b.publicKeys, b.messages, aggSig, nil) {
+			t.Fatalf("VerifyAggregate(%d): valid aggregate signature rejected", n)
+		}
+	}
+}
+
+func testAggregateVerifyOptions(t *testing.T) {
+	for _, opts := range []*ed25519.Options{
+		{Context: "test context"},
+		{Hash: crypto.SHA512},
+		{Hash: crypto.SHA512, Context: "test context"},
+		{Verify: ed25519.VerifyOptionsFIPS_186_5},
+		{Verify: ed25519.VerifyOptionsZIP_215},
+	} {
+		b := newTestBatch(t, 8, opts)
+
+		aggSig, err := Aggregate(b.publicKeys, b.messages, b.sigs)
+		if err != nil {
+			t.Fatalf("Aggregate: %v", err)
+		}
+		if !VerifyAggregate(b.publicKeys, b.messages, aggSig, opts) {
+			t.Fatalf("VerifyAggregate(%+v): valid aggregate signature rejected", opts)
+		}
+		if opts.Context != "" && VerifyAggregate(b.publicKeys, b.messages, aggSig, &ed25519.Options{Hash: opts.Hash}) {
+			t.Fatalf("VerifyAggregate(%+v): aggregate signature accepted without context", opts)
+		}
+	}
+}
+
+func testAggregateIncremental(t *testing.T) {
+	b := newTestBatch(t, 10, nil)
+
+	expected, err := Aggregate(b.publicKeys, b.messages, b.sigs)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+
+	aggSig, err := Aggregate(b.publicKeys[:4], b.messages[:4], b.sigs[:4])
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if aggSig, err = IncrementalAggregate(aggSig, b.publicKeys[:7], b.messages[:7], b.sigs[4:7]); err != nil {
+		t.Fatalf("IncrementalAggregate: %v", err)
+	}
+	if aggSig, err = IncrementalAggregate(aggSig, b.publicKeys, b.messages, b.sigs[7:]); err != nil {
+		t.Fatalf("IncrementalAggregate: %v", err)
+	}
+	if !bytes.Equal(aggSig, expected) {
+		t.Fatalf("incremental aggregate signature mismatch")
+	}
+
+	agg := NewAggregator()
+	for i := range b.sigs {
+		if err = agg.Add(b.publicKeys[i], b.messages[i], b.sigs[i]); err != nil {
+			t.Fatalf("Aggregator.Add: %v", err)
+		}
+		if !VerifyAggregate(b.publicKeys[:i+1], b.messages[:i+1], agg.Signature(), nil) {
+			t.Fatalf("VerifyAggregate: valid partial aggregate signature rejected")
+		}
+	}
+	if agg.Len() != len(b.sigs) {
+		t.Fatalf("Aggregator.Len: %d", agg.Len())
+	}
+	if !bytes.Equal(agg.Signature(), expected) {
+		t.Fatalf("Aggregator signature mismatch")
+	}
+}
+
+func testAggregateInvalid(t *testing.T) {
+	b := newTestBatch(t, 8, nil)
+
+	aggSig, err := Aggregate(b.publicKeys, b.messages, b.sigs)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+
+	// Wrong message.
+	messages := append([][]byte{}, b.messages...)
+	messages[3] = []byte("wrong message")
+	if VerifyAggregate(b.publicKeys, messages, aggSig, nil) {
+		t.Fatalf("aggregate signature with wrong message accepted")
+	}
+
+	// Swapped entries.
+	publicKeys := append([]ed25519.PublicKey{}, b.publicKeys...)
+	publicKeys[0], publicKeys[1] = publicKeys[1], publicKeys[0]
+	messages = append([][]byte{}, b.messages...)
+	messages[0], messages[1] = messages[1], messages[0]
+	if VerifyAggregate(publicKeys, messages, aggSig, nil) {
+		t.Fatalf("aggregate signature with reordered entries accepted")
+	}
+
+	// Corrupted S.
+	badAggSig := append([]byte{}, aggSig...)
+	badAggSig[len(badAggSig)-SSize] ^= 1
+	if VerifyAggregate(b.publicKeys, b.messages, badAggSig, nil) {
+		t.Fatalf("aggregate signature with corrupted S accepted")
+	}
+
+	// Invalid signature.
+	sigs := append([][]byte{}, b.sigs...)
+	sigs[5] = append([]byte{}, sigs[5]...)
+	sigs[5][RSize] ^= 1
+	if badAggSig, err = Aggregate(b.publicKeys, b.messages, sigs); err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if VerifyAggregate(b.publicKeys, b.messages, badAggSig, nil) {
+		t.Fatalf("aggregate signature with invalid signature accepted")
+	}
+
+	// Truncated.
+	if VerifyAggregate(b.publicKeys, b.messages, aggSig[:len(aggSig)-1], nil) {
+		t.Fatalf("truncated aggregate signature accepted")
+	}
+	if VerifyAggregate(nil, nil, aggSig[len(aggSig)-SSize:], nil) {
+		t.Fatalf("empty aggregate signature accepted")
+	}
+
+	// Malformed inputs.
+	if _, err = Aggregate(b.publicKeys, b.messages, append(b.sigs, b.sigs[0])); err == nil {
+		t.Fatalf("Aggregate accepted too many signatures")
+	}
+	if _, err = Aggregate(b.publicKeys[:1], b.messages[:1], [][]byte{b.sigs[0][:63]}); err == nil {
+		t.Fatalf("Aggregate accepted a truncated signature")
+	}
+}
+
+func testAggregateCofactored(t *testing.T) {
+	// Construct a signature that is only valid under the cofactored
+	// verification equation, by adding a small order point to R.
+	seed := testhelpers.MustUnhex(t, "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	priv := ed25519.NewKeyFromSeed(seed)
+	pub := priv.Public().(ed25519.PublicKey)
+	msg := []byte("cofactored test message")
+
+	extsk := sha512.Sum512(seed)
+	extsk[0] &= 248
+	extsk[31] &= 127
+	extsk[31] |= 64
+	var a, r, k scalar.Scalar
+	if _, err := a.SetBits(extsk[:32]); err != nil {
+		t.Fatalf("SetBits: %v", err)
+	}
+	r.SetUint64(0xdeadbeef)
+
+	var (
+		T, R        curve.EdwardsPoint
+		tCompressed curve.CompressedEdwardsY
+		rCompressed curve.CompressedEdwardsY
+	)
+	_, _ = tCompressed.SetBytes(testhelpers.MustUnhex(t, "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05"))
+	if _, err := T.SetCompressedY(&tCompressed); err != nil {
+		t.Fatalf("SetCompressedY: %v", err)
+	}
+	R.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &r)
+	R.Add(&R, &T)
+	rCompressed.SetEdwardsPoint(&R)
+
+	h := sha512.New()
+	_, _ = h.Write(rCompressed[:])
+	_, _ = h.Write(pub)
+	_, _ = h.Write(msg)
+	if _, err := k.SetBytesModOrderWide(h.Sum(nil)); err != nil {
+		t.Fatalf("SetBytesModOrderWide: %v", err)
+	}
+
+	var S scalar.Scalar
+	S.Mul(&k, &a)
+	S.Add(&S, &r)
+	sig := append([]byte{}, rCompressed[:]...)
+	sig = append(sig, make([]byte, SSize)...)
+	_ = S.ToBytes(sig[RSize:])
+
+	if !ed25519.Verify(pub, msg, sig) || ed25519.VerifyWithOptions(pub, msg, sig, &ed25519.Options{Verify: ed25519.VerifyOptionsStdLib}) {
+		t.Fatalf("test signature is not cofactored-only")
+	}
+
+	b := newTestBatch(t, 4, nil)
+	b.publicKeys = append(b.publicKeys, pub)
+	b.messages = append(b.messages, msg)
+	b.sigs = append(b.sigs, sig)
+
+	aggSig, err := Aggregate(b.publicKeys, b.messages, b.sigs)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if !VerifyAggregate(b.publicKeys, b.messages, aggSig, nil) {
+		t.Fatalf("cofactored aggregate signature rejected")
+	}
+
+}
+
+func testAggregatePresets(t *testing.T) {
+	b := newTestBatch(t, 8, nil)
+
+	aggSig, err := Aggregate(b.publicKeys, b.messages, b.sigs)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+
+	// The cofactorless presets are unsupported, and always reject.
+	for _, tc := range []struct {
+		name     string
+		vOpts    *ed25519.VerifyOptions
+		expected bool
+	}{
+		{"Default", ed25519.VerifyOptionsDefault, true},
+		{"StdLib", ed25519.VerifyOptionsStdLib, false},
+		{"FIPS_186_5", ed25519.VerifyOptionsFIPS_186_5, true},
+		{"ZIP_215", ed25519.VerifyOptionsZIP_215, true},
+		{"Libsodium", ed25519.VerifyOptionsLibsodium, false},
+		{"Dalek", ed25519.VerifyOptionsDalek, false},
+		{"DalekStrict", ed25519.VerifyOptionsDalekStrict, false},
+		{"BoringSSL", ed25519.VerifyOptionsBoringSSL, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if ok := VerifyAggregate(b.publicKeys, b.messages, aggSig, &ed25519.Options{Verify: tc.vOpts}); ok != tc.expected {
+				t.Fatalf("VerifyAggregate: %v (expected %v)", ok, tc.expected)
+			}
+		})
+	}
+}
+
+func BenchmarkAggregate(b *testing.B) {
+	for _, n := range []int{16, 256} {
+		batch := newTestBatch(b, n, nil)
+		aggSig, err := Aggregate(batch.publicKeys, batch.messages, batch.sigs)
+		if err != nil {
+			b.Fatalf("Aggregate: %v", err)
+		}
+
+		b.Run(fmt.Sprintf("Aggregate/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = Aggregate(batch.publicKeys, batch.messages, batch.sigs)
+			}
+		})
+		b.Run(fmt.Sprintf("VerifyAggregate/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !VerifyAggregate(batch.publicKeys, batch.messages, aggSig, nil) {
+					b.Fatalf("VerifyAggregate: valid aggregate signature rejected")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package blinding implements Ed25519 key blinding, as in the Ed25519-SHA512
+// construction of the "Key Blinding for Signature Schemes" draft
+// (draft-irtf-cfrg-signature-key-blinding), and the variant used by Tor
+// v3 onion services (rend-spec-v3 Appendix A.2).
+//
+// Key blinding allows a holder of a private key and a secret blinding key
+// to derive a blinded key pair, such that signatures made by the blinded
+// private key are verifiable with the blinded public key via standard
+// Ed25519 verification, while the blinded public key is unlinkable to the
+// original public key without knowledge of the blinding key.
+//
+// For the draft construction, given a blinding key bk and context ctx,
+// the blinding scalar s is the lower half of SHA-512(bk || ctx), reduced
+// modulo the group order.  The blinded public key is [s]A, the blinded
+// secret scalar is s * a, and the blinded nonce prefix is the first 32
+// bytes of SHA-512(prefix || upper half of SHA-512(bk || ctx)).
+package blinding
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const (
+	// BlindingKeySize is the size, in bytes, of blinding keys.
+	BlindingKeySize = 32
+
+	// TorParameterSize is the size, in bytes, of a Tor blinding parameter.
+	TorParameterSize = 32
+
+	// TorDefaultPeriodLength is the default Tor time period length, in
+	// minutes.
+	TorDefaultPeriodLength = 1440
+
+	torBlindString       = "Derive temporary signing key\x00"
+	torPrefixString      = "Derive temporary signing key hash input"
+	torNonceString       = "key-blind"
+	torBasepointString   = "(15112221349535400772501151409588531511454012693041857206046113283949847762202, 46316835694926478169428394003475163141307993866256225615783033603165251855960)"
+	blindingScalarLength = 32
+)
+
+// BlindPublicKey blinds publicKey with blindingKey and context.
+func BlindPublicKey(publicKey ed25519.PublicKey, blindingKey, context []byte) (ed25519.PublicKey, error) {
+	var s scalar.Scalar
+	if _, err := deriveBlindingScalar(&s, nil, blindingKey, context); err != nil {
+		return nil, err
+	}
+
+	return mulPublicKey(publicKey, &s)
+}
+
+// UnblindPublicKey recovers the public key that was blinded with
+// blindingKey and context to produce blindedPublicKey.
+func UnblindPublicKey(blindedPublicKey ed25519.PublicKey, blindingKey, context []byte) (ed25519.PublicKey, error) {
+	var s scalar.Scalar
+	if _, err := deriveBlindingScalar(&s, nil, blindingKey, context); err != nil {
+		return nil, err
+	}
+
+	return mulPublicKey(blindedPublicKey, s.Invert(&s))
+}
+
+// BlindPrivateKey blinds privateKey with blindingKey and context.  The
+// returned key will produce signatures that are verifiable with the
+// public key returned by BlindPublicKey.
+func BlindPrivateKey(privateKey ed25519.PrivateKey, blindingKey, context []byte) (*ed25519.ExpandedPrivateKey, error) {
+	var (
+		s           scalar.Scalar
+		blindPrefix [32]byte
+	)
+	if _, err := deriveBlindingScalar(&s, blindPrefix[:], blindingKey, context); err != nil {
+		return nil, err
+	}
+
+	k, err := expandPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha512.New()
+	_, _ = h.Write(k.Prefix())
+	_, _ = h.Write(blindPrefix[:])
+
+	return newBlindedKey(k.SecretScalar(), &s, h.Sum(nil)[:32])
+}
+
+// BlindKeySign signs the message with privateKey blinded with blindingKey
+// and context, and returns a signature.  The signature is verifiable with
+// the public key returned by BlindPublicKey.
+func BlindKeySign(privateKey ed25519.PrivateKey, blindingKey, context, message []byte) ([]byte, error) {
+	blindedKey, err := BlindPrivateKey(privateKey, blindingKey, context)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed25519.SignExpanded(blindedKey, message), nil
+}
+
+// TorBlindingParameter derives the Tor blinding parameter for publicKey
+// and the given time period, as specified in rend-spec-v3 Appendix A.2.
+// The optional secret is included in the derivation if non-empty.
+func TorBlindingParameter(publicKey ed25519.PublicKey, secret []byte, periodNumber, periodLength uint64) ([]byte, error) {
+	if l := len(publicKey); l != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("blinding: bad public key length: %d", l)
+	}
+
+	// N = "key-blind" | INT_8(period-number) | INT_8(period_length)
+	var nonce [len(torNonceString) + 16]byte
+	copy(nonce[:], torNonceString)
+	binary.BigEndian.PutUint64(nonce[len(torNonceString):], periodNumber)
+	binary.BigEndian.PutUint64(nonce[len(torNonceString)+8:], periodLength)
+
+	// h = H(BLIND_STRING | A | s | B | N)
+	h := sha3.New256()
+	_, _ = h.Write([]byte(torBlindString))
+	_, _ = h.Write(publicKey)
+	_, _ = h.Write(secret)
+	_, _ = h.Write([]byte(torBasepointString))
+	_, _ = h.Write(nonce[:])
+
+	return h.Sum(nil), nil
+}
+
+// TorBlindPublicKey blinds publicKey with the Tor blinding parameter.
+func TorBlindPublicKey(publicKey ed25519.PublicKey, param []byte) (ed25519.PublicKey, error) {
+	var h scalar.Scalar
+	if _, err := deriveTorScalar(&h, param); err != nil {
+		return nil, err
+	}
+
+	return mulPublicKey(publicKey, &h)
+}
+
+// TorBlindPrivateKey blinds privateKey with the Tor blinding parameter.
+// The returned key will produce signatures that are verifiable with the
+// public key returned by TorBlindPublicKey.
+func TorBlindPrivateKey(privateKey ed25519.PrivateKey, param []byte) (*ed25519.ExpandedPrivateKey, error) {
+	var h scalar.Scalar
+	if _, err := deriveTorScalar(&h, param); err != nil {
+		return nil, err
+	}
+
+	k, err := expandPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// RH' = SHA-512("Derive temporary signing key hash input" | RH)[:32]
+	hPrefix := sha512.New()
+	_, _ = hPrefix.Write([]byte(torPrefixString))
+	_, _ = hPrefix.Write(k.Prefix())
+
+	return newBlindedKey(k.SecretScalar(), &h, hPrefix.Sum(nil)[:32])
+}
+
+func deriveBlindingScalar(s *scalar.Scalar, blindPrefix, blindingKey, context []byte) (*scalar.Scalar, error) {
+	if l := len(blindingKey); l != BlindingKeySize {
+		return nil, fmt.Errorf("blinding: bad blinding key length: %d", l)
+	}
+
+	h := sha512.New()
+	_, _ = h.Write(blindingKey)
+	_, _ = h.Write(context)
+	digest := h.Sum(nil)
+
+	if _, err := s.SetBytesModOrder(digest[:blindingScalarLength]); err != nil {
+		return nil, fmt.Errorf("blinding: failed to deserialize blinding scalar: %w", err)
+	}
+	if s.Equal(scalar.New()) == 1 {
+		return nil, fmt.Errorf("blinding: blinding scalar is zero")
+	}
+	if blindPrefix != nil {
+		copy(blindPrefix, digest[blindingScalarLength:])
+	}
+
+	return s, nil
+}
+
+func deriveTorScalar(h *scalar.Scalar, param []byte) (*scalar.Scalar, error) {
+	if l := len(param); l != TorParameterSize {
+		return nil, fmt.Errorf("blinding: bad blinding parameter length: %d", l)
+	}
+
+	var tweak [TorParameterSize]byte
+	copy(tweak[:], param)
+	tweak[0] &= 248
+	tweak[31] &= 63
+	tweak[31] |= 64
+
+	if _, err := h.SetBytesModOrder(tweak[:]); err != nil {
+		return nil, fmt.Errorf("blinding: failed to deserialize blinding scalar: %w", err)
+	}
+
+	return h, nil
+}
+
+func expandPrivateKey(privateKey ed25519.PrivateKey) (*ed25519.ExpandedPrivateKey, error) {
+	if l := len(privateKey); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("blinding: bad private key length: %d", l)
+	}
+
+	k, err := ed25519.NewExpandedPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("blinding: failed to expand private key: %w", err)
+	}
+
+	return k, nil
+}
+
+func newBlindedKey(a, s *scalar.Scalar, prefix []byte) (*ed25519.ExpandedPrivateKey, error) {
+	var aBytes [scalar.ScalarSize]byte
+	if err := a.Mul(a, s).ToBytes(aBytes[:]); err != nil {
+		return nil, fmt.Errorf("blinding: failed to serialize blinded scalar: %w", err)
+	}
+
+	k, err := ed25519.NewExpandedPrivateKeyFromScalar(aBytes[:], prefix)
+	if err != nil {
+		return nil, fmt.Errorf("blinding: failed to create blinded key: %w", err)
+	}
+
+	return k, nil
+}
+
+func mulPublicKey(publicKey ed25519.PublicKey, s *scalar.Scalar) (ed25519.PublicKey, error) {
+	if l := len(publicKey); l != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("blinding: bad public key length: %d", l)
+	}
+
+	var (
+		compressed curve.CompressedEdwardsY
+		A          curve.EdwardsPoint
+	)
+	if _, err := compressed.SetBytes(publicKey); err != nil {
+		return nil, fmt.Errorf("blinding: failed to deserialize public key: %w", err)
+	}
+	if _, err := A.SetCompressedY(&compressed); err != nil {
+		return nil, fmt.Errorf("blinding: failed to decompress public key: %w", err)
+	}
+	if A.IsSmallOrder() {
+		return nil, fmt.Errorf("blinding: small order public key")
+	}
+
+	compressed.SetEdwardsPoint(A.Mul(&A, s))
+
+	return ed25519.PublicKey(compressed[:]), nil
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package blinding
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+func TestBlinding(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	blindingKey := make([]byte, BlindingKeySize)
+	for i := range blindingKey {
+		blindingKey[i] = byte(i)
+	}
+	msg := []byte("test message")
+
+	t.Run("Draft", func(t *testing.T) {
+		for _, context := range [][]byte{nil, []byte("context")} {
+			t.Run(fmt.Sprintf("Context_%q", context), func(t *testing.T) {
+				blindedPub, err := BlindPublicKey(pub, blindingKey, context)
+				if err != nil {
+					t.Fatalf("BlindPublicKey: %v", err)
+				}
+				if bytes.Equal(pub, blindedPub) {
+					t.Fatalf("BlindPublicKey: blinded key == public key")
+				}
+
+				blindedPriv, err := BlindPrivateKey(priv, blindingKey, context)
+				if err != nil {
+					t.Fatalf("BlindPrivateKey: %v", err)
+				}
+				if p := blindedPriv.Public().(ed25519.PublicKey); !bytes.Equal(blindedPub, p) {
+					t.Fatalf("BlindPrivateKey: public key mismatch (Got: %x, Expected: %x)", p, blindedPub)
+				}
+
+				sig, err := BlindKeySign(priv, blindingKey, context, msg)
+				if err != nil {
+					t.Fatalf("BlindKeySign: %v", err)
+				}
+				if !ed25519.Verify(blindedPub, msg, sig) {
+					t.Fatalf("ed25519.Verify(blindedPub): failed")
+				}
+				if ed25519.Verify(pub, msg, sig) {
+					t.Fatalf("ed25519.Verify(pub): succeeded")
+				}
+
+				unblindedPub, err := UnblindPublicKey(blindedPub, blindingKey, context)
+				if err != nil {
+					t.Fatalf("UnblindPublicKey: %v", err)
+				}
+				if !bytes.Equal(pub, unblindedPub) {
+					t.Fatalf("UnblindPublicKey: mismatch (Got: %x, Expected: %x)", unblindedPub, pub)
+				}
+			})
+		}
+
+		pub1, _ := BlindPublicKey(pub, blindingKey, nil)
+		pub2, _ := BlindPublicKey(pub, blindingKey, []byte("context"))
+		if bytes.Equal(pub1, pub2) {
+			t.Fatalf("BlindPublicKey: context ignored")
+		}
+	})
+	t.Run("Tor", func(t *testing.T) {
+		param, err := TorBlindingParameter(pub, nil, 19000, TorDefaultPeriodLength)
+		if err != nil {
+			t.Fatalf("TorBlindingParameter: %v", err)
+		}
+		param2, _ := TorBlindingParameter(pub, nil, 19001, TorDefaultPeriodLength)
+		if bytes.Equal(param, param2) {
+			t.Fatalf("TorBlindingParameter: period number ignored")
+		}
+		param3, _ := TorBlindingParameter(pub, []byte("secret"), 19000, TorDefaultPeriodLength)
+		if bytes.Equal(param, param3) {
+			t.Fatalf("TorBlindingParameter: secret ignored")
+		}
+
+		blindedPub, err := TorBlindPublicKey(pub, param)
+		if err != nil {
+			t.Fatalf("TorBlindPublicKey: %v", err)
+		}
+		blindedPriv, err := TorBlindPrivateKey(priv, param)
+		if err != nil {
+			t.Fatalf("TorBlindPrivateKey: %v", err)
+		}
+		if p := blindedPriv.Public().(ed25519.PublicKey); !bytes.Equal(blindedPub, p) {
+			t.Fatalf("TorBlindPrivateKey: public key mismatch (Got: %x, Expected: %x)", p, blindedPub)
+		}
+
+		sig := ed25519.SignExpanded(blindedPriv, msg)
+		if !ed25519.Verify(blindedPub, msg, sig) {
+			t.Fatalf("ed25519.Verify(blindedPub): failed")
+		}
+	})
+	t.Run("Draft/KAT", func(t *testing.T) {
+		// These vectors were generated with an independent big-integer
+		// implementation of the construction, following the Ed25519
+		// reference code from RFC 8032.
+		for i, vec := range []struct {
+			seed, publicKey, blindingKey, context, blindedPublicKey, message, signature string
+		}{
+			{
+				seed:             "0000000000000000000000000000000000000000000000000000000000000000",
+				publicKey:        "3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29",
+				blindingKey:      "0101010101010101010101010101010101010101010101010101010101010101",
+				context:          "",
+				blindedPublicKey: "afe7d190d39456279304dc95f7ccd5504121ca854b40263848183c7b34840598",
+				message:          "",
+				signature:        "c24b05dddd05d9f014842a4b06ee9c219ede6a673585651fcefbe8d3da688fde8ffeb22dc3cb2169aa03b5609a6c295cd56301193f970d4964f19eb01de55307",
+			},
+			{
+				seed:             "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				publicKey:        "03a107bff3ce10be1d70dd18e74bc09967e4d6309ba50d5f1ddc8664125531b8",
+				blindingKey:      "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+				context:          "636f6e74657874",
+				blindedPublicKey: "c9ab44c00fa3f666c9c24c775bcdc4241dfed34dfbe1297aef7acce3c3af25a5",
+				message:          "74657374206d657373616765",
+				signature:        "22b0b413f12f3c743a260ab5cac9dbebe354a7f4bdfa00f4e084ccb52a337a3faf92a87b61f40c8cb4428112312b464a880eaa213f9f5bbba8e5adec620b4e0a",
+			},
+			{
+				seed:             "1ea7ad119cf5e275415ba3dd4642e1ee181fc99041bae962b2d32553d679c119",
+				publicKey:        "d0c941d54247d02e0149880d8268d7a1003dc969cba4371dcaf5c10233980ddb",
+				blindingKey:      "822c3fe405240c749694760ced56174ccbee4c1a52e0e766b239cbcb4437e3c6",
+				context:          "00000000000000000000000000000000",
+				blindedPublicKey: "0adb0a8e30ef53d25299c8dfc7268d0fdc5babc0708efcf5bec3572250209c48",
+				message:          "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f60616263",
+				signature:        "97e3d3ed5d50f933495b2a380c7c5d1cbb4c718f0e3bc50410cb9d89c41e7ef2ab57e8efcd0d13b8bb010dad05f759a556577c27bf7aaec8416036278c02b00c",
+			},
+		} {
+			seed := testhelpers.MustUnhex(t, vec.seed)
+			blindingKey := testhelpers.MustUnhex(t, vec.blindingKey)
+			context := testhelpers.MustUnhex(t, vec.context)
+			message := testhelpers.MustUnhex(t, vec.message)
+
+			priv := ed25519.NewKeyFromSeed(seed)
+			pub := priv.Public().(ed25519.PublicKey)
+			if expected := testhelpers.MustUnhex(t, vec.publicKey); !bytes.Equal(pub, expected) {
+				t.Fatalf("%d: public key mismatch (Got: %x, Expected: %x)", i, pub, expected)
+			}
+
+			blindedPub, err := BlindPublicKey(pub, blindingKey, context)
+			if err != nil {
+				t.Fatalf("%d: BlindPublicKey: %v", i, err)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.blindedPublicKey); !bytes.Equal(blindedPub, expected) {
+				t.Fatalf("%d: BlindPublicKey: mismatch (Got: %x, Expected: %x)", i, blindedPub, expected)
+			}
+
+			sig, err := BlindKeySign(priv, blindingKey, context, message)
+			if err != nil {
+				t.Fatalf("%d: BlindKeySign: %v", i, err)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.signature); !bytes.Equal(sig, expected) {
+				t.Fatalf("%d: BlindKeySign: mismatch (Got: %x, Expected: %x)", i, sig, expected)
+			}
+		}
+	})
+	t.Run("Tor/KAT", func(t *testing.T) {
+		// These vectors were generated with an independent big-integer
+		// implementation of blindPK and blindESK from Tor's
+		// src/test/ed25519_exts_ref.py, with the blinding parameter
+		// derived as in rend-spec-v3 Appendix A.2.
+		for i, vec := range []struct {
+			seed, publicKey, secret                     string
+			periodNumber, periodLength                  uint64
+			param, blindedPublicKey, message, signature string
+		}{
+			{
+				seed:             "0000000000000000000000000000000000000000000000000000000000000000",
+				publicKey:        "3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29",
+				secret:           "",
+				periodNumber:     0,
+				periodLength:     1440,
+				param:            "88b6df24993edad808cf181deff10cf6f9b4bfaa432ce2e3255e493b523c85a8",
+				blindedPublicKey: "229369636ac25208b6c54e4a1da93d455e255fe7c6e581e71da5915dc6d03864",
+				message:          "",
+				signature:        "18cb98d5934ed5d62777009b7f3c2b6afa768a1ca1b2c4963e45ddfaaf386b4598fdd839312a2def7a333d2e86045bc32807f9a50788cd37ca85c05821456009",
+			},
+			{
+				seed:             "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				publicKey:        "03a107bff3ce10be1d70dd18e74bc09967e4d6309ba50d5f1ddc8664125531b8",
+				secret:           "",
+				periodNumber:     19000,
+				periodLength:     1440,
+				param:            "029a6f2dce03d63d1bf3890f91117a8f4302718c8473fd6dd5d48523d22ecab5",
+				blindedPublicKey: "4273564583706ba537bbc6d1322248923ed6841f62850848d898e18e5e460958",
+				message:          "74657374206d657373616765",
+				signature:        "a7e9d57ecea8617e18b2f5456454ff1cf8638693ab2709ce2e0175e64770152116a76d3a8366dfa82460a62ef0cc1f585e1a35a7f423a62ec769821b4195db0b",
+			},
+			{
+				seed:             "ded02ccdcf9a5f057e15fd67f83aa3ecea234b2b5ecd1774178a028122df3935",
+				publicKey:        "ba4d572656e6aece534d7d3d7d1ce2f0c9b5c2c6118068b42090d428a03c5773",
+				secret:           "736563726574",
+				periodNumber:     20123,
+				periodLength:     720,
+				param:            "b48a4a91f702e7f9f47f8659554f802d85449bf204eff66cdc6dcac13a1a170e",
+				blindedPublicKey: "1c5f5e6119e8bae12d1af359e2fd131ede8d7a7d60ee327d523fec54a626b134",
+				message:          "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+				signature:        "7588c6b0cd6ccbbe39aa010f085dfc7001d6a98e741fe2277829282c37c5887af5f09dc21c25dea8ad4cf0c305d48cc3804f7140209cb19b94b7e10067da1b02",
+			},
+		} {
+			seed := testhelpers.MustUnhex(t, vec.seed)
+			message := testhelpers.MustUnhex(t, vec.message)
+
+			priv := ed25519.NewKeyFromSeed(seed)
+			pub := priv.Public().(ed25519.PublicKey)
+			if expected := testhelpers.MustUnhex(t, vec.publicKey); !bytes.Equal(pub, expected) {
+				t.Fatalf("%d: public key mismatch (Got: %x, Expected: %x)", i, pub, expected)
+			}
+
+			param, err := TorBlindingParameter(pub, testhelpers.MustUnhex(t, vec.secret), vec.periodNumber, vec.periodLength)
+			if err != nil {
+				t.Fatalf("%d: TorBlindingParameter: %v", i, err)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.param); !bytes.Equal(param, expected) {
+				t.Fatalf("%d: TorBlindingParameter: mismatch (Got: %x, Expected: %x)", i, param, expected)
+			}
+
+			blindedPub, err := TorBlindPublicKey(pub, param)
+			if err != nil {
+				t.Fatalf("%d: TorBlindPublicKey: %v", i, err)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.blindedPublicKey); !bytes.Equal(blindedPub, expected) {
+				t.Fatalf("%d: TorBlindPublicKey: mismatch (Got: %x, Expected: %x)", i, blindedPub, expected)
+			}
+
+			blindedPriv, err := TorBlindPrivateKey(priv, param)
+			if err != nil {
+				t.Fatalf("%d: TorBlindPrivateKey: %v", i, err)
+			}
+			sig := ed25519.SignExpanded(blindedPriv, message)
+			if expected := testhelpers.MustUnhex(t, vec.signature); !bytes.Equal(sig, expected) {
+				t.Fatalf("%d: SignExpanded: mismatch (Got: %x, Expected: %x)", i, sig, expected)
+			}
+		}
+	})
+	t.Run("TorBasepointString", func(t *testing.T) {
+		// Recompute the affine coordinates of the basepoint: y = 4/5,
+		// and x is the even square root of (y^2 - 1) / (d*y^2 + 1).
+		p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+		inv := func(x *big.Int) *big.Int { return new(big.Int).ModInverse(x, p) }
+		mul := func(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Mul(a, b), p) }
+
+		d := mul(new(big.Int).Sub(p, big.NewInt(121665)), inv(big.NewInt(121666)))
+		y := mul(big.NewInt(4), inv(big.NewInt(5)))
+		yy := mul(y, y)
+		u := new(big.Int).Mod(new(big.Int).Sub(yy, big.NewInt(1)), p)
+		v := new(big.Int).Mod(new(big.Int).Add(mul(d, yy), big.NewInt(1)), p)
+		x := new(big.Int).ModSqrt(mul(u, inv(v)), p)
+		if x.Bit(0) == 1 {
+			x.Sub(p, x)
+		}
+
+		if s := fmt.Sprintf("(%s, %s)", x, y); s != torBasepointString {
+			t.Fatalf("basepoint string mismatch (Got: %s, Expected: %s)", torBasepointString, s)
+		}
+	})
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := BlindPublicKey(pub, blindingKey[:31], nil); err == nil {
+			t.Fatalf("BlindPublicKey: accepted short blinding key")
+		}
+		if _, err := BlindPublicKey(pub[:31], blindingKey, nil); err == nil {
+			t.Fatalf("BlindPublicKey: accepted short public key")
+		}
+		if _, err := BlindPrivateKey(priv[:63], blindingKey, nil); err == nil {
+			t.Fatalf("BlindPrivateKey: accepted short private key")
+		}
+		if _, err := TorBlindPublicKey(pub, make([]byte, 31)); err == nil {
+			t.Fatalf("TorBlindPublicKey: accepted short parameter")
+		}
+
+		identity := make([]byte, ed25519.PublicKeySize)
+		identity[0] = 1
+		if _, err := BlindPublicKey(identity, blindingKey, nil); err == nil {
+			t.Fatalf("BlindPublicKey: accepted small order public key")
+		}
+	})
+}
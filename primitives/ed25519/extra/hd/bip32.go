@@ -0,0 +1,393 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const (
+	// BIP32SeedSize is the size, in bytes, of a BIP32-Ed25519 master seed.
+	BIP32SeedSize = 32
+
+	// BIP32ExpandedSecretSize is the size, in bytes, of a BIP32-Ed25519
+	// expanded secret (kL || kR).
+	BIP32ExpandedSecretSize = 64
+
+	tagPrivateZ     = 0x00
+	tagPrivateChain = 0x01
+	tagPublicZ      = 0x02
+	tagPublicChain  = 0x03
+)
+
+var (
+	// ErrUnusableSeed is the error returned when a seed does not yield a
+	// valid BIP32-Ed25519 master key, and should be discarded.
+	ErrUnusableSeed = errors.New("hd: seed does not yield a valid master key")
+
+	// ErrInvalidChild is the error returned when a child index does not
+	// yield a valid BIP32-Ed25519 key, and should be skipped.
+	ErrInvalidChild = errors.New("hd: index does not yield a valid child key")
+)
+
+// BIP32PrivateKey is a BIP32-Ed25519 extended private key.
+type BIP32PrivateKey struct {
+	kL        [32]byte
+	kR        [32]byte
+	chainCode [ChainCodeSize]byte
+	publicKey [ed25519.PublicKeySize]byte
+}
+
+// ExpandedSecret returns the expanded secret (kL || kR) of k.
+func (k *BIP32PrivateKey) ExpandedSecret() []byte {
+	secret := make([]byte, 0, BIP32ExpandedSecretSize)
+	secret = append(secret, k.kL[:]...)
+	return append(secret, k.kR[:]...)
+}
+
+// ChainCode returns the chain code of k.
+func (k *BIP32PrivateKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode[:]...)
+}
+
+// PublicKey returns the Ed25519 public key of k.
+func (k *BIP32PrivateKey) PublicKey() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, k.publicKey[:]...)
+}
+
+// Public returns the extended public key corresponding to k.
+func (k *BIP32PrivateKey) Public() *BIP32PublicKey {
+	return &BIP32PublicKey{
+		chainCode: k.chainCode,
+		publicKey: k.publicKey,
+	}
+}
+
+// ExpandedPrivateKey returns the Ed25519 private key of k, for signing.
+func (k *BIP32PrivateKey) ExpandedPrivateKey() (*ed25519.ExpandedPrivateKey, error) {
+	var (
+		a      scalar.Scalar
+		aBytes [scalar.ScalarSize]byte
+	)
+	if _, err := a.SetBytesModOrder(k.kL[:]); err != nil {
+		return nil, fmt.Errorf("hd: failed to deserialize kL: %w", err)
+	}
+	if err := a.ToBytes(aBytes[:]); err != nil {
+		return nil, fmt.Errorf("hd: failed to serialize secret scalar: %w", err)
+	}
+
+	return ed25519.NewExpandedPrivateKeyFromScalar(aBytes[:], k.kR[:])
+}
+
+// Child derives the child key of k with the given index.
+func (k *BIP32PrivateKey) Child(index uint32) (*BIP32PrivateKey, error) {
+	var zTag, cTag byte
+	data := make([]byte, 1, 1+BIP32ExpandedSecretSize+4)
+	switch IsHardened(index) {
+	case true:
+		// Z = HMAC-SHA512(c, 0x00 || kL || kR || i)
+		// c_i = HMAC-SHA512(c, 0x01 || kL || kR || i)[32:]
+		zTag, cTag = tagPrivateZ, tagPrivateChain
+		data = append(data, k.kL[:]...)
+		data = append(data, k.kR[:]...)
+	case false:
+		// Z = HMAC-SHA512(c, 0x02 || A || i)
+		// c_i = HMAC-SHA512(c, 0x03 || A || i)[32:]
+		zTag, cTag = tagPublicZ, tagPublicChain
+		data = append(data, k.publicKey[:]...)
+	}
+	data = appendIndex(data, index)
+
+	z, chainCode := deriveZAndChainCode(k.chainCode[:], data, zTag, cTag)
+
+	// kL_i = 8 * ZL + kL, kR_i = ZR + kR mod 2^256
+	child := &BIP32PrivateKey{
+		chainCode: chainCode,
+	}
+	zl8 := mul8ZL(z[:28])
+	if addBytes(&child.kL, &k.kL, &zl8) != 0 {
+		return nil, ErrInvalidChild
+	}
+	_ = addBytes(&child.kR, &k.kR, (*[32]byte)(z[32:]))
+
+	if err := child.setPublicKey(); err != nil {
+		return nil, err
+	}
+
+	return child, nil
+}
+
+// Derive derives the descendant key of k along the given path.
+func (k *BIP32PrivateKey) Derive(path Path) (*BIP32PrivateKey, error) {
+	var err error
+	for _, index := range path {
+		if k, err = k.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+func (k *BIP32PrivateKey) setPublicKey() error {
+	var a scalar.Scalar
+	if _, err := a.SetBytesModOrder(k.kL[:]); err != nil {
+		return fmt.Errorf("hd: failed to deserialize kL: %w", err)
+	}
+	if a.Equal(scalar.New()) == 1 {
+		return ErrInvalidChild
+	}
+
+	var (
+		A           curve.EdwardsPoint
+		aCompressed curve.CompressedEdwardsY
+	)
+	aCompressed.SetEdwardsPoint(A.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &a))
+	copy(k.publicKey[:], aCompressed[:])
+
+	return nil
+}
+
+// BIP32PublicKey is a BIP32-Ed25519 extended public key.
+type BIP32PublicKey struct {
+	chainCode [ChainCodeSize]byte
+	publicKey [ed25519.PublicKeySize]byte
+}
+
+// ChainCode returns the chain code of k.
+func (k *BIP32PublicKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode[:]...)
+}
+
+// PublicKey returns the Ed25519 public key of k.
+func (k *BIP32PublicKey) PublicKey() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, k.publicKey[:]...)
+}
+
+// Child derives the child key of k with the given index, which must be
+// non-hardened.
+func (k *BIP32PublicKey) Child(index uint32) (*BIP32PublicKey, error) {
+	if IsHardened(index) {
+		return nil, fmt.Errorf("hd: hardened derivation requires a private key")
+	}
+
+	var A curve.EdwardsPoint
+	if err := unpackPublicKey(&A, k.publicKey[:]); err != nil {
+		return nil, err
+	}
+
+	// Z = HMAC-SHA512(c, 0x02 || A || i)
+	// c_i = HMAC-SHA512(c, 0x03 || A || i)[32:]
+	data := make([]byte, 1, 1+ed25519.PublicKeySize+4)
+	data = append(data, k.publicKey[:]...)
+	data = appendIndex(data, index)
+
+	z, chainCode := deriveZAndChainCode(k.chainCode[:], data, tagPublicZ, tagPublicChain)
+
+	// A_i = A + [8 * ZL]B
+	var zl scalar.Scalar
+	zl8 := mul8ZL(z[:28])
+	if _, err := zl.SetBytesModOrder(zl8[:]); err != nil {
+		return nil, fmt.Errorf("hd: failed to deserialize 8 * ZL: %w", err)
+	}
+	var ZL curve.EdwardsPoint
+	A.Add(&A, ZL.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &zl))
+	if A.IsIdentity() {
+		return nil, ErrInvalidChild
+	}
+
+	child := &BIP32PublicKey{
+		chainCode: chainCode,
+	}
+	var aCompressed curve.CompressedEdwardsY
+	aCompressed.SetEdwardsPoint(&A)
+	copy(child.publicKey[:], aCompressed[:])
+
+	return child, nil
+}
+
+// Derive derives the descendant key of k along the given path, which
+// must consist of non-hardened indexes.
+func (k *BIP32PublicKey) Derive(path Path) (*BIP32PublicKey, error) {
+	var err error
+	for _, index := range path {
+		if k, err = k.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// NewBIP32PublicKey creates a new extended public key from a public key
+// and chain code.
+func NewBIP32PublicKey(publicKey ed25519.PublicKey, chainCode []byte) (*BIP32PublicKey, error) {
+	if l := len(chainCode); l != ChainCodeSize {
+		return nil, fmt.Errorf("hd: bad chain code length: %d", l)
+	}
+
+	var A curve.EdwardsPoint
+	if err := unpackPublicKey(&A, publicKey); err != nil {
+		return nil, err
+	}
+
+	var k BIP32PublicKey
+	copy(k.chainCode[:], chainCode)
+	copy(k.publicKey[:], publicKey)
+
+	return &k, nil
+}
+
+// NewBIP32MasterKey derives the BIP32-Ed25519 master key from a seed.
+// If ErrUnusableSeed is returned, the seed should be discarded and a new
+// one generated.
+func NewBIP32MasterKey(seed []byte) (*BIP32PrivateKey, error) {
+	if l := len(seed); l != BIP32SeedSize {
+		return nil, fmt.Errorf("hd: bad seed length: %d", l)
+	}
+
+	// k = H512(seed), discarding seeds where the third highest bit of
+	// kL is set.
+	var k BIP32PrivateKey
+	digest := sha512.Sum512(seed)
+	if digest[31]&0x20 != 0 {
+		return nil, ErrUnusableSeed
+	}
+	digest[0] &= 248
+	digest[31] &= 127
+	digest[31] |= 64
+	copy(k.kL[:], digest[:32])
+	copy(k.kR[:], digest[32:])
+
+	// c = H256(0x01 || seed)
+	h := sha256.New()
+	_, _ = h.Write([]byte{0x01})
+	_, _ = h.Write(seed)
+	h.Sum(k.chainCode[:0])
+
+	if err := k.setPublicKey(); err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+// NewBIP32PrivateKey creates a new extended private key from an expanded
+// secret (kL || kR) and chain code.
+func NewBIP32PrivateKey(expandedSecret, chainCode []byte) (*BIP32PrivateKey, error) {
+	if l := len(expandedSecret); l != BIP32ExpandedSecretSize {
+		return nil, fmt.Errorf("hd: bad expanded secret length: %d", l)
+	}
+	if l := len(chainCode); l != ChainCodeSize {
+		return nil, fmt.Errorf("hd: bad chain code length: %d", l)
+	}
+
+	var k BIP32PrivateKey
+	copy(k.kL[:], expandedSecret[:32])
+	copy(k.kR[:], expandedSecret[32:])
+	copy(k.chainCode[:], chainCode)
+	if err := k.setPublicKey(); err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+func deriveZAndChainCode(key, data []byte, zTag, cTag byte) ([sha512.Size]byte, [ChainCodeSize]byte) {
+	var (
+		z, c      [sha512.Size]byte
+		chainCode [ChainCodeSize]byte
+	)
+
+	mac := hmac.New(sha512.New, key)
+	data[0] = zTag
+	_, _ = mac.Write(data)
+	mac.Sum(z[:0])
+
+	mac.Reset()
+	data[0] = cTag
+	_, _ = mac.Write(data)
+	mac.Sum(c[:0])
+	copy(chainCode[:], c[32:])
+
+	return z, chainCode
+}
+
+func appendIndex(data []byte, index uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], index)
+	return append(data, b[:]...)
+}
+
+func mul8ZL(zl []byte) [32]byte {
+	var (
+		out   [32]byte
+		carry byte
+	)
+	for i, b := range zl {
+		out[i] = b<<3 | carry
+		carry = b >> 5
+	}
+	out[len(zl)] = carry
+	return out
+}
+
+func addBytes(out, a, b *[32]byte) uint16 {
+	var carry uint16
+	for i := range out {
+		v := uint16(a[i]) + uint16(b[i]) + carry
+		out[i] = byte(v)
+		carry = v >> 8
+	}
+	return carry
+}
+
+func unpackPublicKey(A *curve.EdwardsPoint, publicKey []byte) error {
+	if l := len(publicKey); l != ed25519.PublicKeySize {
+		return fmt.Errorf("hd: bad public key length: %d", l)
+	}
+
+	var aCompressed curve.CompressedEdwardsY
+	if _, err := aCompressed.SetBytes(publicKey); err != nil {
+		return fmt.Errorf("hd: failed to deserialize public key: %w", err)
+	}
+	if _, err := A.SetCompressedY(&aCompressed); err != nil {
+		return fmt.Errorf("hd: failed to decompress public key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package hd implements hierarchical deterministic Ed25519 key derivation,
+// as specified in SLIP-0010 and "BIP32-Ed25519 Hierarchical Deterministic
+// Keys over a Non-linear Keyspace" by Khovratovich and Law.
+//
+// SLIP-0010 only supports hardened derivation, and yields seeds that are
+// usable with ed25519.NewKeyFromSeed.  BIP32-Ed25519 additionally supports
+// non-hardened derivation of child public keys from a parent public key,
+// and yields expanded secrets that can not be expressed as a seed.
+package hd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HardenedOffset is the offset added to the index of hardened children.
+const HardenedOffset uint32 = 1 << 31
+
+// ErrNonHardened is the error returned when attempting non-hardened
+// derivation where it is not supported.
+var ErrNonHardened = errors.New("hd: non-hardened derivation not supported")
+
+// Path is a derivation path, consisting of child indexes.
+type Path []uint32
+
+// String returns the string representation of a derivation path, with
+// hardened indexes denoted by an apostrophe (eg: `m/44'/501'/0'`).
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range p {
+		b.WriteString("/")
+		if IsHardened(index) {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedOffset), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return b.String()
+}
+
+// ParsePath parses a derivation path (eg: `m/44'/501'/0'`).  Hardened
+// indexes may be denoted by any of `'`, `h`, or `H`.
+func ParsePath(s string) (Path, error) {
+	components := strings.Split(s, "/")
+	if components[0] != "m" {
+		return nil, fmt.Errorf("hd: path must start with 'm'")
+	}
+
+	path := make(Path, 0, len(components)-1)
+	for _, c := range components[1:] {
+		var hardened bool
+		if l := len(c); l > 0 {
+			switch c[l-1] {
+			case '\'', 'h', 'H':
+				hardened = true
+				c = c[:l-1]
+			}
+		}
+		if c == "" || c[0] == '+' || c[0] == '-' {
+			return nil, fmt.Errorf("hd: invalid path component: '%s'", c)
+		}
+
+		index, err := strconv.ParseUint(c, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("hd: invalid path component: %w", err)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		path = append(path, uint32(index))
+	}
+
+	return path, nil
+}
+
+// IsHardened returns true iff the index is that of a hardened child.
+func IsHardened(index uint32) bool {
+	return index >= HardenedOffset
+}
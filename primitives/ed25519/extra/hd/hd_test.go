@@ -0,0 +1,329 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+type slip10TestVector struct {
+	path       string
+	chainCode  string
+	privateKey string
+	publicKey  string
+}
+
+func TestPath(t *testing.T) {
+	for _, v := range []struct {
+		s        string
+		path     Path
+		expected string
+	}{
+		{"m", Path{}, "m"},
+		{"m/44'/501'/0'", Path{44 + HardenedOffset, 501 + HardenedOffset, HardenedOffset}, "m/44'/501'/0'"},
+		{"m/0H/1h/2", Path{HardenedOffset, 1 + HardenedOffset, 2}, "m/0'/1'/2"},
+		{"m/2147483647'", Path{0xffffffff}, "m/2147483647'"},
+	} {
+		path, err := ParsePath(v.s)
+		if err != nil {
+			t.Fatalf("ParsePath(%s): %v", v.s, err)
+		}
+		if len(path) != len(v.path) {
+			t.Fatalf("ParsePath(%s): length mismatch (Got: %v, Expected: %v)", v.s, path, v.path)
+		}
+		for i := range path {
+			if path[i] != v.path[i] {
+				t.Fatalf("ParsePath(%s): mismatch (Got: %v, Expected: %v)", v.s, path, v.path)
+			}
+		}
+		if s := path.String(); s != v.expected {
+			t.Fatalf("Path.String(): mismatch (Got: %s, Expected: %s)", s, v.expected)
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"n/0",
+		"m/",
+		"m//0",
+		"m/'",
+		"m/-1",
+		"m/+1",
+		"m/0x1",
+		"m/2147483648",
+		"m/2147483648'",
+	} {
+		if _, err := ParsePath(s); err == nil {
+			t.Fatalf("ParsePath(%s): accepted invalid path", s)
+		}
+	}
+}
+
+func TestSLIP10(t *testing.T) {
+	// Test vectors from SLIP-0010 (curve ed25519).
+	t.Run("TestVector1", func(t *testing.T) {
+		testSLIP10Vectors(t, "000102030405060708090a0b0c0d0e0f", []slip10TestVector{
+			{
+				"m",
+				"90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+				"2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+				"00a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed",
+			},
+			{
+				"m/0H",
+				"8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+				"68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+				"008c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c",
+			},
+			{
+				"m/0H/1H",
+				"a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+				"b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+				"001932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187",
+			},
+			{
+				"m/0H/1H/2H",
+				"2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c",
+				"92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+				"00ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1",
+			},
+			{
+				"m/0H/1H/2H/2H",
+				"8f6d87f93d750e0efccda017d662a1b31a266e4a6f5993b15f5c1f07f74dd5cc",
+				"30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+				"008abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c",
+			},
+			{
+				"m/0H/1H/2H/2H/1000000000H",
+				"68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230",
+				"8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+				"003c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a",
+			},
+		})
+	})
+	t.Run("TestVector2", func(t *testing.T) {
+		testSLIP10Vectors(t, "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542", []slip10TestVector{
+			{
+				"m",
+				"ef70a74db9c3a5af931b5fe73ed8e1a53464133654fd55e7a66f8570b8e33c3b",
+				"171cb88b1b3c1db25add599712e36245d75bc65a1a5c9e18d76f9f2b1eab4012",
+				"008fe9693f8fa62a4305a140b9764c5ee01e455963744fe18204b4fb948249308a",
+			},
+			{
+				"m/0H",
+				"0b78a3226f915c082bf118f83618a618ab6dec793752624cbeb622acb562862d",
+				"1559eb2bbec5790b0c65d8693e4d0875b1747f4970ae8b650486ed7470845635",
+				"0086fab68dcb57aa196c77c5f264f215a112c22a912c10d123b0d03c3c28ef1037",
+			},
+			{
+				"m/0H/2147483647H",
+				"138f0b2551bcafeca6ff2aa88ba8ed0ed8de070841f0c4ef0165df8181eaad7f",
+				"ea4f5bfe8694d8bb74b7b59404632fd5968b774ed545e810de9c32a4fb4192f4",
+				"005ba3b9ac6e90e83effcd25ac4e58a1365a9e35a3d3ae5eb07b9e4d90bcf7506d",
+			},
+		})
+	})
+	t.Run("NonHardened", func(t *testing.T) {
+		k, err := NewSLIP10MasterKey(make([]byte, MinSeedSize))
+		if err != nil {
+			t.Fatalf("NewSLIP10MasterKey: %v", err)
+		}
+		if _, err = k.Child(0); err != ErrNonHardened {
+			t.Fatalf("Child(0): unexpected error: %v", err)
+		}
+	})
+}
+
+func testSLIP10Vectors(t *testing.T, seedHex string, vectors []slip10TestVector) {
+	master, err := NewSLIP10MasterKey(testhelpers.MustUnhex(t, seedHex))
+	if err != nil {
+		t.Fatalf("NewSLIP10MasterKey: %v", err)
+	}
+
+	for _, v := range vectors {
+		path, err := ParsePath(v.path)
+		if err != nil {
+			t.Fatalf("ParsePath(%s): %v", v.path, err)
+		}
+		k, err := master.Derive(path)
+		if err != nil {
+			t.Fatalf("Derive(%s): %v", v.path, err)
+		}
+
+		if cc := hex.EncodeToString(k.ChainCode()); cc != v.chainCode {
+			t.Fatalf("%s: chain code mismatch (Got: %s, Expected: %s)", v.path, cc, v.chainCode)
+		}
+		if seed := hex.EncodeToString(k.Seed()); seed != v.privateKey {
+			t.Fatalf("%s: private key mismatch (Got: %s, Expected: %s)", v.path, seed, v.privateKey)
+		}
+		if pub := "00" + hex.EncodeToString(k.PublicKey()); pub != v.publicKey {
+			t.Fatalf("%s: public key mismatch (Got: %s, Expected: %s)", v.path, pub, v.publicKey)
+		}
+	}
+}
+
+func TestBIP32(t *testing.T) {
+	seed := make([]byte, BIP32SeedSize)
+	var (
+		master *BIP32PrivateKey
+		err    error
+	)
+	for i := 0; ; i++ {
+		seed[0] = byte(i)
+		if master, err = NewBIP32MasterKey(seed); err != ErrUnusableSeed {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("NewBIP32MasterKey: %v", err)
+	}
+
+	t.Run("Master", func(t *testing.T) {
+		// The master key uses the same expansion as RFC 8032, so the
+		// derived key pair must match that of the seed.
+		priv := ed25519.NewKeyFromSeed(seed)
+		if pub := priv.Public().(ed25519.PublicKey); !bytes.Equal(pub, master.PublicKey()) {
+			t.Fatalf("master public key mismatch (Got: %x, Expected: %x)", master.PublicKey(), pub)
+		}
+
+		msg := []byte("test message")
+		expPriv, err := master.ExpandedPrivateKey()
+		if err != nil {
+			t.Fatalf("ExpandedPrivateKey: %v", err)
+		}
+		if sig := ed25519.SignExpanded(expPriv, msg); !bytes.Equal(sig, ed25519.Sign(priv, msg)) {
+			t.Fatalf("master signature mismatch")
+		}
+	})
+	t.Run("Derive", func(t *testing.T) {
+		path, _ := ParsePath("m/1852'/1815'/0'/0/7")
+		k, err := master.Derive(path)
+		if err != nil {
+			t.Fatalf("Derive: %v", err)
+		}
+
+		// Non-hardened derivation from the parent public key must yield
+		// the same public key as derivation from the private key.
+		parent, err := master.Derive(path[:3])
+		if err != nil {
+			t.Fatalf("Derive(parent): %v", err)
+		}
+		pubK, err := parent.Public().Derive(path[3:])
+		if err != nil {
+			t.Fatalf("BIP32PublicKey.Derive: %v", err)
+		}
+		if !bytes.Equal(pubK.PublicKey(), k.PublicKey()) {
+			t.Fatalf("public key mismatch (Got: %x, Expected: %x)", pubK.PublicKey(), k.PublicKey())
+		}
+		if !bytes.Equal(pubK.ChainCode(), k.ChainCode()) {
+			t.Fatalf("chain code mismatch (Got: %x, Expected: %x)", pubK.ChainCode(), k.ChainCode())
+		}
+
+		msg := []byte("test message")
+		expPriv, err := k.ExpandedPrivateKey()
+		if err != nil {
+			t.Fatalf("ExpandedPrivateKey: %v", err)
+		}
+		if !ed25519.Verify(pubK.PublicKey(), msg, ed25519.SignExpanded(expPriv, msg)) {
+			t.Fatalf("ed25519.Verify: failed")
+		}
+
+		if _, err = parent.Public().Child(HardenedOffset); err == nil {
+			t.Fatalf("BIP32PublicKey.Child: allowed hardened derivation")
+		}
+
+		k2, err := NewBIP32PrivateKey(k.ExpandedSecret(), k.ChainCode())
+		if err != nil {
+			t.Fatalf("NewBIP32PrivateKey: %v", err)
+		}
+		if !bytes.Equal(k2.PublicKey(), k.PublicKey()) {
+			t.Fatalf("NewBIP32PrivateKey: public key mismatch")
+		}
+		pubK2, err := NewBIP32PublicKey(parent.PublicKey(), parent.ChainCode())
+		if err != nil {
+			t.Fatalf("NewBIP32PublicKey: %v", err)
+		}
+		if pubK2, err = pubK2.Derive(path[3:]); err != nil || !bytes.Equal(pubK2.PublicKey(), k.PublicKey()) {
+			t.Fatalf("NewBIP32PublicKey: derived public key mismatch (err: %v)", err)
+		}
+	})
+	t.Run("Vectors", func(t *testing.T) {
+		// These vectors were generated with an independent big-integer
+		// implementation of the derivation from the paper, with the
+		// child derivation used by Cardano (ZL truncated to 28 bytes,
+		// and kR added modulo 2^256).
+		for _, vec := range []struct {
+			seed, path, expandedSecret, chainCode, publicKey string
+		}{
+			{"99cb21a99a1f320ba0eb620f1e68a632d46bf9a5b29a7dded5e8b92b0b5cd7b6", "m", "b8c77823d31d75873f70efc494194ab892191759c622c9b02314f147b8d85c52bad9fe8a62f191904c36a5b996304e25a531123eede7e2700c9027950f945bbe", "40808f99bf05cb506860d7422984bae994e435f87d21f6b88da6fe42ad06dcb8", "37acd94bcaeb800428a3d6f881cbb2c6be7e6cad34563cdcc69929a60ff67083"},
+			{"99cb21a99a1f320ba0eb620f1e68a632d46bf9a5b29a7dded5e8b92b0b5cd7b6", "m/0'", "90674b0516129341579e9690273b9d27e784c1f73d0892f0211632cbbbd85c52ac984c1147c52e1d346842e32d3db58beedc32f0dd4add6f81d907a008cb5ed5", "b442990676a7d56a9ca91f371eb1361fb78b34ef4c2834236590898fb7b54b1e", "57e277a6fe466846c2759e442b8b5909948b32b1cc66e01a69e1899cfa16e760"},
+			{"99cb21a99a1f320ba0eb620f1e68a632d46bf9a5b29a7dded5e8b92b0b5cd7b6", "m/1'/2", "38287221d7cc750306406f4e537441706f81a3955556f824ebc12bd7bed85c52a0107b07d620cc20dc8dba0fa743b3a5fbd661854f3929a5ebc6f5e9ca0e15de", "9577560b55c8764f3dd40b1a9afb447c342bc2215a1ab444264a42480ddf8049", "6fefcb86fb11c334e79a46eafdf76d39d4e5654f9bbfa9f1f8e17cbedf1c1b81"},
+			{"99cb21a99a1f320ba0eb620f1e68a632d46bf9a5b29a7dded5e8b92b0b5cd7b6", "m/1852'/1815'/0'/0/7", "f000aa3c3770439a48bd10fecdf3de8575007c72f0ace43b6f0935b1ced85c52d49aced439686c2d66ff57df889cde2858a3d5a80a0e6ce873eb207039b1f650", "efb3fa9771226a5ed0499d9d92527491a5317963f58bc0d736fa86e92e7adc8c", "437de43d64ccbc63f694ce11e16ba0791d9569b13af4f1a196956524e2371f15"},
+			{"99cb21a99a1f320ba0eb620f1e68a632d46bf9a5b29a7dded5e8b92b0b5cd7b6", "m/0/1/2147483647'", "480b8ba078d9eec61faec5407394cd872ad2c79f394335f5606373d3c7d85c5259306c2e07c78a50fedab15a5a20923075d6498d66bcf9ef27d40f820f5e129a", "696597b304e25b03506038fae04a9f4afda03cc92435a0ebda5c00846b1afdfe", "cad2dbc816a6cafbf2a8642b72d7fef92bc4e9da7847a913070af7f4b53197e3"},
+			{"229a07dd1e4ccfeb315116e3d7f694e0c95346e3e5ef3d63cda2f51976527661", "m", "10f040c366bc729df178536a41911e18e6a7203edc1425b0b2bd9a9efe3f645a74f2cd1f68644fde47d3aff7a0a9534bfb8dde3b735337d876edd6e5e626ff45", "adf7f39e55d40a30760463d1a0d0fa15abc983d2e24920f5485430cd210b2621", "7035f66c8736193f394bbe71a410d981b1e67f7d3c5782528766ce2fa56f34a0"},
+			{"229a07dd1e4ccfeb315116e3d7f694e0c95346e3e5ef3d63cda2f51976527661", "m/0'", "a859006517431982ed5a83d39875702ba6fe956e07f0d3caf37d87b4fe3f645afa84c6d36c9b6f242c143057975d93e9ca4870ea2b7b79944989b62634a15297", "fe88ebcd50df4cfd63196eeee3534668bacf883bf2e2d3b2176ca8ffcb2ab1f5", "94ca096c1ca246c1406090e4dbe7ef31710269d9239aee229fcecc059f9a8adb"},
+			{"229a07dd1e4ccfeb315116e3d7f694e0c95346e3e5ef3d63cda2f51976527661", "m/1'/2", "a0a1dac67434bbfa5e0b41f26f4bf50f499f616b5f8c5aa0596894b50440645a2dad918cc84e853936a7578d92e84a628b0ae0873954b1834fb7c9cd91e3ccfe", "a10f427ebee205f502a53db906409be3e3ec95b9fb22d88b6331e9e7af0fdf6c", "e0b8fbb0d3b4c0f57bba0cb8ff7bb975e46df12a4b466ec7f5a54a34e9a45df0"},
+			{"229a07dd1e4ccfeb315116e3d7f694e0c95346e3e5ef3d63cda2f51976527661", "m/1852'/1815'/0'/0/7", "a865a90c8bebb339bc4b2ea22bf27d5346d711ebc482acde669fab521240645ad9dc5e0389f974bc899ac78b346a5c872198bc0881a2e7d0b5ea04cad4c06816", "5722d06e68c73102fa5393dc6081b911be9cb4bd912aa6716380b87798c66559", "9ce25593bee3b71db9637ffd7e1d556d64e9f1b50addc0f0193000ceb1a12c34"},
+			{"229a07dd1e4ccfeb315116e3d7f694e0c95346e3e5ef3d63cda2f51976527661", "m/0/1/2147483647'", "90ade52d066a67227c5325ead0c311fbb47eb3f53436abfd15371f220940645ad757eea49ff1c44e42262616ff061f510c8ae9613f11341de4e47570ee073909", "39e8945dbdc70460fc7976a840ec783d5ec0f6b7a155f9c6c93eb0c419c9a2d2", "070a56eed7f2d79eb668ccc4cb27c340bde7671faff48c7670fd374e41e88822"},
+		} {
+			master, err := NewBIP32MasterKey(testhelpers.MustUnhex(t, vec.seed))
+			if err != nil {
+				t.Fatalf("NewBIP32MasterKey(%s): %v", vec.seed, err)
+			}
+			path, err := ParsePath(vec.path)
+			if err != nil {
+				t.Fatalf("ParsePath(%s): %v", vec.path, err)
+			}
+			k, err := master.Derive(path)
+			if err != nil {
+				t.Fatalf("Derive(%s): %v", vec.path, err)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.expandedSecret); !bytes.Equal(k.ExpandedSecret(), expected) {
+				t.Fatalf("%s: expanded secret mismatch (Got: %x, Expected: %x)", vec.path, k.ExpandedSecret(), expected)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.chainCode); !bytes.Equal(k.ChainCode(), expected) {
+				t.Fatalf("%s: chain code mismatch (Got: %x, Expected: %x)", vec.path, k.ChainCode(), expected)
+			}
+			if expected := testhelpers.MustUnhex(t, vec.publicKey); !bytes.Equal(k.PublicKey(), expected) {
+				t.Fatalf("%s: public key mismatch (Got: %x, Expected: %x)", vec.path, k.PublicKey(), expected)
+			}
+		}
+
+		// SHA-512 of this seed has the third highest bit of kL set.
+		if _, err := NewBIP32MasterKey(testhelpers.MustUnhex(t, "86ebf20457f433f4e9599b0e83f36ae548b9743516c86b67c0241790ffb691a8")); err != ErrUnusableSeed {
+			t.Fatalf("NewBIP32MasterKey: expected ErrUnusableSeed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const (
+	// ChainCodeSize is the size, in bytes, of a chain code.
+	ChainCodeSize = 32
+
+	// MinSeedSize is the minimum size, in bytes, of a master seed.
+	MinSeedSize = 16
+
+	// MaxSeedSize is the maximum size, in bytes, of a master seed.
+	MaxSeedSize = 64
+
+	slip10Curve = "ed25519 seed"
+)
+
+// SLIP10Key is a SLIP-0010 extended private key.
+type SLIP10Key struct {
+	seed      [ed25519.SeedSize]byte
+	chainCode [ChainCodeSize]byte
+}
+
+// Seed returns the Ed25519 seed of k, usable with ed25519.NewKeyFromSeed.
+func (k *SLIP10Key) Seed() []byte {
+	return append([]byte{}, k.seed[:]...)
+}
+
+// ChainCode returns the chain code of k.
+func (k *SLIP10Key) ChainCode() []byte {
+	return append([]byte{}, k.chainCode[:]...)
+}
+
+// PrivateKey returns the Ed25519 private key of k.
+func (k *SLIP10Key) PrivateKey() ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(k.seed[:])
+}
+
+// PublicKey returns the Ed25519 public key of k.
+func (k *SLIP10Key) PublicKey() ed25519.PublicKey {
+	return k.PrivateKey().Public().(ed25519.PublicKey)
+}
+
+// Child derives the child key of k with the given index, which must
+// be hardened.
+func (k *SLIP10Key) Child(index uint32) (*SLIP10Key, error) {
+	if !IsHardened(index) {
+		return nil, ErrNonHardened
+	}
+
+	// I = HMAC-SHA512(Key = c_par, Data = 0x00 || ser256(k_par) || ser32(i))
+	var data [1 + ed25519.SeedSize + 4]byte
+	copy(data[1:], k.seed[:])
+	binary.BigEndian.PutUint32(data[1+ed25519.SeedSize:], index)
+
+	return newSLIP10Key(k.chainCode[:], data[:]), nil
+}
+
+// Derive derives the descendant key of k along the given path.
+func (k *SLIP10Key) Derive(path Path) (*SLIP10Key, error) {
+	var err error
+	for _, index := range path {
+		if k, err = k.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// NewSLIP10MasterKey derives the SLIP-0010 master key from a seed.
+func NewSLIP10MasterKey(seed []byte) (*SLIP10Key, error) {
+	if l := len(seed); l < MinSeedSize || l > MaxSeedSize {
+		return nil, fmt.Errorf("hd: bad seed length: %d", l)
+	}
+
+	return newSLIP10Key([]byte(slip10Curve), seed), nil
+}
+
+func newSLIP10Key(key, data []byte) *SLIP10Key {
+	mac := hmac.New(sha512.New, key)
+	_, _ = mac.Write(data)
+	digest := mac.Sum(nil)
+
+	var k SLIP10Key
+	copy(k.seed[:], digest[:32])
+	copy(k.chainCode[:], digest[32:])
+
+	return &k
+}
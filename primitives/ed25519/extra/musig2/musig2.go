@@ -0,0 +1,450 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package musig2 implements MuSig2 n-of-n multi-signatures for Ed25519.
+//
+// The signers' public keys are aggregated into a single public key, and
+// the signers jointly produce a signature under it in two rounds, such
+// that the result is a standard Ed25519 signature that verifies with
+// ed25519.Verify.  See https://eprint.iacr.org/2020/1261.
+//
+// Given the public keys X_1 ... X_n, with L = H_agg(X_1, ..., X_n), each
+// key is weighted by a_i = H_coef(L, X_i), and the aggregate key is
+// X = sum(a_i * X_i).  Each signer commits to two nonces (R_{i,1},
+// R_{i,2}), and with R_j = sum(R_{i,j}), b = H_non(X, R_1, R_2, M) and
+// R = R_1 + b * R_2, the partial signatures are s_i = r_{i,1} +
+// b * r_{i,2} + H(R, X, M) * a_i * x_i.  The signature is (R, sum(s_i)).
+//
+// Only pure Ed25519 (no context, no pre-hashing) is supported.
+package musig2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const (
+	// PublicNonceSize is the size, in bytes, of public nonces.
+	PublicNonceSize = 64
+
+	// PartialSignatureSize is the size, in bytes, of partial signatures.
+	PartialSignatureSize = 32
+
+	keyAggListDomain  = "curve25519-voi/ed25519/extra/musig2: key list"
+	keyAggCoefDomain  = "curve25519-voi/ed25519/extra/musig2: key coefficient"
+	nonceDomain       = "curve25519-voi/ed25519/extra/musig2: nonce"
+	nonceCoefDomain   = "curve25519-voi/ed25519/extra/musig2: nonce coefficient"
+	nonceEntropySize  = 32
+	numNoncesPerParty = 2
+)
+
+// AggregateKey is an aggregate public key, along with the key aggregation
+// coefficient of each signer.
+type AggregateKey struct {
+	publicKeys   []ed25519.PublicKey
+	points       []*curve.EdwardsPoint
+	coefficients []*scalar.Scalar
+	publicKey    ed25519.PublicKey
+}
+
+// PublicKey returns the aggregate public key.  Signatures produced by a
+// Session verify against it with ed25519.Verify.
+func (ak *AggregateKey) PublicKey() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, ak.publicKey...)
+}
+
+// PublicKeys returns the signers' public keys, in the order used for
+// key aggregation.
+func (ak *AggregateKey) PublicKeys() []ed25519.PublicKey {
+	return append([]ed25519.PublicKey{}, ak.publicKeys...)
+}
+
+func (ak *AggregateKey) index(publicKey []byte) int {
+	for i, v := range ak.publicKeys {
+		if bytes.Equal(v, publicKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewAggregateKey aggregates the public keys of the signers into a single
+// public key.  The order of publicKeys is significant, and must be the
+// same for all of the signers.
+func NewAggregateKey(publicKeys []ed25519.PublicKey) (*AggregateKey, error) {
+	if len(publicKeys) == 0 {
+		return nil, fmt.Errorf("musig2: no public keys")
+	}
+
+	ak := &AggregateKey{
+		publicKeys:   make([]ed25519.PublicKey, 0, len(publicKeys)),
+		points:       make([]*curve.EdwardsPoint, 0, len(publicKeys)),
+		coefficients: make([]*scalar.Scalar, 0, len(publicKeys)),
+	}
+
+	hList := sha512.New()
+	_, _ = hList.Write([]byte(keyAggListDomain))
+	for i, pk := range publicKeys {
+		if l := len(pk); l != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("musig2: bad public key length: %d (signer %d)", l, i)
+		}
+		if ak.index(pk) >= 0 {
+			return nil, fmt.Errorf("musig2: duplicate public key (signer %d)", i)
+		}
+
+		var A curve.EdwardsPoint
+		if !unpackPoint(&A, pk) || A.IsSmallOrder() {
+			return nil, fmt.Errorf("musig2: invalid public key (signer %d)", i)
+		}
+		ak.publicKeys = append(ak.publicKeys, append(ed25519.PublicKey{}, pk...))
+		ak.points = append(ak.points, &A)
+		_, _ = hList.Write(pk)
+	}
+	L := hList.Sum(nil)
+
+	for _, pk := range ak.publicKeys {
+		ak.coefficients = append(ak.coefficients, hashToScalar(keyAggCoefDomain, L, pk))
+	}
+
+	var X curve.EdwardsPoint
+	X.MultiscalarMulVartime(ak.coefficients, ak.points)
+	if X.IsIdentity() {
+		return nil, fmt.Errorf("musig2: aggregate public key is the identity")
+	}
+
+	var compressed curve.CompressedEdwardsY
+	compressed.SetEdwardsPoint(&X)
+	ak.publicKey = append(ed25519.PublicKey{}, compressed[:]...)
+
+	return ak, nil
+}
+
+type sessionState int
+
+const (
+	stateCommit sessionState = iota
+	stateSign
+	stateSigned
+)
+
+// Session is a single MuSig2 signing session for one signer.  A session
+// MUST NOT be reused to sign more than one message.
+//
+// The protocol proceeds as follows:
+//
+//  1. Each signer creates a session with AggregateKey.NewSession, and
+//     broadcasts PublicNonce.
+//  2. Each signer passes every signer's public nonce to SetPublicNonces,
+//     then broadcasts the output of PartialSign.
+//  3. Any signer combines the partial signatures with Aggregate.
+type Session struct {
+	aggKey  *AggregateKey
+	message []byte
+	index   int
+	key     scalar.Scalar
+
+	nonces      [numNoncesPerParty]scalar.Scalar
+	publicNonce [PublicNonceSize]byte
+
+	publicNonces [][numNoncesPerParty]*curve.EdwardsPoint
+	b, c         scalar.Scalar
+	rCompressed  curve.CompressedEdwardsY
+
+	state sessionState
+}
+
+// NewSession creates a new signing session over message, for the signer
+// with the private key privateKey.  If rand is nil, crypto/rand.Reader
+// will be used.
+//
+// The secret nonces are derived from fresh entropy, the private key, the
+// aggregate public key and the message.  Unlike regular Ed25519 signing,
+// MuSig2 nonces MUST NOT be deterministic.
+func (ak *AggregateKey) NewSession(rand io.Reader, privateKey ed25519.PrivateKey, message []byte) (*Session, error) {
+	if l := len(privateKey); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("musig2: bad private key length: %d", l)
+	}
+	publicKey := privateKey[ed25519.SeedSize:]
+	idx := ak.index(publicKey)
+	if idx < 0 {
+		return nil, fmt.Errorf("musig2: private key is not a signer")
+	}
+
+	s := &Session{
+		aggKey:  ak,
+		message: append([]byte{}, message...),
+		index:   idx,
+	}
+
+	// Expand the private key.
+	expandedKey, err := ed25519.NewExpandedPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("musig2: failed to expand private key: %w", err)
+	}
+	s.key.Set(expandedKey.SecretScalar())
+	prefix := expandedKey.Prefix()
+
+	var entropy [nonceEntropySize]byte
+	if _, err := io.ReadFull(randReader(rand), entropy[:]); err != nil {
+		return nil, fmt.Errorf("musig2: failed to read entropy: %w", err)
+	}
+
+	for i := range s.nonces {
+		// r_j = H(domain, entropy, prefix, X_i, X, j, M)
+		s.nonces[i].Set(hashToScalar(nonceDomain, entropy[:], prefix, publicKey, ak.publicKey, []byte{byte(i)}, message))
+
+		var (
+			R          curve.EdwardsPoint
+			compressed curve.CompressedEdwardsY
+		)
+		R.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &s.nonces[i])
+		compressed.SetEdwardsPoint(&R)
+		copy(s.publicNonce[i*32:], compressed[:])
+	}
+
+	return s, nil
+}
+
+// PublicNonce returns the signer's public nonce, to be sent to all of
+// the other signers.
+func (s *Session) PublicNonce() []byte {
+	return append([]byte{}, s.publicNonce[:]...)
+}
+
+// SetPublicNonces sets the public nonces of all of the signers, in the
+// same order as the aggregate key's public keys.  This signer's own
+// nonce must be included.
+func (s *Session) SetPublicNonces(publicNonces [][]byte) error {
+	if s.state != stateCommit {
+		return fmt.Errorf("musig2: public nonces already set")
+	}
+	if l, n := len(publicNonces), len(s.aggKey.publicKeys); l != n {
+		return fmt.Errorf("musig2: invalid number of public nonces: %d (expected %d)", l, n)
+	}
+
+	var (
+		nonces    = make([][numNoncesPerParty]*curve.EdwardsPoint, 0, len(publicNonces))
+		aggNonces [numNoncesPerParty]curve.EdwardsPoint
+		aggBytes  [numNoncesPerParty]curve.CompressedEdwardsY
+	)
+	for j := range aggNonces {
+		aggNonces[j].Identity()
+	}
+	for i, b := range publicNonces {
+		if l := len(b); l != PublicNonceSize {
+			return fmt.Errorf("musig2: bad public nonce length: %d (signer %d)", l, i)
+		}
+		if i == s.index && !bytes.Equal(b, s.publicNonce[:]) {
+			return fmt.Errorf("musig2: public nonce mismatch for own signer")
+		}
+
+		var nonce [numNoncesPerParty]*curve.EdwardsPoint
+		for j := range nonce {
+			nonce[j] = curve.NewEdwardsPoint()
+			if !unpackPoint(nonce[j], b[j*32:(j+1)*32]) {
+				return fmt.Errorf("musig2: invalid public nonce (signer %d)", i)
+			}
+			aggNonces[j].Add(&aggNonces[j], nonce[j])
+		}
+		nonces = append(nonces, nonce)
+	}
+
+	// b = H(domain, X, R_1, R_2, M)
+	for j := range aggNonces {
+		aggBytes[j].SetEdwardsPoint(&aggNonces[j])
+	}
+	s.b.Set(hashToScalar(nonceCoefDomain, s.aggKey.publicKey, aggBytes[0][:], aggBytes[1][:], s.message))
+
+	// R = R_1 + b * R_2
+	var R curve.EdwardsPoint
+	R.Mul(&aggNonces[1], &s.b)
+	R.Add(&R, &aggNonces[0])
+	if R.IsIdentity() {
+		// This can only happen if the other signers collude to
+		// cancel out the honest signers' nonces.
+		return fmt.Errorf("musig2: aggregate nonce is the identity")
+	}
+	s.rCompressed.SetEdwardsPoint(&R)
+
+	// c = H(R, X, M)
+	var digest [sha512.Size]byte
+	h := sha512.New()
+	_, _ = h.Write(s.rCompressed[:])
+	_, _ = h.Write(s.aggKey.publicKey)
+	_, _ = h.Write(s.message)
+	h.Sum(digest[:0])
+	if _, err := s.c.SetBytesModOrderWide(digest[:]); err != nil {
+		return fmt.Errorf("musig2: failed to deserialize H(R,A,m) scalar: %w", err)
+	}
+
+	s.publicNonces = nonces
+	s.state = stateSign
+
+	return nil
+}
+
+// PartialSign returns this signer's partial signature.  It may only be
+// called once per session, after SetPublicNonces, and erases the secret
+// nonces from the session.
+func (s *Session) PartialSign() ([]byte, error) {
+	switch s.state {
+	case stateCommit:
+		return nil, fmt.Errorf("musig2: public nonces not set")
+	case stateSigned:
+		return nil, fmt.Errorf("musig2: session already signed")
+	}
+
+	// s_i = r_1 + b * r_2 + c * a_i * x_i
+	var partial, tmp scalar.Scalar
+	partial.Mul(&s.c, s.aggKey.coefficients[s.index])
+	partial.Mul(&partial, &s.key)
+	tmp.Mul(&s.b, &s.nonces[1])
+	partial.Add(&partial, &tmp)
+	partial.Add(&partial, &s.nonces[0])
+
+	for i := range s.nonces {
+		s.nonces[i].Zero()
+	}
+	s.key.Zero()
+	s.state = stateSigned
+
+	partialSignature := make([]byte, PartialSignatureSize)
+	if err := partial.ToBytes(partialSignature); err != nil {
+		return nil, fmt.Errorf("musig2: failed to serialize partial signature: %w", err)
+	}
+
+	return partialSignature, nil
+}
+
+// VerifyPartialSignature verifies the partial signature produced by the
+// i-th signer.
+func (s *Session) VerifyPartialSignature(i int, partialSignature []byte) bool {
+	if s.state == stateCommit || i < 0 || i >= len(s.publicNonces) {
+		return false
+	}
+
+	var partial scalar.Scalar
+	if _, err := partial.SetCanonicalBytes(partialSignature); err != nil {
+		return false
+	}
+	return s.verifyPartial(i, &partial)
+}
+
+func (s *Session) verifyPartial(i int, partial *scalar.Scalar) bool {
+	// s_i * B == R_{i,1} + b * R_{i,2} + (c * a_i) * X_i
+	var ca scalar.Scalar
+	ca.Mul(&s.c, s.aggKey.coefficients[i])
+
+	var lhs, rhs curve.EdwardsPoint
+	lhs.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, partial)
+	rhs.MultiscalarMulVartime(
+		[]*scalar.Scalar{scalar.New().One(), &s.b, &ca},
+		[]*curve.EdwardsPoint{s.publicNonces[i][0], s.publicNonces[i][1], s.aggKey.points[i]},
+	)
+
+	return lhs.Equal(&rhs) == 1
+}
+
+// Aggregate verifies and combines the partial signatures of all of the
+// signers, in the same order as the aggregate key's public keys, into an
+// Ed25519 signature that verifies against the aggregate public key.
+func (s *Session) Aggregate(partialSignatures [][]byte) ([]byte, error) {
+	if s.state == stateCommit {
+		return nil, fmt.Errorf("musig2: public nonces not set")
+	}
+	if l, n := len(partialSignatures), len(s.publicNonces); l != n {
+		return nil, fmt.Errorf("musig2: invalid number of partial signatures: %d (expected %d)", l, n)
+	}
+
+	var sum scalar.Scalar
+	for i, b := range partialSignatures {
+		var partial scalar.Scalar
+		if _, err := partial.SetCanonicalBytes(b); err != nil {
+			return nil, fmt.Errorf("musig2: failed to deserialize partial signature (signer %d): %w", i, err)
+		}
+		if !s.verifyPartial(i, &partial) {
+			return nil, fmt.Errorf("musig2: invalid partial signature (signer %d)", i)
+		}
+		sum.Add(&sum, &partial)
+	}
+
+	sig := make([]byte, ed25519.SignatureSize)
+	copy(sig[:32], s.rCompressed[:])
+	if err := sum.ToBytes(sig[32:]); err != nil {
+		return nil, fmt.Errorf("musig2: failed to serialize signature: %w", err)
+	}
+
+	return sig, nil
+}
+
+func hashToScalar(domain string, inputs ...[]byte) *scalar.Scalar {
+	var digest [sha512.Size]byte
+	h := sha512.New()
+	_, _ = h.Write([]byte(domain))
+	for _, v := range inputs {
+		_, _ = h.Write(v)
+	}
+	h.Sum(digest[:0])
+
+	s, err := scalar.NewFromBytesModOrderWide(digest[:])
+	if err != nil {
+		panic("musig2: failed to deserialize scalar: " + err.Error())
+	}
+	return s
+}
+
+// unpackPoint decompresses a canonically encoded, torsion-free point.
+func unpackPoint(p *curve.EdwardsPoint, b []byte) bool {
+	var compressed curve.CompressedEdwardsY
+	if _, err := compressed.SetBytes(b); err != nil {
+		return false
+	}
+	if !compressed.IsCanonicalVartime() {
+		return false
+	}
+	if _, err := p.SetCompressedY(&compressed); err != nil {
+		return false
+	}
+
+	return p.IsTorsionFree()
+}
+
+func randReader(r io.Reader) io.Reader {
+	if r == nil {
+		return rand.Reader
+	}
+	return r
+}
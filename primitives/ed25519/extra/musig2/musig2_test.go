@@ -0,0 +1,266 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package musig2
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+func TestMuSig2(t *testing.T) {
+	msg := []byte("The roots of education are bitter, but the fruit is sweet.")
+
+	for _, n := range []int{1, 2, 5} {
+		n := n
+		t.Run("Sign", func(t *testing.T) {
+			privs, ak := testAggregateKey(t, n)
+			sessions := testSessions(t, ak, privs, msg)
+
+			var partials [][]byte
+			for i, s := range sessions {
+				partial, err := s.PartialSign()
+				if err != nil {
+					t.Fatalf("PartialSign(%d): %v", i, err)
+				}
+				if l := len(partial); l != PartialSignatureSize {
+					t.Fatalf("invalid partial signature length: %v", l)
+				}
+				partials = append(partials, partial)
+			}
+
+			for i, s := range sessions {
+				for j, partial := range partials {
+					if !s.VerifyPartialSignature(j, partial) {
+						t.Fatalf("session %d: VerifyPartialSignature(%d): false", i, j)
+					}
+				}
+			}
+
+			sig, err := sessions[n-1].Aggregate(partials)
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+
+			pub := ak.PublicKey()
+			if !ed25519.Verify(pub, msg, sig) {
+				t.Fatalf("Verify(aggregate): false")
+			}
+			for _, opts := range []*ed25519.Options{
+				{Verify: ed25519.VerifyOptionsFIPS_186_5},
+				{Verify: ed25519.VerifyOptionsZIP_215},
+			} {
+				if !ed25519.VerifyWithOptions(pub, msg, sig, opts) {
+					t.Fatalf("VerifyWithOptions(aggregate, %+v): false", opts.Verify)
+				}
+			}
+			if ed25519.Verify(pub, []byte("wrong message"), sig) {
+				t.Fatalf("Verify(aggregate, wrong message): true")
+			}
+		})
+	}
+
+	t.Run("BadPartialSignature", func(t *testing.T) {
+		privs, ak := testAggregateKey(t, 3)
+		sessions := testSessions(t, ak, privs, msg)
+
+		var partials [][]byte
+		for i, s := range sessions {
+			partial, err := s.PartialSign()
+			if err != nil {
+				t.Fatalf("PartialSign(%d): %v", i, err)
+			}
+			partials = append(partials, partial)
+		}
+
+		if sessions[0].VerifyPartialSignature(1, partials[2]) {
+			t.Fatalf("VerifyPartialSignature(wrong signer): true")
+		}
+
+		partials[1][0] ^= 0x01
+		if sessions[0].VerifyPartialSignature(1, partials[1]) {
+			t.Fatalf("VerifyPartialSignature(corrupted): true")
+		}
+		if _, err := sessions[0].Aggregate(partials); err == nil {
+			t.Fatalf("Aggregate(corrupted): expected error")
+		}
+	})
+
+	t.Run("State", func(t *testing.T) {
+		privs, ak := testAggregateKey(t, 2)
+
+		s, err := ak.NewSession(nil, privs[0], msg)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		if _, err = s.PartialSign(); err == nil {
+			t.Fatalf("PartialSign(no nonces): expected error")
+		}
+		if _, err = s.Aggregate(nil); err == nil {
+			t.Fatalf("Aggregate(no nonces): expected error")
+		}
+
+		s2, err := ak.NewSession(nil, privs[1], msg)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		if err = s.SetPublicNonces([][]byte{s2.PublicNonce(), s2.PublicNonce()}); err == nil {
+			t.Fatalf("SetPublicNonces(wrong own nonce): expected error")
+		}
+		if err = s.SetPublicNonces([][]byte{s.PublicNonce()}); err == nil {
+			t.Fatalf("SetPublicNonces(too few): expected error")
+		}
+
+		// Nonces with a torsion component are rejected.
+		badNonce := s2.PublicNonce()
+		var R curve.EdwardsPoint
+		if _, err = R.SetCompressedY((*curve.CompressedEdwardsY)(badNonce[:32])); err != nil {
+			t.Fatalf("SetCompressedY: %v", err)
+		}
+		var compressed curve.CompressedEdwardsY
+		compressed.SetEdwardsPoint(R.Add(&R, curve.EIGHT_TORSION[1]))
+		copy(badNonce[:32], compressed[:])
+		if err = s.SetPublicNonces([][]byte{s.PublicNonce(), badNonce}); err == nil {
+			t.Fatalf("SetPublicNonces(torsion): expected error")
+		}
+
+		nonces := [][]byte{s.PublicNonce(), s2.PublicNonce()}
+		if err = s.SetPublicNonces(nonces); err != nil {
+			t.Fatalf("SetPublicNonces: %v", err)
+		}
+		if err = s.SetPublicNonces(nonces); err == nil {
+			t.Fatalf("SetPublicNonces(again): expected error")
+		}
+		if _, err = s.PartialSign(); err != nil {
+			t.Fatalf("PartialSign: %v", err)
+		}
+		if _, err = s.PartialSign(); err == nil {
+			t.Fatalf("PartialSign(again): expected error")
+		}
+
+		// Sessions must not produce the same nonce twice.
+		s3, err := ak.NewSession(nil, privs[0], msg)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		if bytes.Equal(s3.PublicNonce(), s.PublicNonce()) {
+			t.Fatalf("NewSession: public nonce reused")
+		}
+	})
+
+	t.Run("KeyAggregation", func(t *testing.T) {
+		_, ak := testAggregateKey(t, 3)
+
+		pks := ak.PublicKeys()
+		if _, err := NewAggregateKey(nil); err == nil {
+			t.Fatalf("NewAggregateKey(nil): expected error")
+		}
+		if _, err := NewAggregateKey([]ed25519.PublicKey{pks[0], pks[1], pks[0]}); err == nil {
+			t.Fatalf("NewAggregateKey(duplicate): expected error")
+		}
+
+		// Keys with a torsion component are rejected.
+		var (
+			A          curve.EdwardsPoint
+			compressed curve.CompressedEdwardsY
+		)
+		if _, err := A.SetCompressedY((*curve.CompressedEdwardsY)(pks[2])); err != nil {
+			t.Fatalf("SetCompressedY: %v", err)
+		}
+		compressed.SetEdwardsPoint(A.Add(&A, curve.EIGHT_TORSION[1]))
+		if _, err := NewAggregateKey([]ed25519.PublicKey{pks[0], pks[1], compressed[:]}); err == nil {
+			t.Fatalf("NewAggregateKey(torsion): expected error")
+		}
+
+		// The order of the keys is significant.
+		ak2, err := NewAggregateKey([]ed25519.PublicKey{pks[2], pks[1], pks[0]})
+		if err != nil {
+			t.Fatalf("NewAggregateKey: %v", err)
+		}
+		if bytes.Equal(ak2.PublicKey(), ak.PublicKey()) {
+			t.Fatalf("NewAggregateKey: aggregate key independent of order")
+		}
+
+		_, other, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		if _, err = ak.NewSession(nil, other, msg); err == nil {
+			t.Fatalf("NewSession(non-signer): expected error")
+		}
+	})
+}
+
+func testAggregateKey(t *testing.T, n int) ([]ed25519.PrivateKey, *AggregateKey) {
+	var (
+		privs []ed25519.PrivateKey
+		pubs  []ed25519.PublicKey
+	)
+	for i := 0; i < n; i++ {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		privs = append(privs, priv)
+		pubs = append(pubs, pub)
+	}
+
+	ak, err := NewAggregateKey(pubs)
+	if err != nil {
+		t.Fatalf("NewAggregateKey: %v", err)
+	}
+
+	return privs, ak
+}
+
+func testSessions(t *testing.T, ak *AggregateKey, privs []ed25519.PrivateKey, msg []byte) []*Session {
+	var (
+		sessions []*Session
+		nonces   [][]byte
+	)
+	for i, priv := range privs {
+		s, err := ak.NewSession(nil, priv, msg)
+		if err != nil {
+			t.Fatalf("NewSession(%d): %v", i, err)
+		}
+		sessions = append(sessions, s)
+		nonces = append(nonces, s.PublicNonce())
+	}
+
+	for i, s := range sessions {
+		if err := s.SetPublicNonces(nonces); err != nil {
+			t.Fatalf("SetPublicNonces(%d): %v", i, err)
+		}
+	}
+
+	return sessions
+}
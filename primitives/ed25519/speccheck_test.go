@@ -116,6 +116,87 @@ var speccheckExpectedResultsZIP_215 = []bool{
 	true,  // 11: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A not reduced before hashing)
 }
 
+// The expected results for libsodium, ed25519-dalek (`verify` and
+// `verify_strict`), and BoringSSL are the corresponding rows of the
+// results table in the ed25519-speccheck README, at the same commit as
+// the test data:
+// https://github.com/novifinancial/ed25519-speccheck/blob/336651ba7f1c1ae90b7deac7d175290863a00b66/README.md
+//
+// ed25519-dalek's `verify` and BoringSSL behave identically to the Go
+// standard library on every case, which is why their presets are
+// aliases of VerifyOptionsStdLib.
+
+var speccheckExpectedResultsLibsodium = []bool{
+	false, // 0: small order A, small order R
+	false, // 1: small order A, mixed order R
+	false, // 2: mixed order A, small order R
+	true,  // 3: mixed order A, mixed order R
+	false, // 4: cofactored verify
+	false, // 5: cofactored verify computes 8(hA) instead of (8h mod L)A
+	false, // 6: non-canonical S (S > L)
+	false, // 7: non-canonical S (S >> L)
+	false, // 8: mixed order A, non-canonical small order R (accepted if R reduced before hashing)
+	false, // 9: mixed order A, non-canonical small order R (accepted if R not reduced before hashing)
+	false, // 10: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A reduced before hashing)
+	false, // 11: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A not reduced before hashing)
+}
+
+var speccheckExpectedResultsDalek = []bool{
+	true,  // 0: small order A, small order R
+	true,  // 1: small order A, mixed order R
+	true,  // 2: mixed order A, small order R
+	true,  // 3: mixed order A, mixed order R
+	false, // 4: cofactored verify
+	false, // 5: cofactored verify computes 8(hA) instead of (8h mod L)A
+	false, // 6: non-canonical S (S > L)
+	false, // 7: non-canonical S (S >> L)
+	false, // 8: mixed order A, non-canonical small order R (accepted if R reduced before hashing)
+	false, // 9: mixed order A, non-canonical small order R (accepted if R not reduced before hashing)
+	false, // 10: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A reduced before hashing)
+	true,  // 11: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A not reduced before hashing)
+}
+
+var speccheckExpectedResultsDalekStrict = []bool{
+	false, // 0: small order A, small order R
+	false, // 1: small order A, mixed order R
+	false, // 2: mixed order A, small order R
+	true,  // 3: mixed order A, mixed order R
+	false, // 4: cofactored verify
+	false, // 5: cofactored verify computes 8(hA) instead of (8h mod L)A
+	false, // 6: non-canonical S (S > L)
+	false, // 7: non-canonical S (S >> L)
+	false, // 8: mixed order A, non-canonical small order R (accepted if R reduced before hashing)
+	false, // 9: mixed order A, non-canonical small order R (accepted if R not reduced before hashing)
+	false, // 10: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A reduced before hashing)
+	false, // 11: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A not reduced before hashing)
+}
+
+var speccheckExpectedResultsBoringSSL = []bool{
+	true,  // 0: small order A, small order R
+	true,  // 1: small order A, mixed order R
+	true,  // 2: mixed order A, small order R
+	true,  // 3: mixed order A, mixed order R
+	false, // 4: cofactored verify
+	false, // 5: cofactored verify computes 8(hA) instead of (8h mod L)A
+	false, // 6: non-canonical S (S > L)
+	false, // 7: non-canonical S (S >> L)
+	false, // 8: mixed order A, non-canonical small order R (accepted if R reduced before hashing)
+	false, // 9: mixed order A, non-canonical small order R (accepted if R not reduced before hashing)
+	false, // 10: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A reduced before hashing)
+	true,  // 11: non-canonical small order A, mixed order R (accepted if cofactored or cofactor-less and A not reduced before hashing)
+}
+
+var speccheckExpectedResultsByImpl = map[Implementation][]bool{
+	ImplementationDefault:     speccheckExpectedResults,
+	ImplementationStdLib:      speccheckExpectedResultsStdLib,
+	ImplementationFIPS_186_5:  speccheckExpectedResultsFIPS_186_5,
+	ImplementationZIP_215:     speccheckExpectedResultsZIP_215,
+	ImplementationLibsodium:   speccheckExpectedResultsLibsodium,
+	ImplementationDalek:       speccheckExpectedResultsDalek,
+	ImplementationDalekStrict: speccheckExpectedResultsDalekStrict,
+	ImplementationBoringSSL:   speccheckExpectedResultsBoringSSL,
+}
+
 type speccheckTestVector struct {
 	Message   string `json:"message"`
 	PublicKey string `json:"pub_key"`
@@ -186,7 +267,7 @@ func (v *speccheckTestVector) Run(t *testing.T, impl verificationImpl, opts *Opt
 	return sigOk
 }
 
-func TestSpeccheck(t *testing.T) {
+func loadSpeccheckTestVectors(t *testing.T) []speccheckTestVector {
 	f, err := os.Open("testdata/speccheck_cases.json.gz")
 	if err != nil {
 		t.Fatal(err)
@@ -205,6 +286,12 @@ func TestSpeccheck(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	return testVectors
+}
+
+func TestSpeccheck(t *testing.T) {
+	testVectors := loadSpeccheckTestVectors(t)
+
 	doTestCases := func(n string, opts *Options, expectedResults []bool) {
 		for idx, tc := range testVectors {
 			expected := expectedResults[idx]
@@ -243,4 +330,49 @@ func TestSpeccheck(t *testing.T) {
 	t.Run("ZIP-215", func(t *testing.T) {
 		doTestCases("ZIP-215", &Options{Verify: VerifyOptionsZIP_215}, speccheckExpectedResultsZIP_215)
 	})
+	t.Run("Libsodium", func(t *testing.T) {
+		doTestCases("Libsodium", &Options{Verify: VerifyOptionsLibsodium}, speccheckExpectedResultsLibsodium)
+	})
+	t.Run("Dalek", func(t *testing.T) {
+		doTestCases("Dalek", &Options{Verify: VerifyOptionsDalek}, speccheckExpectedResultsDalek)
+	})
+	t.Run("DalekStrict", func(t *testing.T) {
+		doTestCases("DalekStrict", &Options{Verify: VerifyOptionsDalekStrict}, speccheckExpectedResultsDalekStrict)
+	})
+	t.Run("BoringSSL", func(t *testing.T) {
+		doTestCases("BoringSSL", &Options{Verify: VerifyOptionsBoringSSL}, speccheckExpectedResultsBoringSSL)
+	})
+}
+
+func TestClassifyAcceptance(t *testing.T) {
+	testVectors := loadSpeccheckTestVectors(t)
+
+	for idx, tc := range testVectors {
+		msg, pk, sig, err := tc.toComponents(t)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report := ClassifyAcceptance(pk, msg, sig, nil)
+		for _, impl := range Implementations() {
+			expected := speccheckExpectedResultsByImpl[impl][idx]
+			if accepted := report.Accepted(impl); accepted != expected {
+				t.Errorf("%d: %v: behavior mismatch: %v (expected %v)", idx, impl, accepted, expected)
+			}
+			if (report.Err(impl) == nil) != expected {
+				t.Errorf("%d: %v: error mismatch: %v", idx, impl, report.Err(impl))
+			}
+		}
+
+		// Only test cases 6, 7 (non-canonical S), and 8 (non-canonical
+		// R, reduced before hashing) are rejected by all, and only test
+		// case 3 is accepted by all.
+		if unanimous := report.Unanimous(); unanimous != (idx == 3 || idx == 6 || idx == 7 || idx == 8) {
+			t.Errorf("%d: unexpected unanimity: %v (%v)", idx, unanimous, report)
+		}
+	}
+
+	if Implementation(-1).VerifyOptions() != nil || numImplementations.VerifyOptions() != nil {
+		t.Errorf("unknown implementation returned VerifyOptions")
+	}
 }
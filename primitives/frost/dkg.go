@@ -0,0 +1,617 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/polynomial"
+)
+
+const identifierSize = 2
+
+type dkgState int
+
+const (
+	dkgStateInit dkgState = iota
+	dkgStateRound1
+	dkgStateRound2
+	dkgStateProcessedRound2
+	dkgStateDone
+)
+
+// DKGRound1Message is the message broadcast by each participant in round
+// one of the distributed key generation protocol.
+type DKGRound1Message struct {
+	// Identifier is the sender's participant identifier.
+	Identifier Identifier
+
+	// Commitment is the sender's serialized Feldman VSS commitment.
+	Commitment [][]byte
+
+	// ProofR and ProofMu are the sender's serialized proof of knowledge
+	// of the secret corresponding to the first commitment.
+	ProofR  []byte
+	ProofMu []byte
+}
+
+// MarshalBinary encodes m into binary form and returns the result.
+func (m *DKGRound1Message) MarshalBinary() ([]byte, error) {
+	if len(m.ProofR) != ElementSize || len(m.ProofMu) != ScalarSize {
+		return nil, fmt.Errorf("frost: malformed proof of knowledge")
+	}
+
+	b := make([]byte, identifierSize, identifierSize+ElementSize+ScalarSize+len(m.Commitment)*ElementSize)
+	binary.BigEndian.PutUint16(b, uint16(m.Identifier))
+	b = append(b, m.ProofR...)
+	b = append(b, m.ProofMu...)
+	for _, c := range m.Commitment {
+		if len(c) != ElementSize {
+			return nil, fmt.Errorf("frost: malformed commitment")
+		}
+		b = append(b, c...)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes a binary marshaled message into m.
+func (m *DKGRound1Message) UnmarshalBinary(data []byte) error {
+	const hdrSize = identifierSize + ElementSize + ScalarSize
+	if l := len(data); l < hdrSize+ElementSize || (l-hdrSize)%ElementSize != 0 {
+		return fmt.Errorf("frost: bad round 1 message length: %d", l)
+	}
+
+	m.Identifier = Identifier(binary.BigEndian.Uint16(data))
+	m.ProofR = append([]byte{}, data[identifierSize:identifierSize+ElementSize]...)
+	m.ProofMu = append([]byte{}, data[identifierSize+ElementSize:hdrSize]...)
+	m.Commitment = nil
+	for off := hdrSize; off < len(data); off += ElementSize {
+		m.Commitment = append(m.Commitment, append([]byte{}, data[off:off+ElementSize]...))
+	}
+
+	return nil
+}
+
+// DKGRound2Message is the message sent privately by each participant to
+// each other participant in round two of the distributed key generation
+// protocol.
+//
+// Warning: These messages contain secret shares, and MUST be sent over
+// a confidential and authenticated channel.
+type DKGRound2Message struct {
+	// Sender is the sender's participant identifier.
+	Sender Identifier
+
+	// Recipient is the recipient's participant identifier.
+	Recipient Identifier
+
+	// Share is the serialized secret share, f_sender(recipient).
+	Share []byte
+}
+
+// MarshalBinary encodes m into binary form and returns the result.
+func (m *DKGRound2Message) MarshalBinary() ([]byte, error) {
+	return marshalShareMessage(m.Sender, m.Recipient, m.Share)
+}
+
+// UnmarshalBinary decodes a binary marshaled message into m.
+func (m *DKGRound2Message) UnmarshalBinary(data []byte) error {
+	var err error
+	m.Sender, m.Recipient, m.Share, err = unmarshalShareMessage(data)
+	return err
+}
+
+// DKGComplaint is the message broadcast by a participant that received
+// an invalid (or no) round two share from a dealer.
+type DKGComplaint struct {
+	// Accuser is the complaining participant's identifier.
+	Accuser Identifier
+
+	// Accused is the identifier of the dealer being complained about.
+	Accused Identifier
+}
+
+// MarshalBinary encodes m into binary form and returns the result.
+func (m *DKGComplaint) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 2*identifierSize)
+	binary.BigEndian.PutUint16(b, uint16(m.Accuser))
+	binary.BigEndian.PutUint16(b[identifierSize:], uint16(m.Accused))
+	return b, nil
+}
+
+// UnmarshalBinary decodes a binary marshaled message into m.
+func (m *DKGComplaint) UnmarshalBinary(data []byte) error {
+	if l := len(data); l != 2*identifierSize {
+		return fmt.Errorf("frost: bad complaint length: %d", l)
+	}
+	m.Accuser = Identifier(binary.BigEndian.Uint16(data))
+	m.Accused = Identifier(binary.BigEndian.Uint16(data[identifierSize:]))
+	return nil
+}
+
+// DKGComplaintResponse is the message broadcast by an accused dealer,
+// publicly revealing the share sent to the accuser.
+type DKGComplaintResponse struct {
+	// Dealer is the accused dealer's participant identifier.
+	Dealer Identifier
+
+	// Accuser is the complaining participant's identifier.
+	Accuser Identifier
+
+	// Share is the serialized secret share, f_dealer(accuser).
+	Share []byte
+}
+
+// MarshalBinary encodes m into binary form and returns the result.
+func (m *DKGComplaintResponse) MarshalBinary() ([]byte, error) {
+	return marshalShareMessage(m.Dealer, m.Accuser, m.Share)
+}
+
+// UnmarshalBinary decodes a binary marshaled message into m.
+func (m *DKGComplaintResponse) UnmarshalBinary(data []byte) error {
+	var err error
+	m.Dealer, m.Accuser, m.Share, err = unmarshalShareMessage(data)
+	return err
+}
+
+// DKGBlameError is the error returned when a set of DKG messages can not
+// be processed due to a message that is malformed in a way that aborts
+// the protocol, and identifies the message and its sender.
+type DKGBlameError struct {
+	// Index is the index of the offending message in the slice passed
+	// to the method that returned the error.
+	Index int
+
+	// Sender is the sender's participant identifier, as claimed by the
+	// offending message.
+	Sender Identifier
+
+	// Reason describes what is wrong with the message.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *DKGBlameError) Error() string {
+	return fmt.Sprintf("frost: message %d from participant %d: %s", e.Index, e.Sender, e.Reason)
+}
+
+// DKGParticipant is a participant in the distributed key generation
+// protocol, based on Pedersen's DKG with the proofs of knowledge from
+// "FROST: Flexible Round-Optimized Schnorr Threshold Signatures" by
+// Komlo and Goldberg, and with complaint handling.
+//
+// The protocol proceeds as follows, with all broadcasts being over an
+// authenticated broadcast channel:
+//
+//  1. Each participant calls Round1, and broadcasts the result.
+//  2. Each participant calls Round2 with all of the round one messages,
+//     and sends each resulting message privately to its recipient.
+//  3. Each participant calls ProcessRound2 with the messages it received,
+//     and broadcasts the resulting complaints, if any.
+//  4. Each participant calls RespondToComplaints with all complaints, and
+//     broadcasts the resulting responses, if any.
+//  5. Each participant calls Finalize with all complaints and responses.
+//
+// Dealers that fail to provide a valid proof of knowledge, or fail to
+// resolve a complaint by revealing a valid share, are disqualified, and
+// do not contribute to the group key.
+type DKGParticipant struct {
+	cs              *Ciphersuite
+	rand            io.Reader
+	id              Identifier
+	maxParticipants int
+	minParticipants int
+	context         []byte
+
+	state        dkgState
+	coefficients []*scalar.Scalar
+	commitments  map[Identifier][][]byte
+	shares       map[Identifier]*scalar.Scalar
+}
+
+// NewDKGParticipant creates a new distributed key generation participant,
+// for a group of maxParticipants, any minParticipants of which can sign.
+// The context should uniquely identify the protocol instance.  The
+// randomness is sourced from rand, which will be crypto/rand.Reader if nil.
+func (cs *Ciphersuite) NewDKGParticipant(rand io.Reader, id Identifier, maxParticipants, minParticipants int, context []byte) (*DKGParticipant, error) {
+	if err := checkParticipants(maxParticipants, minParticipants); err != nil {
+		return nil, err
+	}
+	if id == 0 || int(id) > maxParticipants {
+		return nil, fmt.Errorf("frost: invalid identifier: %d", id)
+	}
+
+	return &DKGParticipant{
+		cs:              cs,
+		rand:            rand,
+		id:              id,
+		maxParticipants: maxParticipants,
+		minParticipants: minParticipants,
+		context:         append([]byte{}, context...),
+		commitments:     make(map[Identifier][][]byte),
+		shares:          make(map[Identifier]*scalar.Scalar),
+	}, nil
+}
+
+// Round1 samples the participant's secret polynomial, and returns the
+// round one message to be broadcast to all participants.
+func (p *DKGParticipant) Round1() (*DKGRound1Message, error) {
+	if p.state != dkgStateInit {
+		return nil, fmt.Errorf("frost: invalid DKG state for round 1")
+	}
+
+	p.coefficients = make([]*scalar.Scalar, 0, p.minParticipants)
+	commitment := make([][]byte, 0, p.minParticipants)
+	for i := 0; i < p.minParticipants; i++ {
+		a, err := randomNonZeroScalar(p.rand)
+		if err != nil {
+			return nil, err
+		}
+		p.coefficients = append(p.coefficients, a)
+		commitment = append(commitment, p.cs.newElement().mulBasepoint(a).bytes())
+	}
+
+	// Prove knowledge of a_0:
+	//   R = [k]B, c = H(id, context, [a_0]B, R), mu = k + a_0 * c
+	k, err := randomNonZeroScalar(p.rand)
+	if err != nil {
+		return nil, err
+	}
+	proofR := p.cs.newElement().mulBasepoint(k).bytes()
+	c := p.pokChallenge(p.id, commitment[0], proofR)
+	mu := k.Add(k, c.Mul(c, p.coefficients[0]))
+
+	p.commitments[p.id] = commitment
+	p.shares[p.id] = polynomial.Evaluate(p.id.toScalar(), p.coefficients)
+	p.state = dkgStateRound1
+
+	return &DKGRound1Message{
+		Identifier: p.id,
+		Commitment: commitment,
+		ProofR:     proofR,
+		ProofMu:    serializeScalar(mu),
+	}, nil
+}
+
+// Round2 verifies the round one messages from the other participants, and
+// returns the round two messages to be sent privately to each qualified
+// participant.  If any message has an invalid identifier, or there is more
+// than one message from the same participant, a *DKGBlameError
+// identifying the offending message is returned.
+func (p *DKGParticipant) Round2(msgs []*DKGRound1Message) ([]*DKGRound2Message, error) {
+	if p.state != dkgStateRound1 {
+		return nil, fmt.Errorf("frost: invalid DKG state for round 2")
+	}
+
+	// Check the identifiers before modifying any state, so that the
+	// caller can act on the blame and retry.
+	seen := make(map[Identifier]bool, len(msgs))
+	for i, m := range msgs {
+		if m.Identifier == 0 || int(m.Identifier) > p.maxParticipants {
+			return nil, &DKGBlameError{Index: i, Sender: m.Identifier, Reason: "invalid identifier"}
+		}
+		if seen[m.Identifier] {
+			return nil, &DKGBlameError{Index: i, Sender: m.Identifier, Reason: "duplicate round 1 message"}
+		}
+		seen[m.Identifier] = true
+	}
+
+	for _, m := range msgs {
+		if m.Identifier == p.id {
+			continue
+		}
+
+		// Participants with an invalid commitment or proof of knowledge
+		// are disqualified.
+		if p.verifyRound1Message(m) != nil {
+			continue
+		}
+		p.commitments[m.Identifier] = m.Commitment
+	}
+	if len(p.commitments) < p.minParticipants {
+		return nil, fmt.Errorf("frost: insufficient qualified participants: %d", len(p.commitments))
+	}
+
+	var out []*DKGRound2Message
+	for _, id := range p.qualified() {
+		if id == p.id {
+			continue
+		}
+		out = append(out, &DKGRound2Message{
+			Sender:    p.id,
+			Recipient: id,
+			Share:     serializeScalar(polynomial.Evaluate(id.toScalar(), p.coefficients)),
+		})
+	}
+	p.state = dkgStateRound2
+
+	return out, nil
+}
+
+func (p *DKGParticipant) verifyRound1Message(m *DKGRound1Message) error {
+	if len(m.Commitment) != p.minParticipants {
+		return fmt.Errorf("frost: bad commitment length: %d", len(m.Commitment))
+	}
+	for _, c := range m.Commitment {
+		if _, err := p.cs.deserializeElement(c); err != nil {
+			return err
+		}
+	}
+
+	// R = [mu]B - [c]([a_0]B)
+	phi0, _ := p.cs.deserializeElement(m.Commitment[0])
+	R, err := p.cs.deserializeElement(m.ProofR)
+	if err != nil {
+		return err
+	}
+	mu, err := deserializeScalar(m.ProofMu)
+	if err != nil {
+		return err
+	}
+	c := p.pokChallenge(m.Identifier, m.Commitment[0], m.ProofR)
+	if !p.cs.newElement().doubleScalarMulBasepointVartime(c.Neg(c), phi0, mu).equal(R) {
+		return fmt.Errorf("frost: invalid proof of knowledge")
+	}
+
+	return nil
+}
+
+// ProcessRound2 verifies the round two messages addressed to this
+// participant, and returns the complaints to be broadcast to all
+// participants, against each qualified dealer that did not send a valid
+// share.
+// If any message is addressed to a different participant, or there is
+// more than one message from the same participant, a *DKGBlameError
+// identifying the offending message is returned.
+func (p *DKGParticipant) ProcessRound2(msgs []*DKGRound2Message) ([]*DKGComplaint, error) {
+	if p.state != dkgStateRound2 {
+		return nil, fmt.Errorf("frost: invalid DKG state for processing round 2")
+	}
+
+	seen := make(map[Identifier]bool, len(msgs))
+	for i, m := range msgs {
+		if m.Recipient != p.id {
+			return nil, &DKGBlameError{Index: i, Sender: m.Sender, Reason: fmt.Sprintf("round 2 message for participant %d", m.Recipient)}
+		}
+		if seen[m.Sender] {
+			return nil, &DKGBlameError{Index: i, Sender: m.Sender, Reason: "duplicate round 2 message"}
+		}
+		seen[m.Sender] = true
+	}
+
+	for _, m := range msgs {
+		if _, ok := p.commitments[m.Sender]; !ok || m.Sender == p.id {
+			continue
+		}
+		if share, err := p.verifyShare(m.Sender, p.id, m.Share); err == nil {
+			p.shares[m.Sender] = share
+		}
+	}
+
+	var complaints []*DKGComplaint
+	for _, id := range p.qualified() {
+		if _, ok := p.shares[id]; !ok {
+			complaints = append(complaints, &DKGComplaint{
+				Accuser: p.id,
+				Accused: id,
+			})
+		}
+	}
+	p.state = dkgStateProcessedRound2
+
+	return complaints, nil
+}
+
+// RespondToComplaints returns the responses to the complaints against
+// this participant, to be broadcast to all participants.
+func (p *DKGParticipant) RespondToComplaints(complaints []*DKGComplaint) ([]*DKGComplaintResponse, error) {
+	if p.state != dkgStateProcessedRound2 {
+		return nil, fmt.Errorf("frost: invalid DKG state for responding to complaints")
+	}
+
+	var responses []*DKGComplaintResponse
+	for _, c := range complaints {
+		if c.Accused != p.id {
+			continue
+		}
+
+		// Revealing a share to a disqualified participant would publicly
+		// leak its share of the group key.
+		if _, ok := p.commitments[c.Accuser]; !ok || c.Accuser == p.id {
+			continue
+		}
+		responses = append(responses, &DKGComplaintResponse{
+			Dealer:  p.id,
+			Accuser: c.Accuser,
+			Share:   serializeScalar(polynomial.Evaluate(c.Accuser.toScalar(), p.coefficients)),
+		})
+	}
+
+	return responses, nil
+}
+
+// Finalize resolves the complaints, and returns this participant's key
+// share, and the group's public information.
+func (p *DKGParticipant) Finalize(complaints []*DKGComplaint, responses []*DKGComplaintResponse) (*KeyShare, *PublicKeyPackage, error) {
+	if p.state != dkgStateProcessedRound2 {
+		return nil, nil, fmt.Errorf("frost: invalid DKG state for finalization")
+	}
+
+	// Each complaint against a qualified dealer must be answered with a
+	// valid share, or the dealer is disqualified.
+	disqualified := make(map[Identifier]bool)
+	for _, c := range complaints {
+		if _, ok := p.commitments[c.Accuser]; !ok {
+			continue
+		}
+		if _, ok := p.commitments[c.Accused]; !ok {
+			continue
+		}
+
+		var resolved bool
+		for _, r := range responses {
+			if r.Dealer != c.Accused || r.Accuser != c.Accuser {
+				continue
+			}
+			share, err := p.verifyShare(r.Dealer, r.Accuser, r.Share)
+			if err != nil {
+				continue
+			}
+			if r.Accuser == p.id {
+				p.shares[r.Dealer] = share
+			}
+			resolved = true
+			break
+		}
+		if !resolved {
+			disqualified[c.Accused] = true
+		}
+	}
+	if disqualified[p.id] {
+		return nil, nil, fmt.Errorf("frost: participant disqualified")
+	}
+	for id := range disqualified {
+		delete(p.commitments, id)
+		delete(p.shares, id)
+	}
+	qualified := p.qualified()
+	if len(qualified) < p.minParticipants {
+		return nil, nil, fmt.Errorf("frost: insufficient qualified participants: %d", len(qualified))
+	}
+
+	// s_i = sum(f_l(i)), and the group commitment is the element-wise
+	// sum of the qualified dealers' commitments.
+	secretShare := scalar.New()
+	groupCommitment := make([]element, p.minParticipants)
+	for _, id := range qualified {
+		share, ok := p.shares[id]
+		if !ok {
+			return nil, nil, fmt.Errorf("frost: missing share from participant %d", id)
+		}
+		secretShare.Add(secretShare, share)
+
+		for k, b := range p.commitments[id] {
+			phi, err := p.cs.deserializeElement(b)
+			if err != nil {
+				return nil, nil, err
+			}
+			if groupCommitment[k] == nil {
+				groupCommitment[k] = phi
+			} else {
+				groupCommitment[k].add(groupCommitment[k], phi)
+			}
+		}
+	}
+
+	vssCommitment := make([][]byte, 0, len(groupCommitment))
+	for _, phi := range groupCommitment {
+		vssCommitment = append(vssCommitment, phi.bytes())
+	}
+	pkp, err := p.cs.DeriveGroupInfo(p.maxParticipants, vssCommitment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyShare := &KeyShare{
+		Identifier:     p.id,
+		SecretShare:    serializeScalar(secretShare),
+		GroupPublicKey: pkp.GroupPublicKey,
+	}
+	if err = p.cs.VSSVerify(keyShare, vssCommitment); err != nil {
+		return nil, nil, err
+	}
+
+	for _, c := range p.coefficients {
+		c.Zero()
+	}
+	p.state = dkgStateDone
+
+	return keyShare, pkp, nil
+}
+
+func (p *DKGParticipant) verifyShare(dealer, recipient Identifier, b []byte) (*scalar.Scalar, error) {
+	share, err := deserializeScalar(b)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := p.cs.evaluateVSSCommitment(recipient, p.commitments[dealer])
+	if err != nil {
+		return nil, err
+	}
+	if !p.cs.newElement().mulBasepoint(share).equal(expected) {
+		return nil, fmt.Errorf("frost: share does not match commitment")
+	}
+
+	return share, nil
+}
+
+func (p *DKGParticipant) pokChallenge(id Identifier, phi0, R []byte) *scalar.Scalar {
+	var l [8]byte
+	binary.LittleEndian.PutUint64(l[:], uint64(len(p.context)))
+	return p.cs.hashToScalar("dkg", l[:], p.context, id.bytes(), phi0, R)
+}
+
+func (p *DKGParticipant) qualified() []Identifier {
+	ids := make([]Identifier, 0, len(p.commitments))
+	for id := range p.commitments {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+func marshalShareMessage(from, to Identifier, share []byte) ([]byte, error) {
+	if len(share) != ScalarSize {
+		return nil, fmt.Errorf("frost: malformed share")
+	}
+
+	b := make([]byte, 2*identifierSize, 2*identifierSize+ScalarSize)
+	binary.BigEndian.PutUint16(b, uint16(from))
+	binary.BigEndian.PutUint16(b[identifierSize:], uint16(to))
+	return append(b, share...), nil
+}
+
+func unmarshalShareMessage(data []byte) (Identifier, Identifier, []byte, error) {
+	if l := len(data); l != 2*identifierSize+ScalarSize {
+		return 0, 0, nil, fmt.Errorf("frost: bad share message length: %d", l)
+	}
+
+	from := Identifier(binary.BigEndian.Uint16(data))
+	to := Identifier(binary.BigEndian.Uint16(data[identifierSize:]))
+	return from, to, append([]byte{}, data[2*identifierSize:]...), nil
+}
@@ -0,0 +1,365 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+type dkgTestHooks struct {
+	tamperRound1    func(m *DKGRound1Message)
+	tamperRound2    func(m *DKGRound2Message)
+	dropResponses   func(r *DKGComplaintResponse) bool
+	expectComplaint bool
+	disqualified    Identifier
+}
+
+func TestDKG(t *testing.T) {
+	for _, cs := range []*Ciphersuite{
+		Ed25519SHA512,
+		Ristretto255SHA512,
+	} {
+		t.Run(cs.String(), func(t *testing.T) {
+			testDKG(t, cs)
+		})
+	}
+}
+
+func testDKG(t *testing.T, cs *Ciphersuite) {
+	const (
+		maxParticipants = 5
+		minParticipants = 3
+	)
+
+	t.Run("Honest", func(t *testing.T) {
+		keyShares, pkp := testDKGRun(t, cs, maxParticipants, minParticipants, &dkgTestHooks{})
+		if len(keyShares) != maxParticipants {
+			t.Fatalf("unexpected number of key shares: %d", len(keyShares))
+		}
+
+		// The resulting keys must be usable for signing.
+		msg := []byte("test message")
+		sig := testFROSTSign(t, cs, pkp, keyShares[1:4], msg)
+		switch cs {
+		case Ed25519SHA512:
+			pk, err := cs.Ed25519PublicKey(pkp)
+			if err != nil {
+				t.Fatalf("Ed25519PublicKey: %v", err)
+			}
+			if !ed25519.Verify(pk, msg, sig) {
+				t.Fatalf("ed25519.Verify: failed")
+			}
+			if _, err = cs.SR25519PublicKey(pkp); err == nil {
+				t.Fatalf("SR25519PublicKey: accepted Ed25519 key")
+			}
+		case Ristretto255SHA512:
+			pk, err := cs.SR25519PublicKey(pkp)
+			if err != nil {
+				t.Fatalf("SR25519PublicKey: %v", err)
+			}
+			if b, _ := pk.MarshalBinary(); !bytes.Equal(b, pkp.GroupPublicKey) {
+				t.Fatalf("SR25519PublicKey: mismatch")
+			}
+		}
+	})
+	t.Run("ResolvedComplaint", func(t *testing.T) {
+		// Dealer 2 sends a bad share to participant 4, but reveals the
+		// correct share when accused.
+		keyShares, _ := testDKGRun(t, cs, maxParticipants, minParticipants, &dkgTestHooks{
+			tamperRound2: func(m *DKGRound2Message) {
+				if m.Sender == 2 && m.Recipient == 4 {
+					m.Share[0] ^= 1
+				}
+			},
+			expectComplaint: true,
+		})
+		if len(keyShares) != maxParticipants {
+			t.Fatalf("unexpected number of key shares: %d", len(keyShares))
+		}
+	})
+	t.Run("Disqualified", func(t *testing.T) {
+		// Dealer 2 sends a bad share to participant 4, and does not
+		// respond to the complaint.
+		keyShares, pkp := testDKGRun(t, cs, maxParticipants, minParticipants, &dkgTestHooks{
+			tamperRound2: func(m *DKGRound2Message) {
+				if m.Sender == 2 && m.Recipient == 4 {
+					m.Share[0] ^= 1
+				}
+			},
+			dropResponses: func(r *DKGComplaintResponse) bool {
+				return r.Dealer == 2
+			},
+			expectComplaint: true,
+		})
+		if len(keyShares) != maxParticipants-1 {
+			t.Fatalf("unexpected number of key shares: %d", len(keyShares))
+		}
+		_ = testFROSTSign(t, cs, pkp, keyShares[:3], []byte("test message"))
+	})
+	t.Run("InvalidProof", func(t *testing.T) {
+		// Dealer 5 is disqualified by everyone else in round 2, and will
+		// complain about not receiving shares, which must go unanswered.
+		keyShares, _ := testDKGRun(t, cs, maxParticipants, minParticipants, &dkgTestHooks{
+			tamperRound1: func(m *DKGRound1Message) {
+				if m.Identifier == 5 {
+					m.ProofMu[0] ^= 1
+				}
+			},
+			expectComplaint: true,
+			disqualified:    5,
+		})
+		if len(keyShares) != maxParticipants-1 {
+			t.Fatalf("unexpected number of key shares: %d", len(keyShares))
+		}
+	})
+	t.Run("Blame", func(t *testing.T) {
+		var (
+			participants []*DKGParticipant
+			round1       []*DKGRound1Message
+		)
+		for i := 1; i <= maxParticipants; i++ {
+			p, err := cs.NewDKGParticipant(nil, Identifier(i), maxParticipants, minParticipants, nil)
+			if err != nil {
+				t.Fatalf("NewDKGParticipant(%d): %v", i, err)
+			}
+			m, err := p.Round1()
+			if err != nil {
+				t.Fatalf("Round1: %v", err)
+			}
+			participants = append(participants, p)
+			round1 = append(round1, m)
+		}
+		p := participants[0]
+
+		checkBlame := func(err error, index int, sender Identifier) {
+			t.Helper()
+			var blameErr *DKGBlameError
+			if !errors.As(err, &blameErr) {
+				t.Fatalf("expected DKGBlameError, got %v", err)
+			}
+			if blameErr.Index != index || blameErr.Sender != sender {
+				t.Fatalf("blamed message %d from %d, expected %d from %d", blameErr.Index, blameErr.Sender, index, sender)
+			}
+		}
+
+		outOfRange := *round1[2]
+		outOfRange.Identifier = maxParticipants + 1
+		_, err := p.Round2([]*DKGRound1Message{round1[0], round1[1], &outOfRange})
+		checkBlame(err, 2, maxParticipants+1)
+
+		_, err = p.Round2(append(append([]*DKGRound1Message{}, round1...), round1[3]))
+		checkBlame(err, maxParticipants, round1[3].Identifier)
+
+		// The participant must be usable after a blamed message set.
+		if _, err = p.Round2(round1); err != nil {
+			t.Fatalf("Round2: %v", err)
+		}
+
+		var round2 []*DKGRound2Message
+		for _, q := range participants[1:] {
+			msgs, err := q.Round2(round1)
+			if err != nil {
+				t.Fatalf("Round2: %v", err)
+			}
+			round2 = append(round2, msgs[0])
+		}
+		_, err = p.ProcessRound2(append(append([]*DKGRound2Message{}, round2...), round2[1]))
+		checkBlame(err, len(round2), round2[1].Sender)
+
+		if complaints, err := p.ProcessRound2(round2); err != nil || len(complaints) != 0 {
+			t.Fatalf("ProcessRound2: %v %+v", err, complaints)
+		}
+	})
+	t.Run("State", func(t *testing.T) {
+		p, err := cs.NewDKGParticipant(nil, 1, maxParticipants, minParticipants, nil)
+		if err != nil {
+			t.Fatalf("NewDKGParticipant: %v", err)
+		}
+		if _, err = p.Round2(nil); err == nil {
+			t.Fatalf("Round2: accepted before Round1")
+		}
+		if _, err = p.Round1(); err != nil {
+			t.Fatalf("Round1: %v", err)
+		}
+		if _, err = p.Round1(); err == nil {
+			t.Fatalf("Round1: accepted twice")
+		}
+		if _, err = p.Round2(nil); err == nil {
+			t.Fatalf("Round2: accepted insufficient participants")
+		}
+		if _, err = cs.NewDKGParticipant(nil, maxParticipants+1, maxParticipants, minParticipants, nil); err == nil {
+			t.Fatalf("NewDKGParticipant: accepted out of range identifier")
+		}
+	})
+}
+
+// testDKGRun runs the DKG, passing all messages through serialization,
+// and returns the key shares of the non-disqualified participants.
+func testDKGRun(t *testing.T, cs *Ciphersuite, n, threshold int, hooks *dkgTestHooks) ([]*KeyShare, *PublicKeyPackage) {
+	context := []byte("test DKG instance")
+	participants := make([]*DKGParticipant, 0, n)
+	for i := 1; i <= n; i++ {
+		p, err := cs.NewDKGParticipant(nil, Identifier(i), n, threshold, context)
+		if err != nil {
+			t.Fatalf("NewDKGParticipant(%d): %v", i, err)
+		}
+		participants = append(participants, p)
+	}
+
+	var round1 []*DKGRound1Message
+	for _, p := range participants {
+		m, err := p.Round1()
+		if err != nil {
+			t.Fatalf("Round1: %v", err)
+		}
+		if hooks.tamperRound1 != nil {
+			hooks.tamperRound1(m)
+		}
+		var m2 DKGRound1Message
+		testRoundTrip(t, m, &m2)
+		round1 = append(round1, &m2)
+	}
+
+	round2 := make(map[Identifier][]*DKGRound2Message)
+	for _, p := range participants {
+		msgs, err := p.Round2(round1)
+		if err != nil {
+			t.Fatalf("Round2: %v", err)
+		}
+		for _, m := range msgs {
+			if hooks.tamperRound2 != nil {
+				hooks.tamperRound2(m)
+			}
+			var m2 DKGRound2Message
+			testRoundTrip(t, m, &m2)
+			round2[m2.Recipient] = append(round2[m2.Recipient], &m2)
+		}
+	}
+
+	var complaints []*DKGComplaint
+	for _, p := range participants {
+		cs, err := p.ProcessRound2(round2[p.id])
+		if err != nil {
+			t.Fatalf("ProcessRound2: %v", err)
+		}
+		for _, c := range cs {
+			var c2 DKGComplaint
+			testRoundTrip(t, c, &c2)
+			complaints = append(complaints, &c2)
+		}
+	}
+	if hooks.expectComplaint != (len(complaints) > 0) {
+		t.Fatalf("unexpected complaints: %+v", complaints)
+	}
+
+	var responses []*DKGComplaintResponse
+	for _, p := range participants {
+		rs, err := p.RespondToComplaints(complaints)
+		if err != nil {
+			t.Fatalf("RespondToComplaints: %v", err)
+		}
+		for _, r := range rs {
+			if r.Accuser == hooks.disqualified {
+				t.Fatalf("RespondToComplaints: revealed share to disqualified participant")
+			}
+			if hooks.dropResponses != nil && hooks.dropResponses(r) {
+				continue
+			}
+			var r2 DKGComplaintResponse
+			testRoundTrip(t, r, &r2)
+			responses = append(responses, &r2)
+		}
+	}
+
+	var (
+		keyShares []*KeyShare
+		pkp       *PublicKeyPackage
+	)
+	for _, p := range participants {
+		keyShare, pkpI, err := p.Finalize(complaints, responses)
+		if err != nil {
+			continue
+		}
+		if pkp != nil && !bytes.Equal(pkp.GroupPublicKey, pkpI.GroupPublicKey) {
+			t.Fatalf("Finalize: group public key mismatch")
+		}
+		pkp = pkpI
+		keyShares = append(keyShares, keyShare)
+	}
+	if pkp == nil {
+		t.Fatalf("Finalize: all participants failed")
+	}
+
+	return keyShares, pkp
+}
+
+func testRoundTrip(t *testing.T, m encoding.BinaryMarshaler, out encoding.BinaryUnmarshaler) {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if err = out.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+}
+
+func testFROSTSign(t *testing.T, cs *Ciphersuite, pkp *PublicKeyPackage, signers []*KeyShare, msg []byte) []byte {
+	var (
+		nonces      []*SigningNonces
+		commitments []*SigningCommitment
+		sigShares   []*SignatureShare
+	)
+	for _, share := range signers {
+		n, err := cs.Commit(nil, share)
+		if err != nil {
+			t.Fatalf("Commit(%d): %v", share.Identifier, err)
+		}
+		nonces = append(nonces, n)
+		commitments = append(commitments, n.Commitment())
+	}
+	for i, share := range signers {
+		sigShare, err := cs.Sign(share, nonces[i], msg, commitments)
+		if err != nil {
+			t.Fatalf("Sign(%d): %v", share.Identifier, err)
+		}
+		sigShares = append(sigShares, sigShare)
+	}
+
+	sig, err := cs.Aggregate(pkp, commitments, msg, sigShares)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	return sig
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package frost implements the FROST (Flexible Round-Optimized Schnorr
+// Threshold) signature scheme as specified in RFC 9591, for the
+// FROST(Ed25519, SHA-512) and FROST(ristretto255, SHA-512) ciphersuites.
+//
+// Signatures produced with the Ed25519 ciphersuite are standard Ed25519
+// signatures, verifiable with ed25519.Verify.  Signatures produced with
+// the ristretto255 ciphersuite are Schnorr signatures (R || z) over the
+// ristretto255 group, verifiable with Ciphersuite.Verify.
+//
+// Keys may be generated by a trusted dealer, or with the distributed key
+// generation protocol implemented by DKGParticipant.
+//
+// Group elements and scalars are exchanged as their canonical byte
+// encodings, and all operations are methods on a Ciphersuite.
+package frost
+
+import (
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+)
+
+const (
+	// ElementSize is the size, in bytes, of a serialized group element.
+	ElementSize = elementSize
+
+	// ScalarSize is the size, in bytes, of a serialized scalar.
+	ScalarSize = scalar.ScalarSize
+
+	// SignatureSize is the size, in bytes, of a signature.
+	SignatureSize = ElementSize + ScalarSize
+)
+
+var (
+	// Ed25519SHA512 is the FROST(Ed25519, SHA-512) ciphersuite.
+	Ed25519SHA512 = &Ciphersuite{
+		name:          "FROST(Ed25519, SHA-512)",
+		contextString: "FROST-ED25519-SHA512-v1",
+		newElement: func() element {
+			return new(edwardsElement)
+		},
+		isEd25519: true,
+	}
+
+	// Ristretto255SHA512 is the FROST(ristretto255, SHA-512) ciphersuite.
+	Ristretto255SHA512 = &Ciphersuite{
+		name:          "FROST(ristretto255, SHA-512)",
+		contextString: "FROST-RISTRETTO255-SHA512-v1",
+		newElement: func() element {
+			return new(ristrettoElement)
+		},
+	}
+)
+
+// Identifier is a participant identifier.  Identifiers are non-zero.
+type Identifier uint16
+
+func (id Identifier) toScalar() *scalar.Scalar {
+	return scalar.NewFromUint64(uint64(id))
+}
+
+func (id Identifier) bytes() []byte {
+	b := make([]byte, ScalarSize)
+	if err := id.toScalar().ToBytes(b); err != nil {
+		panic("frost: failed to serialize identifier: " + err.Error())
+	}
+	return b
+}
+
+// Ciphersuite is a FROST ciphersuite.
+type Ciphersuite struct {
+	name          string
+	contextString string
+	newElement    func() element
+	isEd25519     bool
+}
+
+// String returns the name of the ciphersuite.
+func (cs *Ciphersuite) String() string {
+	return cs.name
+}
+
+func (cs *Ciphersuite) deserializeElement(b []byte) (element, error) {
+	e := cs.newElement()
+	if err := e.setBytes(b); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (cs *Ciphersuite) hash(tag string, inputs ...[]byte) []byte {
+	h := sha512.New()
+	_, _ = h.Write([]byte(cs.contextString))
+	_, _ = h.Write([]byte(tag))
+	for _, input := range inputs {
+		_, _ = h.Write(input)
+	}
+	return h.Sum(nil)
+}
+
+func (cs *Ciphersuite) hashToScalar(tag string, inputs ...[]byte) *scalar.Scalar {
+	s, err := scalar.NewFromBytesModOrderWide(cs.hash(tag, inputs...))
+	if err != nil {
+		panic("frost: failed to deserialize hash scalar: " + err.Error())
+	}
+	return s
+}
+
+// h1 is used to compute binding factors.
+func (cs *Ciphersuite) h1(m []byte) *scalar.Scalar {
+	return cs.hashToScalar("rho", m)
+}
+
+// h2 is used to compute the challenge.  For compatibility with Ed25519,
+// the Ed25519 ciphersuite does not use a context string or tag.
+func (cs *Ciphersuite) h2(m ...[]byte) *scalar.Scalar {
+	if !cs.isEd25519 {
+		return cs.hashToScalar("chal", m...)
+	}
+
+	h := sha512.New()
+	for _, input := range m {
+		_, _ = h.Write(input)
+	}
+	s, err := scalar.NewFromBytesModOrderWide(h.Sum(nil))
+	if err != nil {
+		panic("frost: failed to deserialize challenge: " + err.Error())
+	}
+	return s
+}
+
+// h3 is used to generate nonces.
+func (cs *Ciphersuite) h3(m ...[]byte) *scalar.Scalar {
+	return cs.hashToScalar("nonce", m...)
+}
+
+// h4 is used to hash the message.
+func (cs *Ciphersuite) h4(m []byte) []byte {
+	return cs.hash("msg", m)
+}
+
+// h5 is used to hash the encoded commitment list.
+func (cs *Ciphersuite) h5(m []byte) []byte {
+	return cs.hash("com", m)
+}
+
+func deserializeScalar(b []byte) (*scalar.Scalar, error) {
+	if l := len(b); l != ScalarSize {
+		return nil, fmt.Errorf("frost: bad scalar length: %d", l)
+	}
+	s, err := scalar.NewFromCanonicalBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("frost: failed to deserialize scalar: %w", err)
+	}
+	return s, nil
+}
+
+func serializeScalar(s *scalar.Scalar) []byte {
+	b := make([]byte, ScalarSize)
+	if err := s.ToBytes(b); err != nil {
+		panic("frost: failed to serialize scalar: " + err.Error())
+	}
+	return b
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+func TestFROST(t *testing.T) {
+	for _, cs := range []*Ciphersuite{
+		Ed25519SHA512,
+		Ristretto255SHA512,
+	} {
+		t.Run(cs.String(), func(t *testing.T) {
+			testFROST(t, cs)
+		})
+	}
+}
+
+func testFROST(t *testing.T, cs *Ciphersuite) {
+	const (
+		maxParticipants = 5
+		minParticipants = 3
+	)
+	shares, pkp, vssCommitment, err := cs.TrustedDealerKeygen(nil, nil, maxParticipants, minParticipants)
+	if err != nil {
+		t.Fatalf("TrustedDealerKeygen: %v", err)
+	}
+	for _, share := range shares {
+		if err = cs.VSSVerify(share, vssCommitment); err != nil {
+			t.Fatalf("VSSVerify(%d): %v", share.Identifier, err)
+		}
+	}
+
+	msg := []byte("test message")
+	signers := []*KeyShare{shares[4], shares[0], shares[2]}
+
+	commit := func() ([]*SigningNonces, []*SigningCommitment) {
+		var (
+			nonces      []*SigningNonces
+			commitments []*SigningCommitment
+		)
+		for _, share := range signers {
+			n, err := cs.Commit(nil, share)
+			if err != nil {
+				t.Fatalf("Commit(%d): %v", share.Identifier, err)
+			}
+			nonces = append(nonces, n)
+			commitments = append(commitments, n.Commitment())
+		}
+		return nonces, commitments
+	}
+
+	t.Run("Sign", func(t *testing.T) {
+		nonces, commitments := commit()
+
+		var sigShares []*SignatureShare
+		for i, share := range signers {
+			sigShare, err := cs.Sign(share, nonces[i], msg, commitments)
+			if err != nil {
+				t.Fatalf("Sign(%d): %v", share.Identifier, err)
+			}
+			sigShares = append(sigShares, sigShare)
+		}
+
+		sig, err := cs.Aggregate(pkp, commitments, msg, sigShares)
+		if err != nil {
+			t.Fatalf("Aggregate: %v", err)
+		}
+		if !cs.Verify(pkp.GroupPublicKey, msg, sig) {
+			t.Fatalf("Verify: failed")
+		}
+		if cs.Verify(pkp.GroupPublicKey, []byte("other message"), sig) {
+			t.Fatalf("Verify: accepted wrong message")
+		}
+		if cs == Ed25519SHA512 {
+			for _, vOpts := range []*ed25519.VerifyOptions{
+				ed25519.VerifyOptionsDefault,
+				ed25519.VerifyOptionsFIPS_186_5,
+			} {
+				if !ed25519.VerifyWithOptions(pkp.GroupPublicKey, msg, sig, &ed25519.Options{Verify: vOpts}) {
+					t.Fatalf("ed25519.VerifyWithOptions(%+v): failed", vOpts)
+				}
+			}
+		}
+
+		if _, err = cs.Sign(signers[0], nonces[0], msg, commitments); err == nil {
+			t.Fatalf("Sign: allowed nonce reuse")
+		}
+	})
+	t.Run("InvalidShare", func(t *testing.T) {
+		nonces, commitments := commit()
+
+		var sigShares []*SignatureShare
+		for i, share := range signers {
+			sigShare, err := cs.Sign(share, nonces[i], msg, commitments)
+			if err != nil {
+				t.Fatalf("Sign(%d): %v", share.Identifier, err)
+			}
+			sigShares = append(sigShares, sigShare)
+		}
+		sigShares[1].Share[0] ^= 1
+
+		if err := cs.VerifySignatureShare(pkp, commitments, msg, sigShares[1]); err == nil {
+			t.Fatalf("VerifySignatureShare: accepted invalid share")
+		}
+		_, err := cs.Aggregate(pkp, commitments, msg, sigShares)
+		if err == nil {
+			t.Fatalf("Aggregate: accepted invalid share")
+		}
+		if !strings.Contains(err.Error(), "[1]") {
+			t.Fatalf("Aggregate: failed to identify invalid share: %v", err)
+		}
+	})
+	t.Run("InvalidCommitments", func(t *testing.T) {
+		nonces, commitments := commit()
+
+		if _, err := cs.Sign(signers[0], nonces[0], msg, commitments[1:]); err == nil {
+			t.Fatalf("Sign: accepted commitment list without own commitment")
+		}
+		dup := append([]*SigningCommitment{commitments[0]}, commitments...)
+		if _, err := cs.Sign(signers[0], nonces[0], msg, dup); err == nil {
+			t.Fatalf("Sign: accepted duplicate commitment")
+		}
+		swapped := append([]*SigningCommitment{}, commitments...)
+		swapped[0] = &SigningCommitment{
+			Identifier: commitments[0].Identifier,
+			Hiding:     commitments[0].Binding,
+			Binding:    commitments[0].Hiding,
+		}
+		if _, err := cs.Sign(signers[0], nonces[0], msg, swapped); err == nil {
+			t.Fatalf("Sign: accepted mismatched commitment")
+		}
+	})
+	t.Run("Keygen", func(t *testing.T) {
+		if _, _, _, err := cs.TrustedDealerKeygen(nil, nil, 3, 1); err == nil {
+			t.Fatalf("TrustedDealerKeygen: accepted threshold of 1")
+		}
+		if _, _, _, err := cs.TrustedDealerKeygen(nil, nil, 2, 3); err == nil {
+			t.Fatalf("TrustedDealerKeygen: accepted threshold > participants")
+		}
+
+		badShare := *shares[0]
+		badShare.SecretShare = shares[1].SecretShare
+		if err := cs.VSSVerify(&badShare, vssCommitment); err == nil {
+			t.Fatalf("VSSVerify: accepted invalid share")
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+)
+
+const elementSize = 32
+
+// element is a prime-order group element.
+type element interface {
+	add(a, b element) element
+	mul(p element, s *scalar.Scalar) element
+	mulBasepoint(s *scalar.Scalar) element
+	multiscalarMulVartime(scalars []*scalar.Scalar, points []element) element
+	doubleScalarMulBasepointVartime(a *scalar.Scalar, A element, b *scalar.Scalar) element
+	equal(other element) bool
+	isIdentity() bool
+	setBytes(b []byte) error
+	bytes() []byte
+}
+
+type edwardsElement struct {
+	p curve.EdwardsPoint
+}
+
+func (e *edwardsElement) add(a, b element) element {
+	e.p.Add(&a.(*edwardsElement).p, &b.(*edwardsElement).p)
+	return e
+}
+
+func (e *edwardsElement) mul(p element, s *scalar.Scalar) element {
+	e.p.Mul(&p.(*edwardsElement).p, s)
+	return e
+}
+
+func (e *edwardsElement) mulBasepoint(s *scalar.Scalar) element {
+	e.p.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, s)
+	return e
+}
+
+func (e *edwardsElement) multiscalarMulVartime(scalars []*scalar.Scalar, points []element) element {
+	ps := make([]*curve.EdwardsPoint, 0, len(points))
+	for _, p := range points {
+		ps = append(ps, &p.(*edwardsElement).p)
+	}
+	e.p.MultiscalarMulVartime(scalars, ps)
+	return e
+}
+
+func (e *edwardsElement) doubleScalarMulBasepointVartime(a *scalar.Scalar, A element, b *scalar.Scalar) element {
+	e.p.DoubleScalarMulBasepointVartime(a, &A.(*edwardsElement).p, b)
+	return e
+}
+
+func (e *edwardsElement) equal(other element) bool {
+	return e.p.Equal(&other.(*edwardsElement).p) == 1
+}
+
+func (e *edwardsElement) isIdentity() bool {
+	return e.p.IsIdentity()
+}
+
+func (e *edwardsElement) setBytes(b []byte) error {
+	if l := len(b); l != elementSize {
+		return fmt.Errorf("frost: bad element length: %d", l)
+	}
+
+	var compressed curve.CompressedEdwardsY
+	if _, err := compressed.SetBytes(b); err != nil {
+		return fmt.Errorf("frost: failed to deserialize element: %w", err)
+	}
+	if !compressed.IsCanonicalVartime() {
+		return fmt.Errorf("frost: non-canonical element")
+	}
+	if _, err := e.p.SetCompressedY(&compressed); err != nil {
+		return fmt.Errorf("frost: failed to decompress element: %w", err)
+	}
+	if e.p.IsIdentity() {
+		return fmt.Errorf("frost: identity element")
+	}
+	if !e.p.IsTorsionFree() {
+		return fmt.Errorf("frost: element not in prime-order subgroup")
+	}
+
+	return nil
+}
+
+func (e *edwardsElement) bytes() []byte {
+	var compressed curve.CompressedEdwardsY
+	compressed.SetEdwardsPoint(&e.p)
+	return compressed[:]
+}
+
+type ristrettoElement struct {
+	p curve.RistrettoPoint
+}
+
+func (e *ristrettoElement) add(a, b element) element {
+	e.p.Add(&a.(*ristrettoElement).p, &b.(*ristrettoElement).p)
+	return e
+}
+
+func (e *ristrettoElement) mul(p element, s *scalar.Scalar) element {
+	e.p.Mul(&p.(*ristrettoElement).p, s)
+	return e
+}
+
+func (e *ristrettoElement) mulBasepoint(s *scalar.Scalar) element {
+	e.p.MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, s)
+	return e
+}
+
+func (e *ristrettoElement) multiscalarMulVartime(scalars []*scalar.Scalar, points []element) element {
+	ps := make([]*curve.RistrettoPoint, 0, len(points))
+	for _, p := range points {
+		ps = append(ps, &p.(*ristrettoElement).p)
+	}
+	e.p.MultiscalarMulVartime(scalars, ps)
+	return e
+}
+
+func (e *ristrettoElement) doubleScalarMulBasepointVartime(a *scalar.Scalar, A element, b *scalar.Scalar) element {
+	e.p.DoubleScalarMulBasepointVartime(a, &A.(*ristrettoElement).p, b)
+	return e
+}
+
+func (e *ristrettoElement) equal(other element) bool {
+	return e.p.Equal(&other.(*ristrettoElement).p) == 1
+}
+
+func (e *ristrettoElement) isIdentity() bool {
+	return e.p.IsIdentity()
+}
+
+func (e *ristrettoElement) setBytes(b []byte) error {
+	if l := len(b); l != elementSize {
+		return fmt.Errorf("frost: bad element length: %d", l)
+	}
+
+	var compressed curve.CompressedRistretto
+	if _, err := compressed.SetBytes(b); err != nil {
+		return fmt.Errorf("frost: failed to deserialize element: %w", err)
+	}
+	if _, err := e.p.SetCompressed(&compressed); err != nil {
+		return fmt.Errorf("frost: failed to decompress element: %w", err)
+	}
+	if e.p.IsIdentity() {
+		return fmt.Errorf("frost: identity element")
+	}
+
+	return nil
+}
+
+func (e *ristrettoElement) bytes() []byte {
+	var compressed curve.CompressedRistretto
+	compressed.SetRistrettoPoint(&e.p)
+	return compressed[:]
+}
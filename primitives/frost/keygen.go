@@ -0,0 +1,276 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/polynomial"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/sr25519"
+)
+
+// KeyShare is a participant's share of the group signing key.
+type KeyShare struct {
+	// Identifier is the participant identifier.
+	Identifier Identifier
+
+	// SecretShare is the serialized secret share (sk_i).
+	SecretShare []byte
+
+	// GroupPublicKey is the serialized group public key.
+	GroupPublicKey []byte
+}
+
+// PublicKeyPackage is the public information about a signing group,
+// required to aggregate signature shares.
+type PublicKeyPackage struct {
+	// GroupPublicKey is the serialized group public key.
+	GroupPublicKey []byte
+
+	// VerificationShares are the serialized per-participant public key
+	// shares (PK_i), used to verify signature shares.
+	VerificationShares map[Identifier][]byte
+}
+
+// Ed25519PublicKey returns the group public key as an Ed25519 public key.
+// It is only valid for keys generated with the Ed25519 ciphersuite.
+func (cs *Ciphersuite) Ed25519PublicKey(pkp *PublicKeyPackage) (ed25519.PublicKey, error) {
+	if !cs.isEd25519 {
+		return nil, fmt.Errorf("frost: not an Ed25519 group public key")
+	}
+	if _, err := cs.deserializeElement(pkp.GroupPublicKey); err != nil {
+		return nil, err
+	}
+	return append(ed25519.PublicKey{}, pkp.GroupPublicKey...), nil
+}
+
+// SR25519PublicKey returns the group public key as a sr25519 public key.
+// It is only valid for keys generated with the ristretto255 ciphersuite.
+//
+// Note: FROST(ristretto255, SHA-512) signatures are not sr25519
+// signatures, as sr25519 uses a Merlin transcript for the challenge.
+func (cs *Ciphersuite) SR25519PublicKey(pkp *PublicKeyPackage) (*sr25519.PublicKey, error) {
+	if cs.isEd25519 {
+		return nil, fmt.Errorf("frost: not a ristretto255 group public key")
+	}
+	if _, err := cs.deserializeElement(pkp.GroupPublicKey); err != nil {
+		return nil, err
+	}
+	return sr25519.NewPublicKeyFromBytes(pkp.GroupPublicKey)
+}
+
+// TrustedDealerKeygen splits secretKey into maxParticipants shares, such
+// that any minParticipants of them can sign.  If secretKey is nil, a new
+// key will be generated.  The randomness is sourced from rand, which will
+// be crypto/rand.Reader if nil.
+//
+// It returns the participant key shares, the group's public information,
+// and the VSS commitment that participants can use to verify their share.
+func (cs *Ciphersuite) TrustedDealerKeygen(rand io.Reader, secretKey []byte, maxParticipants, minParticipants int) ([]*KeyShare, *PublicKeyPackage, [][]byte, error) {
+	if err := checkParticipants(maxParticipants, minParticipants); err != nil {
+		return nil, nil, nil, err
+	}
+
+	coefficients := make([]*scalar.Scalar, minParticipants)
+	switch secretKey {
+	case nil:
+		s, err := randomNonZeroScalar(rand)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		coefficients[0] = s
+	default:
+		s, err := deserializeScalar(secretKey)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if s.Equal(scalar.New()) == 1 {
+			return nil, nil, nil, fmt.Errorf("frost: secret key is zero")
+		}
+		coefficients[0] = s
+	}
+	for i := 1; i < minParticipants; i++ {
+		s, err := randomNonZeroScalar(rand)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		coefficients[i] = s
+	}
+
+	return cs.trustedDealerKeygen(coefficients, maxParticipants)
+}
+
+func (cs *Ciphersuite) trustedDealerKeygen(coefficients []*scalar.Scalar, maxParticipants int) ([]*KeyShare, *PublicKeyPackage, [][]byte, error) {
+	vssCommitment := make([][]byte, 0, len(coefficients))
+	for _, c := range coefficients {
+		vssCommitment = append(vssCommitment, cs.newElement().mulBasepoint(c).bytes())
+	}
+
+	pkp, err := cs.DeriveGroupInfo(maxParticipants, vssCommitment)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	shares := make([]*KeyShare, 0, maxParticipants)
+	for i := 1; i <= maxParticipants; i++ {
+		id := Identifier(i)
+		shares = append(shares, &KeyShare{
+			Identifier:     id,
+			SecretShare:    serializeScalar(polynomial.Evaluate(id.toScalar(), coefficients)),
+			GroupPublicKey: pkp.GroupPublicKey,
+		})
+	}
+
+	return shares, pkp, vssCommitment, nil
+}
+
+// VSSVerify verifies that share is consistent with the VSS commitment.
+func (cs *Ciphersuite) VSSVerify(share *KeyShare, vssCommitment [][]byte) error {
+	if share.Identifier == 0 {
+		return fmt.Errorf("frost: invalid identifier: 0")
+	}
+	sk, err := deserializeScalar(share.SecretShare)
+	if err != nil {
+		return err
+	}
+
+	expected, err := cs.evaluateVSSCommitment(share.Identifier, vssCommitment)
+	if err != nil {
+		return err
+	}
+	if !cs.newElement().mulBasepoint(sk).equal(expected) {
+		return fmt.Errorf("frost: share does not match VSS commitment")
+	}
+
+	return nil
+}
+
+// DeriveGroupInfo derives the group's public information, for participants
+// 1 through maxParticipants, from the VSS commitment.
+func (cs *Ciphersuite) DeriveGroupInfo(maxParticipants int, vssCommitment [][]byte) (*PublicKeyPackage, error) {
+	if err := checkParticipants(maxParticipants, len(vssCommitment)); err != nil {
+		return nil, err
+	}
+
+	pk, err := cs.deserializeElement(vssCommitment[0])
+	if err != nil {
+		return nil, err
+	}
+
+	pkp := &PublicKeyPackage{
+		GroupPublicKey:     pk.bytes(),
+		VerificationShares: make(map[Identifier][]byte),
+	}
+	for i := 1; i <= maxParticipants; i++ {
+		id := Identifier(i)
+		pkI, err := cs.evaluateVSSCommitment(id, vssCommitment)
+		if err != nil {
+			return nil, err
+		}
+		pkp.VerificationShares[id] = pkI.bytes()
+	}
+
+	return pkp, nil
+}
+
+func (cs *Ciphersuite) evaluateVSSCommitment(id Identifier, vssCommitment [][]byte) (element, error) {
+	if len(vssCommitment) == 0 {
+		return nil, fmt.Errorf("frost: empty VSS commitment")
+	}
+
+	// sum(commitment[j] * i^j)
+	x := id.toScalar()
+	scalars := make([]*scalar.Scalar, 0, len(vssCommitment))
+	points := make([]element, 0, len(vssCommitment))
+	xPow := scalar.One()
+	for _, b := range vssCommitment {
+		p, err := cs.deserializeElement(b)
+		if err != nil {
+			return nil, err
+		}
+		scalars = append(scalars, scalar.New().Set(xPow))
+		points = append(points, p)
+		xPow.Mul(xPow, x)
+	}
+
+	return cs.newElement().multiscalarMulVartime(scalars, points), nil
+}
+
+// deriveInterpolatingValue computes the Lagrange coefficient for xI, at
+// x = 0, given the set of participant identifiers.
+func deriveInterpolatingValue(participants []Identifier, xI Identifier) (*scalar.Scalar, error) {
+	idx := -1
+	xs := make([]*scalar.Scalar, 0, len(participants))
+	for i, xJ := range participants {
+		for _, xK := range participants[i+1:] {
+			if xJ == xK {
+				return nil, fmt.Errorf("frost: duplicate identifier: %d", xJ)
+			}
+		}
+		if xJ == xI {
+			idx = i
+		}
+		xs = append(xs, xJ.toScalar())
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("frost: identifier %d not in participant list", xI)
+	}
+
+	return polynomial.LagrangeCoefficient(xs, idx), nil
+}
+
+func checkParticipants(maxParticipants, minParticipants int) error {
+	if minParticipants < 2 {
+		return fmt.Errorf("frost: minimum participants must be at least 2: %d", minParticipants)
+	}
+	if maxParticipants < minParticipants {
+		return fmt.Errorf("frost: maximum participants less than minimum: %d < %d", maxParticipants, minParticipants)
+	}
+	if maxParticipants > math.MaxUint16 {
+		return fmt.Errorf("frost: maximum participants too large: %d", maxParticipants)
+	}
+	return nil
+}
+
+func randomNonZeroScalar(rand io.Reader) (*scalar.Scalar, error) {
+	for {
+		s, err := scalar.New().SetRandom(rand)
+		if err != nil {
+			return nil, fmt.Errorf("frost: failed to generate scalar: %w", err)
+		}
+		if s.Equal(scalar.New()) == 0 {
+			return s, nil
+		}
+	}
+}
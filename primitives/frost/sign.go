@@ -0,0 +1,386 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+)
+
+const nonceRandomnessSize = 32
+
+// SigningCommitment is a participant's round one nonce commitment.
+type SigningCommitment struct {
+	// Identifier is the participant identifier.
+	Identifier Identifier
+
+	// Hiding is the serialized hiding nonce commitment.
+	Hiding []byte
+
+	// Binding is the serialized binding nonce commitment.
+	Binding []byte
+}
+
+// SigningNonces are a participant's secret round one nonces.  Nonces
+// MUST NOT be reused, and are erased after use by Sign.
+type SigningNonces struct {
+	hiding     scalar.Scalar
+	binding    scalar.Scalar
+	commitment SigningCommitment
+	used       bool
+}
+
+// Commitment returns the commitment to the nonces, which is to be sent
+// to the coordinator.
+func (n *SigningNonces) Commitment() *SigningCommitment {
+	return &SigningCommitment{
+		Identifier: n.commitment.Identifier,
+		Hiding:     append([]byte{}, n.commitment.Hiding...),
+		Binding:    append([]byte{}, n.commitment.Binding...),
+	}
+}
+
+// SignatureShare is a participant's round two signature share.
+type SignatureShare struct {
+	// Identifier is the participant identifier.
+	Identifier Identifier
+
+	// Share is the serialized signature share.
+	Share []byte
+}
+
+// Commit generates a pair of nonces and the corresponding commitment,
+// for round one of the signing protocol.  The randomness is sourced from
+// rand, which will be crypto/rand.Reader if nil.
+func (cs *Ciphersuite) Commit(rand io.Reader, keyShare *KeyShare) (*SigningNonces, error) {
+	if keyShare.Identifier == 0 {
+		return nil, fmt.Errorf("frost: invalid identifier: 0")
+	}
+	sk, err := deserializeScalar(keyShare.SecretShare)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &SigningNonces{
+		commitment: SigningCommitment{
+			Identifier: keyShare.Identifier,
+		},
+	}
+	if err = cs.nonceGenerate(&n.hiding, rand, sk); err != nil {
+		return nil, err
+	}
+	if err = cs.nonceGenerate(&n.binding, rand, sk); err != nil {
+		return nil, err
+	}
+	n.commitment.Hiding = cs.newElement().mulBasepoint(&n.hiding).bytes()
+	n.commitment.Binding = cs.newElement().mulBasepoint(&n.binding).bytes()
+
+	return n, nil
+}
+
+func (cs *Ciphersuite) nonceGenerate(nonce *scalar.Scalar, rng io.Reader, secret *scalar.Scalar) error {
+	if rng == nil {
+		rng = rand.Reader
+	}
+
+	// nonce = H3(random_bytes || SerializeScalar(secret))
+	var randomBytes [nonceRandomnessSize]byte
+	if _, err := io.ReadFull(rng, randomBytes[:]); err != nil {
+		return fmt.Errorf("frost: failed to read nonce randomness: %w", err)
+	}
+	nonce.Set(cs.h3(randomBytes[:], serializeScalar(secret)))
+
+	return nil
+}
+
+// signingPackage is the state derived from the commitment list, common
+// to signing, signature share verification, and aggregation.
+type signingPackage struct {
+	participants    []Identifier
+	commitments     map[Identifier]*SigningCommitment
+	bindingFactors  map[Identifier]*scalar.Scalar
+	commitmentShare map[Identifier]element
+	groupCommitment element
+	challenge       *scalar.Scalar
+}
+
+func (cs *Ciphersuite) newSigningPackage(groupPublicKey []byte, commitments []*SigningCommitment, message []byte) (*signingPackage, error) {
+	if _, err := cs.deserializeElement(groupPublicKey); err != nil {
+		return nil, fmt.Errorf("frost: invalid group public key: %w", err)
+	}
+	if len(commitments) == 0 {
+		return nil, fmt.Errorf("frost: empty commitment list")
+	}
+
+	// The commitment list is processed in ascending order of identifier.
+	sorted := append([]*SigningCommitment{}, commitments...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Identifier < sorted[j].Identifier
+	})
+
+	sp := &signingPackage{
+		commitments:     make(map[Identifier]*SigningCommitment),
+		bindingFactors:  make(map[Identifier]*scalar.Scalar),
+		commitmentShare: make(map[Identifier]element),
+	}
+
+	var encodedCommitments []byte
+	hidings := make([]element, 0, len(sorted))
+	bindings := make([]element, 0, len(sorted))
+	for i, c := range sorted {
+		if c.Identifier == 0 {
+			return nil, fmt.Errorf("frost: invalid identifier: 0")
+		}
+		if i > 0 && sorted[i-1].Identifier == c.Identifier {
+			return nil, fmt.Errorf("frost: duplicate identifier: %d", c.Identifier)
+		}
+
+		hiding, err := cs.deserializeElement(c.Hiding)
+		if err != nil {
+			return nil, fmt.Errorf("frost: invalid hiding commitment for %d: %w", c.Identifier, err)
+		}
+		binding, err := cs.deserializeElement(c.Binding)
+		if err != nil {
+			return nil, fmt.Errorf("frost: invalid binding commitment for %d: %w", c.Identifier, err)
+		}
+
+		sp.participants = append(sp.participants, c.Identifier)
+		sp.commitments[c.Identifier] = c
+		hidings = append(hidings, hiding)
+		bindings = append(bindings, binding)
+
+		encodedCommitments = append(encodedCommitments, c.Identifier.bytes()...)
+		encodedCommitments = append(encodedCommitments, c.Hiding...)
+		encodedCommitments = append(encodedCommitments, c.Binding...)
+	}
+
+	// rho_input = SerializeElement(PK) || H4(msg) || H5(encoded_commitments) || SerializeScalar(i)
+	rhoInputPrefix := make([]byte, 0, ElementSize+2*64+ScalarSize)
+	rhoInputPrefix = append(rhoInputPrefix, groupPublicKey...)
+	rhoInputPrefix = append(rhoInputPrefix, cs.h4(message)...)
+	rhoInputPrefix = append(rhoInputPrefix, cs.h5(encodedCommitments)...)
+
+	// R = sum(D_i) + sum([rho_i]E_i)
+	n := len(sp.participants)
+	scalars := make([]*scalar.Scalar, 0, 2*n)
+	points := make([]element, 0, 2*n)
+	for i, id := range sp.participants {
+		rho := cs.h1(append(rhoInputPrefix, id.bytes()...))
+		sp.bindingFactors[id] = rho
+		sp.commitmentShare[id] = cs.newElement().add(hidings[i], cs.newElement().mul(bindings[i], rho))
+
+		scalars = append(scalars, scalar.One(), rho)
+		points = append(points, hidings[i], bindings[i])
+	}
+	sp.groupCommitment = cs.newElement().multiscalarMulVartime(scalars, points)
+
+	// c = H2(SerializeElement(R) || SerializeElement(PK) || msg)
+	sp.challenge = cs.h2(sp.groupCommitment.bytes(), groupPublicKey, message)
+
+	return sp, nil
+}
+
+// Sign produces a signature share over message, for round two of the
+// signing protocol.  The commitment list must include the commitment
+// corresponding to nonces, which are erased after use.
+func (cs *Ciphersuite) Sign(keyShare *KeyShare, nonces *SigningNonces, message []byte, commitments []*SigningCommitment) (*SignatureShare, error) {
+	if nonces.used {
+		return nil, fmt.Errorf("frost: nonces already used")
+	}
+	id := keyShare.Identifier
+	if nonces.commitment.Identifier != id {
+		return nil, fmt.Errorf("frost: nonces do not belong to participant %d", id)
+	}
+	sk, err := deserializeScalar(keyShare.SecretShare)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err := cs.newSigningPackage(keyShare.GroupPublicKey, commitments, message)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := sp.commitments[id]
+	if !ok {
+		return nil, fmt.Errorf("frost: participant %d not in commitment list", id)
+	}
+	if !bytes.Equal(c.Hiding, nonces.commitment.Hiding) || !bytes.Equal(c.Binding, nonces.commitment.Binding) {
+		return nil, fmt.Errorf("frost: commitment mismatch for participant %d", id)
+	}
+
+	lambda, err := deriveInterpolatingValue(sp.participants, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// z_i = d_i + (e_i * rho_i) + (lambda_i * sk_i * c)
+	var z, tmp scalar.Scalar
+	z.Mul(&nonces.binding, sp.bindingFactors[id])
+	z.Add(&z, &nonces.hiding)
+	tmp.Mul(lambda, sk)
+	tmp.Mul(&tmp, sp.challenge)
+	z.Add(&z, &tmp)
+
+	nonces.hiding.Zero()
+	nonces.binding.Zero()
+	nonces.used = true
+
+	return &SignatureShare{
+		Identifier: id,
+		Share:      serializeScalar(&z),
+	}, nil
+}
+
+// VerifySignatureShare verifies a signature share produced by Sign.
+func (cs *Ciphersuite) VerifySignatureShare(pkp *PublicKeyPackage, commitments []*SigningCommitment, message []byte, share *SignatureShare) error {
+	sp, err := cs.newSigningPackage(pkp.GroupPublicKey, commitments, message)
+	if err != nil {
+		return err
+	}
+	return cs.verifySignatureShare(pkp, sp, share)
+}
+
+func (cs *Ciphersuite) verifySignatureShare(pkp *PublicKeyPackage, sp *signingPackage, share *SignatureShare) error {
+	id := share.Identifier
+	commShare, ok := sp.commitmentShare[id]
+	if !ok {
+		return fmt.Errorf("frost: participant %d not in commitment list", id)
+	}
+	pkI, ok := pkp.VerificationShares[id]
+	if !ok {
+		return fmt.Errorf("frost: no verification share for participant %d", id)
+	}
+	PKi, err := cs.deserializeElement(pkI)
+	if err != nil {
+		return fmt.Errorf("frost: invalid verification share for participant %d: %w", id, err)
+	}
+	z, err := deserializeScalar(share.Share)
+	if err != nil {
+		return err
+	}
+
+	lambda, err := deriveInterpolatingValue(sp.participants, id)
+	if err != nil {
+		return err
+	}
+
+	// [z_i]B - [c * lambda_i]PK_i = D_i + [rho_i]E_i
+	var k scalar.Scalar
+	k.Mul(sp.challenge, lambda)
+	if !cs.newElement().doubleScalarMulBasepointVartime(k.Neg(&k), PKi, z).equal(commShare) {
+		return fmt.Errorf("frost: invalid signature share from participant %d", id)
+	}
+
+	return nil
+}
+
+// Aggregate aggregates the signature shares into a signature over message.
+// If the resulting signature is invalid, the signature shares are
+// individually verified, and the returned error identifies the invalid
+// shares.
+func (cs *Ciphersuite) Aggregate(pkp *PublicKeyPackage, commitments []*SigningCommitment, message []byte, shares []*SignatureShare) ([]byte, error) {
+	sp, err := cs.newSigningPackage(pkp.GroupPublicKey, commitments, message)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(sp.participants) {
+		return nil, fmt.Errorf("frost: expected %d signature shares, got %d", len(sp.participants), len(shares))
+	}
+
+	// z = sum(z_i)
+	seen := make(map[Identifier]bool)
+	z := scalar.New()
+	for _, share := range shares {
+		if _, ok := sp.commitments[share.Identifier]; !ok {
+			return nil, fmt.Errorf("frost: participant %d not in commitment list", share.Identifier)
+		}
+		if seen[share.Identifier] {
+			return nil, fmt.Errorf("frost: duplicate signature share: %d", share.Identifier)
+		}
+		seen[share.Identifier] = true
+
+		zI, err := deserializeScalar(share.Share)
+		if err != nil {
+			return nil, err
+		}
+		z.Add(z, zI)
+	}
+
+	sig := make([]byte, 0, SignatureSize)
+	sig = append(sig, sp.groupCommitment.bytes()...)
+	sig = append(sig, serializeScalar(z)...)
+
+	if !cs.Verify(pkp.GroupPublicKey, message, sig) {
+		var invalid []Identifier
+		for _, share := range shares {
+			if cs.verifySignatureShare(pkp, sp, share) != nil {
+				invalid = append(invalid, share.Identifier)
+			}
+		}
+		return nil, fmt.Errorf("frost: invalid signature, invalid shares from: %v", invalid)
+	}
+
+	return sig, nil
+}
+
+// Verify reports whether sig is a valid signature of message by the
+// groupPublicKey.  For the Ed25519 ciphersuite, this is equivalent to
+// ed25519.Verify.
+func (cs *Ciphersuite) Verify(groupPublicKey, message, sig []byte) bool {
+	if len(groupPublicKey) != ElementSize || len(sig) != SignatureSize {
+		return false
+	}
+	if cs.isEd25519 {
+		return ed25519.Verify(groupPublicKey, message, sig)
+	}
+
+	PK, err := cs.deserializeElement(groupPublicKey)
+	if err != nil {
+		return false
+	}
+	R, err := cs.deserializeElement(sig[:ElementSize])
+	if err != nil {
+		return false
+	}
+	z, err := deserializeScalar(sig[ElementSize:])
+	if err != nil {
+		return false
+	}
+
+	// [z]B - [c]PK = R
+	c := cs.h2(sig[:ElementSize], groupPublicKey, message)
+	return cs.newElement().doubleScalarMulBasepointVartime(c.Neg(c), PK, z).equal(R)
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package frost
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+)
+
+type rfcParticipantVector struct {
+	identifier             Identifier
+	hidingNonceRandomness  string
+	bindingNonceRandomness string
+	hidingNonce            string
+	bindingNonce           string
+	hidingNonceCommitment  string
+	bindingNonceCommitment string
+	bindingFactor          string
+	sigShare               string
+}
+
+type rfcTestVector struct {
+	suite            *Ciphersuite
+	maxParticipants  int
+	groupSecretKey   string
+	groupPublicKey   string
+	message          string
+	coefficient      string
+	participantShare []string
+	participants     []rfcParticipantVector
+	sig              string
+}
+
+// Test vectors from RFC 9591 Appendix E.
+var rfcTestVectors = []*rfcTestVector{
+	{
+		suite:           Ed25519SHA512,
+		maxParticipants: 3,
+		groupSecretKey:  "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304",
+		groupPublicKey:  "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+		message:         "74657374",
+		coefficient:     "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204",
+		participantShare: []string{
+			"929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+			"a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+			"d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+		},
+		participants: []rfcParticipantVector{
+			{
+				identifier:             1,
+				hidingNonceRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+				bindingNonceRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+				hidingNonce:            "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+				bindingNonce:           "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+				hidingNonceCommitment:  "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+				bindingNonceCommitment: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+				bindingFactor:          "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+				sigShare:               "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+			},
+			{
+				identifier:             3,
+				hidingNonceRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+				bindingNonceRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+				hidingNonce:            "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+				bindingNonce:           "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+				hidingNonceCommitment:  "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+				bindingNonceCommitment: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+				bindingFactor:          "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+				sigShare:               "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+			},
+		},
+		sig: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbebd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+	},
+	{
+		suite:           Ristretto255SHA512,
+		maxParticipants: 3,
+		groupSecretKey:  "1b25a55e463cfd15cf14a5d3acc3d15053f08da49c8afcf3ab265f2ebc4f970b",
+		groupPublicKey:  "e2a62f39eede11269e3bd5a7d97554f5ca384f9f6d3dd9c3c0d05083c7254f57",
+		message:         "74657374",
+		coefficient:     "410f8b744b19325891d73736923525a4f596c805d060dfb9c98009d34e3fec02",
+		participantShare: []string{
+			"5c3430d391552f6e60ecdc093ff9f6f4488756aa6cebdbad75a768010b8f830e",
+			"b06fc5eac20b4f6e1b271d9df2343d843e1e1fb03c4cbb673f2872d459ce6f01",
+			"f17e505f0e2581c6acfe54d3846a622834b5e7b50cad9a2109a97ba7a80d5c04",
+		},
+		participants: []rfcParticipantVector{
+			{
+				identifier:             1,
+				hidingNonceRandomness:  "f595a133b4d95c6e1f79887220c8b275ce6277e7f68a6640e1e7140f9be2fb5c",
+				bindingNonceRandomness: "34dd1001360e3513cb37bebfabe7be4a32c5bb91ba19fbd4360d039111f0fbdc",
+				hidingNonce:            "214f2cabb86ed71427ea7ad4283b0fae26b6746c801ce824b83ceb2b99278c03",
+				bindingNonce:           "c9b8f5e16770d15603f744f8694c44e335e8faef00dad182b8d7a34a62552f0c",
+				hidingNonceCommitment:  "965def4d0958398391fc06d8c2d72932608b1e6255226de4fb8d972dac15fd57",
+				bindingNonceCommitment: "ec5170920660820007ae9e1d363936659ef622f99879898db86e5bf1d5bf2a14",
+				bindingFactor:          "8967fd70fa06a58e5912603317fa94c77626395a695a0e4e4efc4476662eba0c",
+				sigShare:               "9285f875923ce7e0c491a592e9ea1865ec1b823ead4854b48c8a46287749ee09",
+			},
+			{
+				identifier:             3,
+				hidingNonceRandomness:  "daa0cf42a32617786d390e0c7edfbf2efbd428037069357b5173ae61d6dd5d5e",
+				bindingNonceRandomness: "b4387e72b2e4108ce4168931cc2c7fcce5f345a5297368952c18b5fc8473f050",
+				hidingNonce:            "3f7927872b0f9051dd98dd73eb2b91494173bbe0feb65a3e7e58d3e2318fa40f",
+				bindingNonce:           "ffd79445fb8030f0a3ddd3861aa4b42b618759282bfe24f1f9304c7009728305",
+				hidingNonceCommitment:  "480e06e3de182bf83489c45d7441879932fd7b434a26af41455756264fbd5d6e",
+				bindingNonceCommitment: "3064746dfd3c1862ef58fc68c706da287dd925066865ceacc816b3a28c7b363b",
+				bindingFactor:          "f2c1bb7c33a10511158c2f1766a4a5fadf9f86f2a92692ed333128277cc31006",
+				sigShare:               "7cb211fe0e3d59d25db6e36b3fb32344794139602a7b24f1ae0dc4e26ad7b908",
+			},
+		},
+		sig: "fc45655fbc66bbffad654ea4ce5fdae253a49a64ace25d9adb62010dd9fb25552164141787162e5b4cab915b4aa45d94655dbb9ed7c378a53b980a0be220a802",
+	},
+}
+
+func TestRFCVectors(t *testing.T) {
+	for _, v := range rfcTestVectors {
+		t.Run(v.suite.String(), func(t *testing.T) {
+			testRFCVector(t, v)
+		})
+	}
+}
+
+func testRFCVector(t *testing.T, v *rfcTestVector) {
+	cs := v.suite
+
+	// Key generation.
+	s, err := scalar.NewFromCanonicalBytes(testhelpers.MustUnhex(t, v.groupSecretKey))
+	if err != nil {
+		t.Fatalf("group secret key: %v", err)
+	}
+	a1, err := scalar.NewFromCanonicalBytes(testhelpers.MustUnhex(t, v.coefficient))
+	if err != nil {
+		t.Fatalf("coefficient: %v", err)
+	}
+	shares, pkp, vssCommitment, err := cs.trustedDealerKeygen([]*scalar.Scalar{s, a1}, v.maxParticipants)
+	if err != nil {
+		t.Fatalf("trustedDealerKeygen: %v", err)
+	}
+	checkHex(t, "group public key", pkp.GroupPublicKey, v.groupPublicKey)
+	for i, share := range shares {
+		checkHex(t, "participant share", share.SecretShare, v.participantShare[i])
+		if err = cs.VSSVerify(share, vssCommitment); err != nil {
+			t.Fatalf("VSSVerify(%d): %v", share.Identifier, err)
+		}
+	}
+
+	// Round one.
+	msg := testhelpers.MustUnhex(t, v.message)
+	nonces := make(map[Identifier]*SigningNonces)
+	var commitments []*SigningCommitment
+	for _, p := range v.participants {
+		rng := bytes.NewReader(append(testhelpers.MustUnhex(t, p.hidingNonceRandomness), testhelpers.MustUnhex(t, p.bindingNonceRandomness)...))
+		n, err := cs.Commit(rng, shares[p.identifier-1])
+		if err != nil {
+			t.Fatalf("Commit(%d): %v", p.identifier, err)
+		}
+		checkHex(t, "hiding nonce", serializeScalar(&n.hiding), p.hidingNonce)
+		checkHex(t, "binding nonce", serializeScalar(&n.binding), p.bindingNonce)
+		checkHex(t, "hiding nonce commitment", n.Commitment().Hiding, p.hidingNonceCommitment)
+		checkHex(t, "binding nonce commitment", n.Commitment().Binding, p.bindingNonceCommitment)
+
+		nonces[p.identifier] = n
+		commitments = append(commitments, n.Commitment())
+	}
+
+	// Round two.
+	sp, err := cs.newSigningPackage(pkp.GroupPublicKey, commitments, msg)
+	if err != nil {
+		t.Fatalf("newSigningPackage: %v", err)
+	}
+	var sigShares []*SignatureShare
+	for _, p := range v.participants {
+		checkHex(t, "binding factor", serializeScalar(sp.bindingFactors[p.identifier]), p.bindingFactor)
+
+		share, err := cs.Sign(shares[p.identifier-1], nonces[p.identifier], msg, commitments)
+		if err != nil {
+			t.Fatalf("Sign(%d): %v", p.identifier, err)
+		}
+		checkHex(t, "signature share", share.Share, p.sigShare)
+		if err = cs.VerifySignatureShare(pkp, commitments, msg, share); err != nil {
+			t.Fatalf("VerifySignatureShare(%d): %v", p.identifier, err)
+		}
+		sigShares = append(sigShares, share)
+	}
+
+	sig, err := cs.Aggregate(pkp, commitments, msg, sigShares)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	checkHex(t, "signature", sig, v.sig)
+	if !cs.Verify(pkp.GroupPublicKey, msg, sig) {
+		t.Fatalf("Verify: failed")
+	}
+}
+
+func checkHex(t *testing.T, what string, b []byte, expected string) {
+	if x := hex.EncodeToString(b); x != expected {
+		t.Errorf("%s mismatch (Got: %s, Expected: %s)", what, x, expected)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hpke
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var (
+	// ErrOpen is the error returned when a ciphertext fails to decrypt.
+	ErrOpen = errors.New("hpke: decryption failed")
+
+	// ErrMessageLimitReached is the error returned when a context's
+	// sequence number has reached the maximum value.
+	ErrMessageLimitReached = errors.New("hpke: message limit reached")
+
+	errExportOnly = errors.New("hpke: export-only context")
+)
+
+type context struct {
+	kdf *labeledKDF
+
+	aead           cipher.AEAD
+	baseNonce      []byte
+	seq            uint64
+	exporterSecret []byte
+}
+
+func (ctx *context) nonce() []byte {
+	// nonce = xor(base_nonce, I2OSP(seq, Nn))
+	nonce := append([]byte{}, ctx.baseNonce...)
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], ctx.seq)
+	off := len(nonce) - len(seq)
+	for i, v := range seq {
+		nonce[off+i] ^= v
+	}
+	return nonce
+}
+
+func (ctx *context) checkSeq() error {
+	if ctx.aead == nil {
+		return errExportOnly
+	}
+	// The sequence number is a uint64, so the limit is reached well
+	// before the 2^(8*Nn) - 1 specified by the RFC.
+	if ctx.seq == math.MaxUint64 {
+		return ErrMessageLimitReached
+	}
+	return nil
+}
+
+func (ctx *context) export(exporterContext []byte, length int) ([]byte, error) {
+	return ctx.kdf.expand(ctx.exporterSecret, "sec", exporterContext, length)
+}
+
+// SenderContext is a sender's encryption context.
+type SenderContext struct {
+	ctx context
+}
+
+// Seal encrypts and authenticates plaintext and authenticates aad, and
+// returns the ciphertext.
+func (ctx *SenderContext) Seal(aad, plaintext []byte) ([]byte, error) {
+	if err := ctx.ctx.checkSeq(); err != nil {
+		return nil, err
+	}
+
+	ciphertext := ctx.ctx.aead.Seal(nil, ctx.ctx.nonce(), plaintext, aad)
+	ctx.ctx.seq++
+
+	return ciphertext, nil
+}
+
+// Export derives a secret of the specified length from the context,
+// bound to the exporter context.
+func (ctx *SenderContext) Export(exporterContext []byte, length int) ([]byte, error) {
+	return ctx.ctx.export(exporterContext, length)
+}
+
+// RecipientContext is a recipient's decryption context.
+type RecipientContext struct {
+	ctx context
+}
+
+// Open authenticates and decrypts ciphertext and authenticates aad, and
+// returns the plaintext.
+func (ctx *RecipientContext) Open(aad, ciphertext []byte) ([]byte, error) {
+	if err := ctx.ctx.checkSeq(); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := ctx.ctx.aead.Open(nil, ctx.ctx.nonce(), ciphertext, aad)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	ctx.ctx.seq++
+
+	return plaintext, nil
+}
+
+// Export derives a secret of the specified length from the context,
+// bound to the exporter context.
+func (ctx *RecipientContext) Export(exporterContext []byte, length int) ([]byte, error) {
+	return ctx.ctx.export(exporterContext, length)
+}
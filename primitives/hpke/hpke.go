@@ -0,0 +1,442 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package hpke implements Hybrid Public Key Encryption (HPKE) as specified
+// in RFC 9180, with the DHKEM(X25519, HKDF-SHA256) KEM.
+//
+// All four modes (Base, PSK, Auth, AuthPSK) are supported, with the mode
+// selected by the Options passed to the setup and single-shot functions.
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+// Mode is a HPKE mode.
+type Mode uint8
+
+const (
+	// ModeBase is the Base mode.
+	ModeBase Mode = 0x00
+
+	// ModePSK is the PSK mode, which authenticates the sender by the
+	// possession of a pre-shared key.
+	ModePSK Mode = 0x01
+
+	// ModeAuth is the Auth mode, which authenticates the sender by the
+	// possession of a static private key.
+	ModeAuth Mode = 0x02
+
+	// ModeAuthPSK is the AuthPSK mode, which combines the PSK and Auth
+	// modes.
+	ModeAuthPSK Mode = 0x03
+)
+
+// String returns the string representation of a mode.
+func (m Mode) String() string {
+	switch m {
+	case ModeBase:
+		return "Base"
+	case ModePSK:
+		return "PSK"
+	case ModeAuth:
+		return "Auth"
+	case ModeAuthPSK:
+		return "AuthPSK"
+	default:
+		return fmt.Sprintf("[unknown mode: %d]", uint8(m))
+	}
+}
+
+// KDF is a HPKE KDF identifier.
+type KDF uint16
+
+const (
+	// KDFHKDFSHA256 is HKDF-SHA256.
+	KDFHKDFSHA256 KDF = 0x0001
+
+	// KDFHKDFSHA384 is HKDF-SHA384.
+	KDFHKDFSHA384 KDF = 0x0002
+
+	// KDFHKDFSHA512 is HKDF-SHA512.
+	KDFHKDFSHA512 KDF = 0x0003
+)
+
+func (kdf KDF) hash() (func() hash.Hash, error) {
+	switch kdf {
+	case KDFHKDFSHA256:
+		return sha256.New, nil
+	case KDFHKDFSHA384:
+		return sha512.New384, nil
+	case KDFHKDFSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("hpke: unsupported KDF: 0x%04x", uint16(kdf))
+	}
+}
+
+// AEAD is a HPKE AEAD identifier.
+type AEAD uint16
+
+const (
+	// AEADAES128GCM is AES-128-GCM.
+	AEADAES128GCM AEAD = 0x0001
+
+	// AEADAES256GCM is AES-256-GCM.
+	AEADAES256GCM AEAD = 0x0002
+
+	// AEADChaCha20Poly1305 is ChaCha20Poly1305.
+	AEADChaCha20Poly1305 AEAD = 0x0003
+
+	// AEADExportOnly is the export-only AEAD, which only allows the
+	// use of the secret export interface.
+	AEADExportOnly AEAD = 0xffff
+)
+
+func (aead AEAD) keySize() (int, error) {
+	switch aead {
+	case AEADAES128GCM:
+		return 16, nil
+	case AEADAES256GCM, AEADChaCha20Poly1305:
+		return 32, nil
+	case AEADExportOnly:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("hpke: unsupported AEAD: 0x%04x", uint16(aead))
+	}
+}
+
+func (aead AEAD) new(key []byte) (cipher.AEAD, error) {
+	switch aead {
+	case AEADAES128GCM, AEADAES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case AEADChaCha20Poly1305:
+		return chacha20poly1305.New(key)
+	default:
+		return nil, fmt.Errorf("hpke: unsupported AEAD: 0x%04x", uint16(aead))
+	}
+}
+
+// Suite is a HPKE cipher suite.  The KEM is always DHKEM(X25519, HKDF-SHA256).
+type Suite struct {
+	// KDF is the KDF used by the key schedule.
+	KDF KDF
+
+	// AEAD is the AEAD used to encrypt messages.
+	AEAD AEAD
+}
+
+func (s *Suite) id() []byte {
+	// suite_id = concat("HPKE", I2OSP(kem_id, 2), I2OSP(kdf_id, 2), I2OSP(aead_id, 2))
+	var b [10]byte
+	copy(b[:], "HPKE")
+	binary.BigEndian.PutUint16(b[4:], KEMX25519HKDFSHA256)
+	binary.BigEndian.PutUint16(b[6:], uint16(s.KDF))
+	binary.BigEndian.PutUint16(b[8:], uint16(s.AEAD))
+	return b[:]
+}
+
+// Options are the optional setup parameters, which select the mode.
+//
+// The PSK modes are used iff PSK and PSKID are set, and the Auth modes are
+// used iff SenderPrivateKey (sender side) or SenderPublicKey (recipient
+// side) is set.
+type Options struct {
+	// PSK is the pre-shared key.
+	PSK []byte
+
+	// PSKID is the pre-shared key identifier.
+	PSKID []byte
+
+	// SenderPrivateKey is the sender's static private key, used by the
+	// sender in the Auth modes.
+	SenderPrivateKey *x25519.PrivateKey
+
+	// SenderPublicKey is the sender's static public key, used by the
+	// recipient in the Auth modes.
+	SenderPublicKey *x25519.PublicKey
+}
+
+func (opts *Options) mode(isSender bool) (Mode, error) {
+	if opts == nil {
+		return ModeBase, nil
+	}
+
+	// VerifyPSKInputs
+	hasPSK, hasPSKID := len(opts.PSK) > 0, len(opts.PSKID) > 0
+	if hasPSK != hasPSKID {
+		return 0, fmt.Errorf("hpke: inconsistent PSK inputs")
+	}
+
+	var isAuth bool
+	switch isSender {
+	case true:
+		if opts.SenderPublicKey != nil {
+			return 0, fmt.Errorf("hpke: sender public key set for the sender")
+		}
+		isAuth = opts.SenderPrivateKey != nil
+	case false:
+		if opts.SenderPrivateKey != nil {
+			return 0, fmt.Errorf("hpke: sender private key set for the recipient")
+		}
+		isAuth = opts.SenderPublicKey != nil
+	}
+
+	switch {
+	case hasPSK && isAuth:
+		return ModeAuthPSK, nil
+	case hasPSK:
+		return ModePSK, nil
+	case isAuth:
+		return ModeAuth, nil
+	default:
+		return ModeBase, nil
+	}
+}
+
+func (opts *Options) psk() ([]byte, []byte) {
+	if opts == nil {
+		return nil, nil
+	}
+	return opts.PSK, opts.PSKID
+}
+
+// SetupSender sets up a context for the sender, that encrypts messages to
+// the recipient's public key, and returns the encapsulated key that must be
+// sent to the recipient.  If rand is nil, crypto/rand.Reader will be used.
+func (s *Suite) SetupSender(rand io.Reader, recipientPublicKey *x25519.PublicKey, info []byte, opts *Options) ([]byte, *SenderContext, error) {
+	mode, err := opts.mode(true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var senderPrivateKey *x25519.PrivateKey
+	if opts != nil {
+		senderPrivateKey = opts.SenderPrivateKey
+	}
+	sharedSecret, enc, err := encap(rand, recipientPublicKey, senderPrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	psk, pskID := opts.psk()
+	ctx, err := s.keySchedule(mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return enc, &SenderContext{*ctx}, nil
+}
+
+// SetupRecipient sets up a context for the recipient, that decrypts messages
+// from the sender, given the encapsulated key.
+func (s *Suite) SetupRecipient(enc []byte, recipientPrivateKey *x25519.PrivateKey, info []byte, opts *Options) (*RecipientContext, error) {
+	mode, err := opts.mode(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var senderPublicKey *x25519.PublicKey
+	if opts != nil {
+		senderPublicKey = opts.SenderPublicKey
+	}
+	sharedSecret, err := decap(enc, recipientPrivateKey, senderPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	psk, pskID := opts.psk()
+	ctx, err := s.keySchedule(mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecipientContext{*ctx}, nil
+}
+
+// Seal encrypts a single message to the recipient's public key, and returns
+// the encapsulated key and the ciphertext.  If rand is nil,
+// crypto/rand.Reader will be used.
+func (s *Suite) Seal(rand io.Reader, recipientPublicKey *x25519.PublicKey, info, aad, plaintext []byte, opts *Options) ([]byte, []byte, error) {
+	enc, ctx, err := s.SetupSender(rand, recipientPublicKey, info, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	ciphertext, err := ctx.Seal(aad, plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return enc, ciphertext, nil
+}
+
+// Open decrypts a single message encrypted by Seal.
+func (s *Suite) Open(enc []byte, recipientPrivateKey *x25519.PrivateKey, info, aad, ciphertext []byte, opts *Options) ([]byte, error) {
+	ctx, err := s.SetupRecipient(enc, recipientPrivateKey, info, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.Open(aad, ciphertext)
+}
+
+// SendExport derives a secret shared with the recipient, and returns the
+// encapsulated key and the secret.  If rand is nil, crypto/rand.Reader
+// will be used.
+func (s *Suite) SendExport(rand io.Reader, recipientPublicKey *x25519.PublicKey, info, exporterContext []byte, length int, opts *Options) ([]byte, []byte, error) {
+	enc, ctx, err := s.SetupSender(rand, recipientPublicKey, info, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err := ctx.Export(exporterContext, length)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return enc, secret, nil
+}
+
+// ReceiveExport derives the secret returned by SendExport.
+func (s *Suite) ReceiveExport(enc []byte, recipientPrivateKey *x25519.PrivateKey, info, exporterContext []byte, length int, opts *Options) ([]byte, error) {
+	ctx, err := s.SetupRecipient(enc, recipientPrivateKey, info, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.Export(exporterContext, length)
+}
+
+func (s *Suite) keySchedule(mode Mode, sharedSecret, info, psk, pskID []byte) (*context, error) {
+	h, err := s.KDF.hash()
+	if err != nil {
+		return nil, err
+	}
+	keySize, err := s.AEAD.keySize()
+	if err != nil {
+		return nil, err
+	}
+	kdf := &labeledKDF{
+		hash:    h,
+		suiteID: s.id(),
+	}
+
+	// key_schedule_context = concat(mode, psk_id_hash, info_hash)
+	pskIDHash := kdf.extract(nil, "psk_id_hash", pskID)
+	infoHash := kdf.extract(nil, "info_hash", info)
+	keyScheduleContext := make([]byte, 0, 1+len(pskIDHash)+len(infoHash))
+	keyScheduleContext = append(keyScheduleContext, byte(mode))
+	keyScheduleContext = append(keyScheduleContext, pskIDHash...)
+	keyScheduleContext = append(keyScheduleContext, infoHash...)
+
+	secret := kdf.extract(sharedSecret, "secret", psk)
+	defer func() {
+		for i := range secret {
+			secret[i] = 0
+		}
+	}()
+
+	ctx := &context{
+		kdf: kdf,
+	}
+	if s.AEAD != AEADExportOnly {
+		key, err := kdf.expand(secret, "key", keyScheduleContext, keySize)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.aead, err = s.AEAD.new(key); err != nil {
+			return nil, err
+		}
+		if ctx.baseNonce, err = kdf.expand(secret, "base_nonce", keyScheduleContext, ctx.aead.NonceSize()); err != nil {
+			return nil, err
+		}
+	}
+	if ctx.exporterSecret, err = kdf.expand(secret, "exp", keyScheduleContext, h().Size()); err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+type labeledKDF struct {
+	hash    func() hash.Hash
+	suiteID []byte
+}
+
+func (kdf *labeledKDF) extract(salt []byte, label string, ikm []byte) []byte {
+	// labeled_ikm = concat("HPKE-v1", suite_id, label, ikm)
+	labeledIKM := make([]byte, 0, len(versionLabel)+len(kdf.suiteID)+len(label)+len(ikm))
+	labeledIKM = append(labeledIKM, versionLabel...)
+	labeledIKM = append(labeledIKM, kdf.suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+
+	prk := hkdf.Extract(kdf.hash, labeledIKM, salt)
+	for i := range labeledIKM {
+		labeledIKM[i] = 0
+	}
+
+	return prk
+}
+
+func (kdf *labeledKDF) expand(prk []byte, label string, info []byte, length int) ([]byte, error) {
+	if length < 0 || length > 255*kdf.hash().Size() {
+		return nil, fmt.Errorf("hpke: invalid expand length: %d", length)
+	}
+
+	// labeled_info = concat(I2OSP(L, 2), "HPKE-v1", suite_id, label, info)
+	labeledInfo := make([]byte, 2, 2+len(versionLabel)+len(kdf.suiteID)+len(label)+len(info))
+	binary.BigEndian.PutUint16(labeledInfo, uint16(length))
+	labeledInfo = append(labeledInfo, versionLabel...)
+	labeledInfo = append(labeledInfo, kdf.suiteID...)
+	labeledInfo = append(labeledInfo, label...)
+	labeledInfo = append(labeledInfo, info...)
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(kdf.hash, prk, labeledInfo), b); err != nil {
+		return nil, fmt.Errorf("hpke: failed to expand: %w", err)
+	}
+
+	return b, nil
+}
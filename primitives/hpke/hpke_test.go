@@ -0,0 +1,317 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hpke
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+func TestHPKE(t *testing.T) {
+	t.Run("RoundTrip", testRoundTrip)
+	t.Run("Errors", testErrors)
+	t.Run("ExportOnly", testExportOnly)
+	t.Run("MessageLimit", testMessageLimit)
+}
+
+func testGenerateKeyPair(t *testing.T) (*x25519.PublicKey, *x25519.PrivateKey) {
+	pk, sk, err := GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	return pk, sk
+}
+
+func testModeOptions(t *testing.T, mode Mode) (*Options, *Options) {
+	var senderOpts, recipientOpts Options
+	if mode == ModePSK || mode == ModeAuthPSK {
+		senderOpts.PSK = []byte("0123456789abcdef0123456789abcdef")
+		senderOpts.PSKID = []byte("test psk")
+		recipientOpts.PSK, recipientOpts.PSKID = senderOpts.PSK, senderOpts.PSKID
+	}
+	if mode == ModeAuth || mode == ModeAuthPSK {
+		pkS, skS := testGenerateKeyPair(t)
+		senderOpts.SenderPrivateKey = skS
+		recipientOpts.SenderPublicKey = pkS
+	}
+
+	if m, err := senderOpts.mode(true); err != nil || m != mode {
+		t.Fatalf("sender mode: %v (Got: %v)", err, m)
+	}
+	if m, err := recipientOpts.mode(false); err != nil || m != mode {
+		t.Fatalf("recipient mode: %v (Got: %v)", err, m)
+	}
+
+	return &senderOpts, &recipientOpts
+}
+
+func testRoundTrip(t *testing.T) {
+	for _, kdf := range []KDF{KDFHKDFSHA256, KDFHKDFSHA384, KDFHKDFSHA512} {
+		for _, aead := range []AEAD{AEADAES128GCM, AEADAES256GCM, AEADChaCha20Poly1305} {
+			for _, mode := range []Mode{ModeBase, ModePSK, ModeAuth, ModeAuthPSK} {
+				suite := &Suite{KDF: kdf, AEAD: aead}
+				mode := mode
+				n := fmt.Sprintf("%v/0x%04x/0x%04x", mode, uint16(kdf), uint16(aead))
+				t.Run(n, func(t *testing.T) {
+					testRoundTripSuite(t, suite, mode)
+				})
+			}
+		}
+	}
+}
+
+func testRoundTripSuite(t *testing.T, suite *Suite, mode Mode) {
+	pkR, skR := testGenerateKeyPair(t)
+	senderOpts, recipientOpts := testModeOptions(t, mode)
+	info := []byte("test info")
+
+	enc, sender, err := suite.SetupSender(nil, pkR, info, senderOpts)
+	if err != nil {
+		t.Fatalf("SetupSender: %v", err)
+	}
+	recipient, err := suite.SetupRecipient(enc, skR, info, recipientOpts)
+	if err != nil {
+		t.Fatalf("SetupRecipient: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		aad := []byte(fmt.Sprintf("aad %d", i))
+		pt := []byte(fmt.Sprintf("message %d", i))
+		ct, err := sender.Seal(aad, pt)
+		if err != nil {
+			t.Fatalf("Seal: %v", err)
+		}
+
+		if _, err = recipient.Open([]byte("bad aad"), ct); !errors.Is(err, ErrOpen) {
+			t.Fatalf("Open(bad aad): %v", err)
+		}
+		b, err := recipient.Open(aad, ct)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		if !bytes.Equal(b, pt) {
+			t.Fatalf("plaintext mismatch")
+		}
+	}
+
+	exporterContext := []byte("test exporter context")
+	senderSecret, err := sender.Export(exporterContext, 42)
+	if err != nil {
+		t.Fatalf("sender Export: %v", err)
+	}
+	recipientSecret, err := recipient.Export(exporterContext, 42)
+	if err != nil {
+		t.Fatalf("recipient Export: %v", err)
+	}
+	if !bytes.Equal(senderSecret, recipientSecret) || len(senderSecret) != 42 {
+		t.Fatalf("exported secret mismatch")
+	}
+
+	// Single-shot APIs.
+	aad, pt := []byte("single-shot aad"), []byte("single-shot message")
+	enc, ct, err := suite.Seal(nil, pkR, info, aad, pt, senderOpts)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	b, err := suite.Open(enc, skR, info, aad, ct, recipientOpts)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(b, pt) {
+		t.Fatalf("single-shot plaintext mismatch")
+	}
+	if _, err = suite.Open(enc, skR, []byte("bad info"), aad, ct, recipientOpts); err == nil {
+		t.Fatalf("Open(bad info) succeeded")
+	}
+
+	enc, senderSecret, err = suite.SendExport(nil, pkR, info, exporterContext, 32, senderOpts)
+	if err != nil {
+		t.Fatalf("SendExport: %v", err)
+	}
+	recipientSecret, err = suite.ReceiveExport(enc, skR, info, exporterContext, 32, recipientOpts)
+	if err != nil {
+		t.Fatalf("ReceiveExport: %v", err)
+	}
+	if !bytes.Equal(senderSecret, recipientSecret) {
+		t.Fatalf("single-shot exported secret mismatch")
+	}
+
+	// The recipient must use the same mode specific inputs.
+	if mode != ModeBase {
+		badOpts := *recipientOpts
+		if badOpts.SenderPublicKey != nil {
+			badOpts.SenderPublicKey, _ = testGenerateKeyPair(t)
+		}
+		if badOpts.PSK != nil {
+			badOpts.PSKID = []byte("bad psk")
+		}
+		if _, err = suite.Open(enc, skR, info, aad, ct, &badOpts); err == nil {
+			t.Fatalf("Open(bad options) succeeded")
+		}
+	}
+}
+
+func testErrors(t *testing.T) {
+	suite := &Suite{KDF: KDFHKDFSHA256, AEAD: AEADChaCha20Poly1305}
+	pkR, skR := testGenerateKeyPair(t)
+	pkS, skS := testGenerateKeyPair(t)
+
+	t.Run("LowOrder", func(t *testing.T) {
+		var lowOrder x25519.PublicKey // The all-zero u-coordinate.
+
+		if _, _, err := suite.SetupSender(nil, &lowOrder, nil, nil); err == nil {
+			t.Fatalf("SetupSender(low order pkR) succeeded")
+		}
+		if _, err := suite.SetupRecipient(lowOrder[:], skR, nil, nil); err == nil {
+			t.Fatalf("SetupRecipient(low order enc) succeeded")
+		}
+
+		enc, _, err := suite.SetupSender(nil, pkR, nil, nil)
+		if err != nil {
+			t.Fatalf("SetupSender: %v", err)
+		}
+		if _, err = suite.SetupRecipient(enc, skR, nil, &Options{SenderPublicKey: &lowOrder}); err == nil {
+			t.Fatalf("SetupRecipient(low order pkS) succeeded")
+		}
+	})
+	t.Run("Options", func(t *testing.T) {
+		for _, v := range []struct {
+			name     string
+			opts     *Options
+			isSender bool
+		}{
+			{"PSKWithoutID", &Options{PSK: []byte("psk")}, true},
+			{"IDWithoutPSK", &Options{PSKID: []byte("psk id")}, false},
+			{"SenderPublicKey", &Options{SenderPublicKey: pkS}, true},
+			{"SenderPrivateKey", &Options{SenderPrivateKey: skS}, false},
+		} {
+			if _, err := v.opts.mode(v.isSender); err == nil {
+				t.Fatalf("%s: mode succeeded", v.name)
+			}
+		}
+	})
+	t.Run("Suite", func(t *testing.T) {
+		for _, s := range []*Suite{
+			{KDF: 0, AEAD: AEADChaCha20Poly1305},
+			{KDF: KDFHKDFSHA256, AEAD: 0},
+		} {
+			if _, _, err := s.SetupSender(nil, pkR, nil, nil); err == nil {
+				t.Fatalf("SetupSender(%+v) succeeded", s)
+			}
+		}
+	})
+	t.Run("EncapsulatedKey", func(t *testing.T) {
+		if _, err := suite.SetupRecipient(pkS[:31], skR, nil, nil); err == nil {
+			t.Fatalf("SetupRecipient(truncated enc) succeeded")
+		}
+	})
+	t.Run("DeriveKeyPair", func(t *testing.T) {
+		if _, _, err := DeriveKeyPair(make([]byte, 31)); err == nil {
+			t.Fatalf("DeriveKeyPair(short ikm) succeeded")
+		}
+	})
+	t.Run("ExportLength", func(t *testing.T) {
+		_, ctx, err := suite.SetupSender(nil, pkR, nil, nil)
+		if err != nil {
+			t.Fatalf("SetupSender: %v", err)
+		}
+		if _, err = ctx.Export(nil, 255*32+1); err == nil {
+			t.Fatalf("Export(too long) succeeded")
+		}
+	})
+}
+
+func testExportOnly(t *testing.T) {
+	suite := &Suite{KDF: KDFHKDFSHA512, AEAD: AEADExportOnly}
+	pkR, skR := testGenerateKeyPair(t)
+
+	enc, sender, err := suite.SetupSender(nil, pkR, nil, nil)
+	if err != nil {
+		t.Fatalf("SetupSender: %v", err)
+	}
+	recipient, err := suite.SetupRecipient(enc, skR, nil, nil)
+	if err != nil {
+		t.Fatalf("SetupRecipient: %v", err)
+	}
+
+	if _, err = sender.Seal(nil, []byte("message")); err == nil {
+		t.Fatalf("Seal succeeded")
+	}
+	if _, err = recipient.Open(nil, make([]byte, 32)); err == nil {
+		t.Fatalf("Open succeeded")
+	}
+
+	senderSecret, err := sender.Export(nil, 64)
+	if err != nil {
+		t.Fatalf("sender Export: %v", err)
+	}
+	recipientSecret, err := recipient.Export(nil, 64)
+	if err != nil {
+		t.Fatalf("recipient Export: %v", err)
+	}
+	if !bytes.Equal(senderSecret, recipientSecret) {
+		t.Fatalf("exported secret mismatch")
+	}
+}
+
+func testMessageLimit(t *testing.T) {
+	suite := &Suite{KDF: KDFHKDFSHA256, AEAD: AEADAES128GCM}
+	pkR, skR := testGenerateKeyPair(t)
+
+	enc, sender, err := suite.SetupSender(nil, pkR, nil, nil)
+	if err != nil {
+		t.Fatalf("SetupSender: %v", err)
+	}
+	recipient, err := suite.SetupRecipient(enc, skR, nil, nil)
+	if err != nil {
+		t.Fatalf("SetupRecipient: %v", err)
+	}
+
+	sender.ctx.seq, recipient.ctx.seq = math.MaxUint64-1, math.MaxUint64-1
+	ct, err := sender.Seal(nil, []byte("last message"))
+	if err != nil {
+		t.Fatalf("Seal(MaxUint64 - 1): %v", err)
+	}
+	if _, err = recipient.Open(nil, ct); err != nil {
+		t.Fatalf("Open(MaxUint64 - 1): %v", err)
+	}
+
+	if _, err = sender.Seal(nil, []byte("one too many")); !errors.Is(err, ErrMessageLimitReached) {
+		t.Fatalf("Seal(MaxUint64): %v", err)
+	}
+	if _, err = recipient.Open(nil, ct); !errors.Is(err, ErrMessageLimitReached) {
+		t.Fatalf("Open(MaxUint64): %v", err)
+	}
+}
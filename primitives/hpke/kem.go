@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hpke
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// KEMX25519HKDFSHA256 is the KEM identifier of DHKEM(X25519, HKDF-SHA256).
+	KEMX25519HKDFSHA256 = 0x0020
+
+	// EncapsulatedKeySize is the size, in bytes, of an encapsulated key.
+	EncapsulatedKeySize = x25519.PublicKeySize
+
+	// SharedSecretSize is the size, in bytes, of the KEM shared secret.
+	SharedSecretSize = 32
+
+	versionLabel = "HPKE-v1"
+)
+
+var kemKDF = &labeledKDF{
+	hash:    sha256.New,
+	suiteID: kemSuiteID(),
+}
+
+func kemSuiteID() []byte {
+	// suite_id = concat("KEM", I2OSP(kem_id, 2))
+	var b [5]byte
+	copy(b[:], "KEM")
+	binary.BigEndian.PutUint16(b[3:], KEMX25519HKDFSHA256)
+	return b[:]
+}
+
+// GenerateKeyPair generates a public/private key pair using entropy from
+// rand.  If rand is nil, crypto/rand.Reader will be used.
+func GenerateKeyPair(rand io.Reader) (*x25519.PublicKey, *x25519.PrivateKey, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var ikm [x25519.PrivateKeySize]byte
+	if _, err := io.ReadFull(rand, ikm[:]); err != nil {
+		return nil, nil, fmt.Errorf("hpke: failed to read entropy: %w", err)
+	}
+	defer func() {
+		for i := range ikm {
+			ikm[i] = 0
+		}
+	}()
+
+	return DeriveKeyPair(ikm[:])
+}
+
+// DeriveKeyPair deterministically derives a public/private key pair from
+// the input keying material, which must be at least 32 bytes long.
+func DeriveKeyPair(ikm []byte) (*x25519.PublicKey, *x25519.PrivateKey, error) {
+	if l := len(ikm); l < x25519.PrivateKeySize {
+		return nil, nil, fmt.Errorf("hpke: insufficient input keying material: %d", l)
+	}
+
+	dkpPRK := kemKDF.extract(nil, "dkp_prk", ikm)
+	sk, err := kemKDF.expand(dkpPRK, "sk", nil, x25519.PrivateKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var privateKey x25519.PrivateKey
+	copy(privateKey[:], sk)
+	for i := range sk {
+		sk[i] = 0
+	}
+
+	return privateKey.Public(), &privateKey, nil
+}
+
+// encap implements Encap, or AuthEncap iff senderPrivateKey is non-nil.
+func encap(rand io.Reader, recipientPublicKey *x25519.PublicKey, senderPrivateKey *x25519.PrivateKey) ([]byte, []byte, error) {
+	ephemeralPublicKey, ephemeralPrivateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		for i := range ephemeralPrivateKey {
+			ephemeralPrivateKey[i] = 0
+		}
+	}()
+
+	// kem_context = concat(enc, pkRm[, pkSm])
+	enc := ephemeralPublicKey[:]
+	kemContext := append([]byte{}, enc...)
+	kemContext = append(kemContext, recipientPublicKey[:]...)
+
+	// dh = DH(skE, pkR)[ || DH(skS, pkR)]
+	dhs := [][2][]byte{
+		{ephemeralPrivateKey[:], recipientPublicKey[:]},
+	}
+	if senderPrivateKey != nil {
+		kemContext = append(kemContext, senderPrivateKey.Public()[:]...)
+		dhs = append(dhs, [2][]byte{senderPrivateKey[:], recipientPublicKey[:]})
+	}
+
+	sharedSecret, err := extractAndExpand(dhs, kemContext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sharedSecret, append([]byte{}, enc...), nil
+}
+
+// decap implements Decap, or AuthDecap iff senderPublicKey is non-nil.
+func decap(enc []byte, recipientPrivateKey *x25519.PrivateKey, senderPublicKey *x25519.PublicKey) ([]byte, error) {
+	if l := len(enc); l != EncapsulatedKeySize {
+		return nil, fmt.Errorf("hpke: invalid encapsulated key length: %d", l)
+	}
+
+	// kem_context = concat(enc, pkRm[, pkSm])
+	kemContext := append([]byte{}, enc...)
+	kemContext = append(kemContext, recipientPrivateKey.Public()[:]...)
+
+	// dh = DH(skR, pkE)[ || DH(skR, pkS)]
+	dhs := [][2][]byte{
+		{recipientPrivateKey[:], enc},
+	}
+	if senderPublicKey != nil {
+		kemContext = append(kemContext, senderPublicKey[:]...)
+		dhs = append(dhs, [2][]byte{recipientPrivateKey[:], senderPublicKey[:]})
+	}
+
+	return extractAndExpand(dhs, kemContext)
+}
+
+func extractAndExpand(dhs [][2][]byte, kemContext []byte) ([]byte, error) {
+	dh := make([]byte, 0, len(dhs)*x25519.SharedSecretSize)
+	defer func() {
+		for i := range dh {
+			dh[i] = 0
+		}
+	}()
+	for _, v := range dhs {
+		// x25519.X25519 rejects low-order points, which covers the
+		// all-zero shared secret check required by the RFC.
+		b, err := x25519.X25519(v[0], v[1])
+		if err != nil {
+			return nil, fmt.Errorf("hpke: DH failed: %w", err)
+		}
+		dh = append(dh, b...)
+	}
+
+	eaePRK := kemKDF.extract(nil, "eae_prk", dh)
+	return kemKDF.expand(eaePRK, "shared_secret", kemContext, SharedSecretSize)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package hpke
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+type testVector struct {
+	Mode   Mode   `json:"mode"`
+	KEMID  uint16 `json:"kem_id"`
+	KDFID  KDF    `json:"kdf_id"`
+	AEADID AEAD   `json:"aead_id"`
+	Info   string `json:"info"`
+
+	IKMR string `json:"ikmR"`
+	IKMS string `json:"ikmS"`
+	IKME string `json:"ikmE"`
+	SKRm string `json:"skRm"`
+	SKSm string `json:"skSm"`
+	SKEm string `json:"skEm"`
+	PKRm string `json:"pkRm"`
+	PKSm string `json:"pkSm"`
+	PKEm string `json:"pkEm"`
+
+	PSK   string `json:"psk"`
+	PSKID string `json:"psk_id"`
+
+	Enc            string `json:"enc"`
+	SharedSecret   string `json:"shared_secret"`
+	BaseNonce      string `json:"base_nonce"`
+	ExporterSecret string `json:"exporter_secret"`
+
+	Encryptions []testEncryption `json:"encryptions"`
+	Exports     []testExport     `json:"exports"`
+}
+
+type testEncryption struct {
+	AAD   string `json:"aad"`
+	CT    string `json:"ct"`
+	Nonce string `json:"nonce"`
+	PT    string `json:"pt"`
+}
+
+type testExport struct {
+	ExporterContext string `json:"exporter_context"`
+	L               int    `json:"L"`
+	ExportedValue   string `json:"exported_value"`
+}
+
+func TestVectors(t *testing.T) {
+	f, err := os.Open("testdata/rfc9180.json.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rd, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+
+	var testVectors []testVector
+
+	dec := json.NewDecoder(rd)
+	if err = dec.Decode(&testVectors); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, vec := range testVectors {
+		if vec.KEMID != KEMX25519HKDFSHA256 {
+			continue
+		}
+		vec := vec
+		n := fmt.Sprintf("TestCase/%d/%v/0x%04x/0x%04x", i, vec.Mode, uint16(vec.KDFID), uint16(vec.AEADID))
+		t.Run(n, func(t *testing.T) {
+			testVectorCase(t, &vec)
+		})
+	}
+}
+
+func testVectorKeyPair(t *testing.T, ikm, expectedSk, expectedPk string) *x25519.PrivateKey {
+	pk, sk, err := DeriveKeyPair(testhelpers.MustUnhex(t, ikm))
+	if err != nil {
+		t.Fatalf("DeriveKeyPair: %v", err)
+	}
+	if !bytes.Equal(sk[:], testhelpers.MustUnhex(t, expectedSk)) {
+		t.Fatalf("DeriveKeyPair: private key mismatch (Got: %x)", sk[:])
+	}
+	if !bytes.Equal(pk[:], testhelpers.MustUnhex(t, expectedPk)) {
+		t.Fatalf("DeriveKeyPair: public key mismatch (Got: %x)", pk[:])
+	}
+	return sk
+}
+
+func testVectorCase(t *testing.T, vec *testVector) {
+	skR := testVectorKeyPair(t, vec.IKMR, vec.SKRm, vec.PKRm)
+	_ = testVectorKeyPair(t, vec.IKME, vec.SKEm, vec.PKEm)
+
+	var senderOpts, recipientOpts Options
+	if vec.Mode == ModePSK || vec.Mode == ModeAuthPSK {
+		senderOpts.PSK = testhelpers.MustUnhex(t, vec.PSK)
+		senderOpts.PSKID = testhelpers.MustUnhex(t, vec.PSKID)
+		recipientOpts.PSK, recipientOpts.PSKID = senderOpts.PSK, senderOpts.PSKID
+	}
+	if vec.Mode == ModeAuth || vec.Mode == ModeAuthPSK {
+		skS := testVectorKeyPair(t, vec.IKMS, vec.SKSm, vec.PKSm)
+		senderOpts.SenderPrivateKey = skS
+		recipientOpts.SenderPublicKey = skS.Public()
+	}
+
+	suite := &Suite{
+		KDF:  vec.KDFID,
+		AEAD: vec.AEADID,
+	}
+	info := testhelpers.MustUnhex(t, vec.Info)
+
+	// The ephemeral key pair is derived from ikmE, which GenerateKeyPair
+	// reads from rand.
+	enc, sender, err := suite.SetupSender(bytes.NewReader(testhelpers.MustUnhex(t, vec.IKME)), skR.Public(), info, &senderOpts)
+	if err != nil {
+		t.Fatalf("SetupSender: %v", err)
+	}
+	if !bytes.Equal(enc, testhelpers.MustUnhex(t, vec.Enc)) {
+		t.Fatalf("SetupSender: enc mismatch (Got: %x)", enc)
+	}
+	sharedSecret, err := decap(enc, skR, recipientOpts.SenderPublicKey)
+	if err != nil {
+		t.Fatalf("decap: %v", err)
+	}
+	if !bytes.Equal(sharedSecret, testhelpers.MustUnhex(t, vec.SharedSecret)) {
+		t.Fatalf("decap: shared secret mismatch (Got: %x)", sharedSecret)
+	}
+	recipient, err := suite.SetupRecipient(enc, skR, info, &recipientOpts)
+	if err != nil {
+		t.Fatalf("SetupRecipient: %v", err)
+	}
+
+	for _, ctx := range []*context{&sender.ctx, &recipient.ctx} {
+		if !bytes.Equal(ctx.baseNonce, testhelpers.MustUnhex(t, vec.BaseNonce)) {
+			t.Fatalf("base nonce mismatch (Got: %x)", ctx.baseNonce)
+		}
+		if !bytes.Equal(ctx.exporterSecret, testhelpers.MustUnhex(t, vec.ExporterSecret)) {
+			t.Fatalf("exporter secret mismatch (Got: %x)", ctx.exporterSecret)
+		}
+	}
+
+	baseNonce := testhelpers.MustUnhex(t, vec.BaseNonce)
+	for i, v := range vec.Encryptions {
+		// The test vectors only include a subset of the encryptions,
+		// so the sequence number is recovered from the nonce.
+		nonce := testhelpers.MustUnhex(t, v.Nonce)
+		for j := range nonce {
+			nonce[j] ^= baseNonce[j]
+		}
+		seq := binary.BigEndian.Uint64(nonce[len(nonce)-8:])
+		sender.ctx.seq, recipient.ctx.seq = seq, seq
+
+		aad, pt := testhelpers.MustUnhex(t, v.AAD), testhelpers.MustUnhex(t, v.PT)
+		ct, err := sender.Seal(aad, pt)
+		if err != nil {
+			t.Fatalf("Encryptions[%d]: Seal: %v", i, err)
+		}
+		if !bytes.Equal(ct, testhelpers.MustUnhex(t, v.CT)) {
+			t.Fatalf("Encryptions[%d]: ciphertext mismatch (Got: %x)", i, ct)
+		}
+		b, err := recipient.Open(aad, ct)
+		if err != nil {
+			t.Fatalf("Encryptions[%d]: Open: %v", i, err)
+		}
+		if !bytes.Equal(b, pt) {
+			t.Fatalf("Encryptions[%d]: plaintext mismatch (Got: %x)", i, b)
+		}
+		if sender.ctx.seq != seq+1 || recipient.ctx.seq != seq+1 {
+			t.Fatalf("Encryptions[%d]: sequence number not incremented", i)
+		}
+	}
+	if len(vec.Encryptions) > 0 {
+		v := vec.Encryptions[0]
+		aad := testhelpers.MustUnhex(t, v.AAD)
+		b, err := suite.Open(enc, skR, info, aad, testhelpers.MustUnhex(t, v.CT), &recipientOpts)
+		if err != nil {
+			t.Fatalf("single-shot Open: %v", err)
+		}
+		if !bytes.Equal(b, testhelpers.MustUnhex(t, v.PT)) {
+			t.Fatalf("single-shot Open: plaintext mismatch (Got: %x)", b)
+		}
+	}
+
+	for i, v := range vec.Exports {
+		exporterContext := testhelpers.MustUnhex(t, v.ExporterContext)
+		expected := testhelpers.MustUnhex(t, v.ExportedValue)
+		for _, export := range []func([]byte, int) ([]byte, error){
+			sender.Export,
+			recipient.Export,
+		} {
+			b, err := export(exporterContext, v.L)
+			if err != nil {
+				t.Fatalf("Exports[%d]: Export: %v", i, err)
+			}
+			if !bytes.Equal(b, expected) {
+				t.Fatalf("Exports[%d]: exported value mismatch (Got: %x)", i, b)
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package kem provides a key encapsulation mechanism (KEM) interface, with
+// implementations of X25519, and of the X25519MLKEM768 hybrid post-quantum
+// KEM used by TLS 1.3.
+//
+// X25519MLKEM768 uses crypto/mlkem, and is only available when built with
+// Go 1.24 or later.
+package kem
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+// Scheme is a key encapsulation mechanism.
+type Scheme interface {
+	// Name returns the name of the KEM.
+	Name() string
+
+	// EncapsulationKeySize returns the size, in bytes, of an
+	// encapsulation key.
+	EncapsulationKeySize() int
+
+	// CiphertextSize returns the size, in bytes, of a ciphertext.
+	CiphertextSize() int
+
+	// SharedKeySize returns the size, in bytes, of a shared key.
+	SharedKeySize() int
+
+	// SeedSize returns the size, in bytes, of the seed used to derive
+	// a decapsulation key.
+	SeedSize() int
+
+	// GenerateKey generates a decapsulation key using entropy from rand.
+	// If rand is nil, crypto/rand.Reader will be used.
+	GenerateKey(rand io.Reader) (DecapsulationKey, error)
+
+	// NewDecapsulationKey derives a decapsulation key from a seed, as
+	// returned by DecapsulationKey.Bytes.
+	NewDecapsulationKey(seed []byte) (DecapsulationKey, error)
+
+	// NewEncapsulationKey parses an encapsulation key in the wire format.
+	NewEncapsulationKey(b []byte) (EncapsulationKey, error)
+}
+
+// EncapsulationKey is a public key, used to produce ciphertexts.
+type EncapsulationKey interface {
+	// Bytes returns the encapsulation key in the wire format.
+	Bytes() []byte
+
+	// Encapsulate generates a shared key and a ciphertext that can
+	// be decapsulated by the corresponding DecapsulationKey, drawing
+	// random bytes from crypto/rand.Reader.
+	Encapsulate() (sharedKey, ciphertext []byte, err error)
+}
+
+// DecapsulationKey is a private key, used to decapsulate ciphertexts.
+type DecapsulationKey interface {
+	// Bytes returns the seed that the decapsulation key is derived from.
+	Bytes() []byte
+
+	// EncapsulationKey returns the corresponding encapsulation key.
+	EncapsulationKey() EncapsulationKey
+
+	// Decapsulate returns the shared key from a ciphertext.
+	Decapsulate(ciphertext []byte) (sharedKey []byte, err error)
+}
+
+func generateKey(s Scheme, rand io.Reader) (DecapsulationKey, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	seed := make([]byte, s.SeedSize())
+	defer func() {
+		for i := range seed {
+			seed[i] = 0
+		}
+	}()
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, fmt.Errorf("kem: failed to read entropy: %w", err)
+	}
+
+	return s.NewDecapsulationKey(seed)
+}
+
+// X25519 returns the X25519 KEM, as used by TLS 1.3.  The encapsulation key
+// is the X25519 public key, the ciphertext is the ephemeral X25519 public
+// key, and the shared key is the X25519 shared secret.  The seed is the
+// X25519 private key.
+func X25519() Scheme {
+	return x25519Scheme{}
+}
+
+type x25519Scheme struct{}
+
+func (x25519Scheme) Name() string {
+	return "X25519"
+}
+
+func (x25519Scheme) EncapsulationKeySize() int {
+	return x25519.PublicKeySize
+}
+
+func (x25519Scheme) CiphertextSize() int {
+	return x25519.PublicKeySize
+}
+
+func (x25519Scheme) SharedKeySize() int {
+	return x25519.SharedSecretSize
+}
+
+func (x25519Scheme) SeedSize() int {
+	return x25519.PrivateKeySize
+}
+
+func (s x25519Scheme) GenerateKey(rand io.Reader) (DecapsulationKey, error) {
+	return generateKey(s, rand)
+}
+
+func (x25519Scheme) NewDecapsulationKey(seed []byte) (DecapsulationKey, error) {
+	if l := len(seed); l != x25519.PrivateKeySize {
+		return nil, fmt.Errorf("kem: invalid X25519 seed length: %d", l)
+	}
+
+	var dk x25519DecapsulationKey
+	copy(dk.privateKey[:], seed)
+	dk.publicKey = *dk.privateKey.Public()
+
+	return &dk, nil
+}
+
+func (x25519Scheme) NewEncapsulationKey(b []byte) (EncapsulationKey, error) {
+	if l := len(b); l != x25519.PublicKeySize {
+		return nil, fmt.Errorf("kem: invalid X25519 encapsulation key length: %d", l)
+	}
+
+	var ek x25519EncapsulationKey
+	copy(ek.publicKey[:], b)
+
+	return &ek, nil
+}
+
+type x25519EncapsulationKey struct {
+	publicKey x25519.PublicKey
+}
+
+func (ek *x25519EncapsulationKey) Bytes() []byte {
+	return append([]byte{}, ek.publicKey[:]...)
+}
+
+func (ek *x25519EncapsulationKey) Encapsulate() ([]byte, []byte, error) {
+	return ek.encapsulate(cryptorand.Reader)
+}
+
+func (ek *x25519EncapsulationKey) encapsulate(rand io.Reader) ([]byte, []byte, error) {
+	var ephemeralKey x25519.PrivateKey
+	defer func() {
+		for i := range ephemeralKey {
+			ephemeralKey[i] = 0
+		}
+	}()
+	if _, err := io.ReadFull(rand, ephemeralKey[:]); err != nil {
+		return nil, nil, fmt.Errorf("kem: failed to read entropy: %w", err)
+	}
+
+	sharedKey, err := x25519.X25519(ephemeralKey[:], ek.publicKey[:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("kem: X25519 failed: %w", err)
+	}
+
+	return sharedKey, ephemeralKey.Public()[:], nil
+}
+
+type x25519DecapsulationKey struct {
+	privateKey x25519.PrivateKey
+	publicKey  x25519.PublicKey
+}
+
+func (dk *x25519DecapsulationKey) Bytes() []byte {
+	return append([]byte{}, dk.privateKey[:]...)
+}
+
+func (dk *x25519DecapsulationKey) EncapsulationKey() EncapsulationKey {
+	return &x25519EncapsulationKey{
+		publicKey: dk.publicKey,
+	}
+}
+
+func (dk *x25519DecapsulationKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	if l := len(ciphertext); l != x25519.PublicKeySize {
+		return nil, fmt.Errorf("kem: invalid X25519 ciphertext length: %d", l)
+	}
+
+	sharedKey, err := x25519.X25519(dk.privateKey[:], ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("kem: X25519 failed: %w", err)
+	}
+
+	return sharedKey, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package kem
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+)
+
+func TestKEM(t *testing.T) {
+	t.Run("X25519", func(t *testing.T) {
+		testScheme(t, X25519())
+	})
+	t.Run("X25519/KAT", testX25519KAT)
+}
+
+func testX25519KAT(t *testing.T) {
+	// RFC 7748 6.1, with Alice as the sender, and Bob as the recipient.
+	var (
+		seed             = testhelpers.MustUnhex(t, "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb")
+		encapsulationKey = testhelpers.MustUnhex(t, "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f")
+		ephemeralKey     = testhelpers.MustUnhex(t, "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+		ciphertext       = testhelpers.MustUnhex(t, "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+		sharedKey        = testhelpers.MustUnhex(t, "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742")
+	)
+
+	dk, err := X25519().NewDecapsulationKey(seed)
+	if err != nil {
+		t.Fatalf("NewDecapsulationKey: %v", err)
+	}
+	if b := dk.EncapsulationKey().Bytes(); !bytes.Equal(b, encapsulationKey) {
+		t.Fatalf("encapsulation key mismatch (Got: %x)", b)
+	}
+
+	ek := dk.EncapsulationKey().(*x25519EncapsulationKey)
+	ss, ct, err := ek.encapsulate(bytes.NewReader(ephemeralKey))
+	if err != nil {
+		t.Fatalf("encapsulate: %v", err)
+	}
+	if !bytes.Equal(ct, ciphertext) {
+		t.Fatalf("ciphertext mismatch (Got: %x)", ct)
+	}
+	if !bytes.Equal(ss, sharedKey) {
+		t.Fatalf("encapsulate: shared key mismatch (Got: %x)", ss)
+	}
+
+	if ss, err = dk.Decapsulate(ciphertext); err != nil {
+		t.Fatalf("Decapsulate: %v", err)
+	}
+	if !bytes.Equal(ss, sharedKey) {
+		t.Fatalf("Decapsulate: shared key mismatch (Got: %x)", ss)
+	}
+}
+
+func testScheme(t *testing.T, s Scheme) {
+	dk, err := s.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	if l := len(dk.Bytes()); l != s.SeedSize() {
+		t.Fatalf("unexpected seed size: %d", l)
+	}
+
+	ekBytes := dk.EncapsulationKey().Bytes()
+	if l := len(ekBytes); l != s.EncapsulationKeySize() {
+		t.Fatalf("unexpected encapsulation key size: %d", l)
+	}
+	ek, err := s.NewEncapsulationKey(ekBytes)
+	if err != nil {
+		t.Fatalf("NewEncapsulationKey: %v", err)
+	}
+	if !bytes.Equal(ek.Bytes(), ekBytes) {
+		t.Fatalf("encapsulation key round trip mismatch")
+	}
+
+	// The seed must fully determine the decapsulation key.
+	dk2, err := s.NewDecapsulationKey(dk.Bytes())
+	if err != nil {
+		t.Fatalf("NewDecapsulationKey: %v", err)
+	}
+	if !bytes.Equal(dk2.EncapsulationKey().Bytes(), ekBytes) {
+		t.Fatalf("decapsulation key round trip mismatch")
+	}
+
+	sharedKey, ciphertext, err := ek.Encapsulate()
+	if err != nil {
+		t.Fatalf("Encapsulate: %v", err)
+	}
+	if l := len(sharedKey); l != s.SharedKeySize() {
+		t.Fatalf("unexpected shared key size: %d", l)
+	}
+	if l := len(ciphertext); l != s.CiphertextSize() {
+		t.Fatalf("unexpected ciphertext size: %d", l)
+	}
+	for _, k := range []DecapsulationKey{dk, dk2} {
+		b, err := k.Decapsulate(ciphertext)
+		if err != nil {
+			t.Fatalf("Decapsulate: %v", err)
+		}
+		if !bytes.Equal(b, sharedKey) {
+			t.Fatalf("shared key mismatch")
+		}
+	}
+
+	// Another decapsulation key must derive a different shared key.
+	otherDk, err := s.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	if b, err := otherDk.Decapsulate(ciphertext); err == nil && bytes.Equal(b, sharedKey) {
+		t.Fatalf("Decapsulate with wrong key derived the shared key")
+	}
+
+	// In all of the schemes, the X25519 component is the last 32 bytes
+	// of the encapsulation key, and of the ciphertext.
+	lowOrderEk := append([]byte{}, ekBytes...)
+	for i := len(lowOrderEk) - 32; i < len(lowOrderEk); i++ {
+		lowOrderEk[i] = 0
+	}
+	if ek, err = s.NewEncapsulationKey(lowOrderEk); err != nil {
+		t.Fatalf("NewEncapsulationKey(low order): %v", err)
+	}
+	if _, _, err = ek.Encapsulate(); err == nil {
+		t.Fatalf("Encapsulate(low order) succeeded")
+	}
+
+	lowOrderCt := append([]byte{}, ciphertext...)
+	for i := len(lowOrderCt) - 32; i < len(lowOrderCt); i++ {
+		lowOrderCt[i] = 0
+	}
+	if _, err = dk.Decapsulate(lowOrderCt); err == nil {
+		t.Fatalf("Decapsulate(low order) succeeded")
+	}
+
+	// Malformed inputs.
+	if _, err = s.NewDecapsulationKey(dk.Bytes()[1:]); err == nil {
+		t.Fatalf("NewDecapsulationKey(truncated) succeeded")
+	}
+	if _, err = s.NewEncapsulationKey(ekBytes[1:]); err == nil {
+		t.Fatalf("NewEncapsulationKey(truncated) succeeded")
+	}
+	if _, err = dk.Decapsulate(ciphertext[1:]); err == nil {
+		t.Fatalf("Decapsulate(truncated) succeeded")
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+//go:build go1.24
+
+package kem
+
+import (
+	"crypto/mlkem"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// X25519MLKEM768EncapsulationKeySize is the size, in bytes, of a
+	// X25519MLKEM768 encapsulation key (the client key share).
+	X25519MLKEM768EncapsulationKeySize = mlkem.EncapsulationKeySize768 + x25519.PublicKeySize
+
+	// X25519MLKEM768CiphertextSize is the size, in bytes, of a
+	// X25519MLKEM768 ciphertext (the server key share).
+	X25519MLKEM768CiphertextSize = mlkem.CiphertextSize768 + x25519.PublicKeySize
+
+	// X25519MLKEM768SharedKeySize is the size, in bytes, of a
+	// X25519MLKEM768 shared key.
+	X25519MLKEM768SharedKeySize = mlkem.SharedKeySize + x25519.SharedSecretSize
+
+	// X25519MLKEM768SeedSize is the size, in bytes, of a X25519MLKEM768
+	// decapsulation key seed.
+	X25519MLKEM768SeedSize = mlkem.SeedSize + x25519.PrivateKeySize
+)
+
+// X25519MLKEM768 returns the X25519MLKEM768 hybrid KEM, as specified by
+// draft-ietf-tls-ecdhe-mlkem.
+//
+// The encapsulation key is the ML-KEM-768 encapsulation key followed by the
+// X25519 public key, the ciphertext is the ML-KEM-768 ciphertext followed by
+// the ephemeral X25519 public key, and the shared key is the ML-KEM-768
+// shared key followed by the X25519 shared secret.  The seed is the
+// ML-KEM-768 "d || z" seed followed by the X25519 private key.
+func X25519MLKEM768() Scheme {
+	return x25519MLKEM768Scheme{}
+}
+
+type x25519MLKEM768Scheme struct{}
+
+func (x25519MLKEM768Scheme) Name() string {
+	return "X25519MLKEM768"
+}
+
+func (x25519MLKEM768Scheme) EncapsulationKeySize() int {
+	return X25519MLKEM768EncapsulationKeySize
+}
+
+func (x25519MLKEM768Scheme) CiphertextSize() int {
+	return X25519MLKEM768CiphertextSize
+}
+
+func (x25519MLKEM768Scheme) SharedKeySize() int {
+	return X25519MLKEM768SharedKeySize
+}
+
+func (x25519MLKEM768Scheme) SeedSize() int {
+	return X25519MLKEM768SeedSize
+}
+
+func (s x25519MLKEM768Scheme) GenerateKey(rand io.Reader) (DecapsulationKey, error) {
+	return generateKey(s, rand)
+}
+
+func (x25519MLKEM768Scheme) NewDecapsulationKey(seed []byte) (DecapsulationKey, error) {
+	if l := len(seed); l != X25519MLKEM768SeedSize {
+		return nil, fmt.Errorf("kem: invalid X25519MLKEM768 seed length: %d", l)
+	}
+
+	mlkemKey, err := mlkem.NewDecapsulationKey768(seed[:mlkem.SeedSize])
+	if err != nil {
+		return nil, fmt.Errorf("kem: invalid ML-KEM-768 seed: %w", err)
+	}
+	dk := &x25519MLKEM768DecapsulationKey{
+		mlkem: mlkemKey,
+	}
+	copy(dk.x25519[:], seed[mlkem.SeedSize:])
+	dk.ek = &x25519MLKEM768EncapsulationKey{
+		mlkem:  mlkemKey.EncapsulationKey(),
+		x25519: *dk.x25519.Public(),
+	}
+
+	return dk, nil
+}
+
+func (x25519MLKEM768Scheme) NewEncapsulationKey(b []byte) (EncapsulationKey, error) {
+	if l := len(b); l != X25519MLKEM768EncapsulationKeySize {
+		return nil, fmt.Errorf("kem: invalid X25519MLKEM768 encapsulation key length: %d", l)
+	}
+
+	mlkemKey, err := mlkem.NewEncapsulationKey768(b[:mlkem.EncapsulationKeySize768])
+	if err != nil {
+		return nil, fmt.Errorf("kem: invalid ML-KEM-768 encapsulation key: %w", err)
+	}
+	ek := &x25519MLKEM768EncapsulationKey{
+		mlkem: mlkemKey,
+	}
+	copy(ek.x25519[:], b[mlkem.EncapsulationKeySize768:])
+
+	return ek, nil
+}
+
+type x25519MLKEM768EncapsulationKey struct {
+	mlkem  *mlkem.EncapsulationKey768
+	x25519 x25519.PublicKey
+}
+
+func (ek *x25519MLKEM768EncapsulationKey) Bytes() []byte {
+	b := make([]byte, 0, X25519MLKEM768EncapsulationKeySize)
+	b = append(b, ek.mlkem.Bytes()...)
+	return append(b, ek.x25519[:]...)
+}
+
+func (ek *x25519MLKEM768EncapsulationKey) Encapsulate() ([]byte, []byte, error) {
+	return ek.encapsulate(cryptorand.Reader, func() ([]byte, []byte, error) {
+		sharedKey, ciphertext := ek.mlkem.Encapsulate()
+		return sharedKey, ciphertext, nil
+	})
+}
+
+func (ek *x25519MLKEM768EncapsulationKey) encapsulate(rand io.Reader, mlkemEncapsulate func() ([]byte, []byte, error)) ([]byte, []byte, error) {
+	x25519Key := x25519EncapsulationKey{
+		publicKey: ek.x25519,
+	}
+	x25519SharedKey, x25519Ciphertext, err := x25519Key.encapsulate(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	mlkemSharedKey, mlkemCiphertext, err := mlkemEncapsulate()
+	if err != nil {
+		return nil, nil, fmt.Errorf("kem: ML-KEM-768 encapsulation failed: %w", err)
+	}
+
+	sharedKey := make([]byte, 0, X25519MLKEM768SharedKeySize)
+	sharedKey = append(sharedKey, mlkemSharedKey...)
+	sharedKey = append(sharedKey, x25519SharedKey...)
+
+	ciphertext := make([]byte, 0, X25519MLKEM768CiphertextSize)
+	ciphertext = append(ciphertext, mlkemCiphertext...)
+	ciphertext = append(ciphertext, x25519Ciphertext...)
+
+	return sharedKey, ciphertext, nil
+}
+
+type x25519MLKEM768DecapsulationKey struct {
+	mlkem  *mlkem.DecapsulationKey768
+	x25519 x25519.PrivateKey
+	ek     *x25519MLKEM768EncapsulationKey
+}
+
+func (dk *x25519MLKEM768DecapsulationKey) Bytes() []byte {
+	b := make([]byte, 0, X25519MLKEM768SeedSize)
+	b = append(b, dk.mlkem.Bytes()...)
+	return append(b, dk.x25519[:]...)
+}
+
+func (dk *x25519MLKEM768DecapsulationKey) EncapsulationKey() EncapsulationKey {
+	return dk.ek
+}
+
+func (dk *x25519MLKEM768DecapsulationKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	if l := len(ciphertext); l != X25519MLKEM768CiphertextSize {
+		return nil, fmt.Errorf("kem: invalid X25519MLKEM768 ciphertext length: %d", l)
+	}
+
+	mlkemSharedKey, err := dk.mlkem.Decapsulate(ciphertext[:mlkem.CiphertextSize768])
+	if err != nil {
+		return nil, fmt.Errorf("kem: ML-KEM-768 decapsulation failed: %w", err)
+	}
+	x25519SharedKey, err := x25519.X25519(dk.x25519[:], ciphertext[mlkem.CiphertextSize768:])
+	if err != nil {
+		return nil, fmt.Errorf("kem: X25519 failed: %w", err)
+	}
+
+	sharedKey := make([]byte, 0, X25519MLKEM768SharedKeySize)
+	sharedKey = append(sharedKey, mlkemSharedKey...)
+	return append(sharedKey, x25519SharedKey...), nil
+}
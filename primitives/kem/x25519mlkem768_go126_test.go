@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+//go:build go1.26
+
+package kem
+
+import (
+	"bytes"
+	"crypto/mlkem/mlkemtest"
+	"fmt"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+)
+
+func TestX25519MLKEM768Encapsulate(t *testing.T) {
+	for i, vec := range loadX25519MLKEM768TestVectors(t) {
+		vec := vec
+		t.Run(fmt.Sprintf("TestCase/%d", i), func(t *testing.T) {
+			ek, err := X25519MLKEM768().NewEncapsulationKey(testhelpers.MustUnhex(t, vec.EncapsulationKey))
+			if err != nil {
+				t.Fatalf("NewEncapsulationKey: %v", err)
+			}
+
+			// The randomness is the ML-KEM-768 encapsulation randomness
+			// followed by the ephemeral X25519 private key.
+			rnd := testhelpers.MustUnhex(t, vec.EncapsulationRandomness)
+			hybridEk := ek.(*x25519MLKEM768EncapsulationKey)
+			sharedKey, ciphertext, err := hybridEk.encapsulate(bytes.NewReader(rnd[32:]), func() ([]byte, []byte, error) {
+				return mlkemtest.Encapsulate768(hybridEk.mlkem, rnd[:32])
+			})
+			if err != nil {
+				t.Fatalf("encapsulate: %v", err)
+			}
+			if !bytes.Equal(ciphertext, testhelpers.MustUnhex(t, vec.Ciphertext)) {
+				t.Fatalf("ciphertext mismatch (Got: %x)", ciphertext)
+			}
+			if !bytes.Equal(sharedKey, testhelpers.MustUnhex(t, vec.SharedSecret)) {
+				t.Fatalf("shared key mismatch (Got: %x)", sharedKey)
+			}
+		})
+	}
+}
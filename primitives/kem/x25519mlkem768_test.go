@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+//go:build go1.24
+
+package kem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+)
+
+// The known-answer tests were generated with the ML-KEM-768 and X25519
+// implementations from github.com/cloudflare/circl, and cross-checked
+// against its X25519MLKEM768 implementation.
+type x25519MLKEM768TestVector struct {
+	Seed                    string `json:"seed"`
+	EncapsulationKey        string `json:"encapsulation_key"`
+	EncapsulationRandomness string `json:"encapsulation_randomness"`
+	Ciphertext              string `json:"ciphertext"`
+	SharedSecret            string `json:"shared_secret"`
+}
+
+func loadX25519MLKEM768TestVectors(t *testing.T) []x25519MLKEM768TestVector {
+	f, err := os.Open("testdata/x25519mlkem768.json.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rd, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+
+	var testVectors []x25519MLKEM768TestVector
+
+	dec := json.NewDecoder(rd)
+	if err = dec.Decode(&testVectors); err != nil {
+		t.Fatal(err)
+	}
+
+	return testVectors
+}
+
+func TestX25519MLKEM768(t *testing.T) {
+	t.Run("Scheme", func(t *testing.T) {
+		testScheme(t, X25519MLKEM768())
+	})
+	t.Run("KAT", testX25519MLKEM768KAT)
+	t.Run("InvalidEncapsulationKey", func(t *testing.T) {
+		dk, err := X25519MLKEM768().GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+
+		// Set the first ML-KEM-768 coefficient to 4095, which is not
+		// reduced modulo q.
+		b := dk.EncapsulationKey().Bytes()
+		b[0] = 0xff
+		b[1] |= 0x0f
+		if _, err = X25519MLKEM768().NewEncapsulationKey(b); err == nil {
+			t.Fatalf("NewEncapsulationKey(unreduced) succeeded")
+		}
+	})
+}
+
+func testX25519MLKEM768KAT(t *testing.T) {
+	for i, vec := range loadX25519MLKEM768TestVectors(t) {
+		vec := vec
+		t.Run(fmt.Sprintf("TestCase/%d", i), func(t *testing.T) {
+			dk, err := X25519MLKEM768().NewDecapsulationKey(testhelpers.MustUnhex(t, vec.Seed))
+			if err != nil {
+				t.Fatalf("NewDecapsulationKey: %v", err)
+			}
+			if b := dk.EncapsulationKey().Bytes(); !bytes.Equal(b, testhelpers.MustUnhex(t, vec.EncapsulationKey)) {
+				t.Fatalf("encapsulation key mismatch (Got: %x)", b)
+			}
+
+			sharedKey, err := dk.Decapsulate(testhelpers.MustUnhex(t, vec.Ciphertext))
+			if err != nil {
+				t.Fatalf("Decapsulate: %v", err)
+			}
+			if !bytes.Equal(sharedKey, testhelpers.MustUnhex(t, vec.SharedSecret)) {
+				t.Fatalf("shared key mismatch (Got: %x)", sharedKey)
+			}
+		})
+	}
+}
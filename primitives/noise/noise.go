@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package noise implements the Noise Protocol Framework, with the 25519
+// DH function.  See https://noiseprotocol.org/noise.html.
+//
+// The ChaChaPoly and AESGCM cipher functions, and the SHA256 and BLAKE2s
+// hash functions are supported, along with the one-way and interactive
+// handshake patterns, and the psk modifiers.
+package noise
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// DHLen is the size, in bytes, of the 25519 DH function's public keys
+	// and outputs.
+	DHLen = x25519.PublicKeySize
+
+	// KeySize is the size, in bytes, of cipher keys.
+	KeySize = 32
+
+	// PSKSize is the size, in bytes, of pre-shared keys.
+	PSKSize = 32
+
+	// TagSize is the size, in bytes, of authentication tags.
+	TagSize = 16
+
+	// MaxMessageSize is the maximum size, in bytes, of Noise messages.
+	MaxMessageSize = 65535
+
+	dhName = "25519"
+)
+
+// CipherFunc is a Noise cipher function.
+type CipherFunc struct {
+	name        string
+	newAEAD     func(key []byte) (cipher.AEAD, error)
+	encodeNonce func(nonce []byte, n uint64)
+}
+
+// String returns the name of the cipher function.
+func (c *CipherFunc) String() string {
+	return c.name
+}
+
+// HashFunc is a Noise hash function.
+type HashFunc struct {
+	name string
+	size int
+	new  func() hash.Hash
+}
+
+// String returns the name of the hash function.
+func (h *HashFunc) String() string {
+	return h.name
+}
+
+var (
+	// CipherChaChaPoly is the ChaChaPoly cipher function.
+	CipherChaChaPoly = &CipherFunc{
+		name:    "ChaChaPoly",
+		newAEAD: chacha20poly1305.New,
+		encodeNonce: func(nonce []byte, n uint64) {
+			binary.LittleEndian.PutUint64(nonce[4:], n)
+		},
+	}
+
+	// CipherAESGCM is the AESGCM cipher function.
+	CipherAESGCM = &CipherFunc{
+		name: "AESGCM",
+		newAEAD: func(key []byte) (cipher.AEAD, error) {
+			block, err := aes.NewCipher(key)
+			if err != nil {
+				return nil, err
+			}
+			return cipher.NewGCM(block)
+		},
+		encodeNonce: func(nonce []byte, n uint64) {
+			binary.BigEndian.PutUint64(nonce[4:], n)
+		},
+	}
+
+	// HashSHA256 is the SHA256 hash function.
+	HashSHA256 = &HashFunc{
+		name: "SHA256",
+		size: sha256.Size,
+		new:  sha256.New,
+	}
+
+	// HashBLAKE2s is the BLAKE2s hash function.
+	HashBLAKE2s = &HashFunc{
+		name: "BLAKE2s",
+		size: blake2s.Size,
+		new: func() hash.Hash {
+			h, err := blake2s.New256(nil)
+			if err != nil {
+				panic("noise: failed to initialize BLAKE2s: " + err.Error())
+			}
+			return h
+		},
+	}
+)
+
+// CipherSuite is the combination of the 25519 DH function with a cipher
+// function and a hash function.
+type CipherSuite struct {
+	// Cipher is the cipher function.
+	Cipher *CipherFunc
+
+	// Hash is the hash function.
+	Hash *HashFunc
+}
+
+// String returns the name of the cipher suite, as used in Noise protocol
+// names.
+func (cs *CipherSuite) String() string {
+	return dhName + "_" + cs.Cipher.name + "_" + cs.Hash.name
+}
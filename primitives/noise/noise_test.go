@@ -0,0 +1,414 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package noise
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+func TestNoise(t *testing.T) {
+	t.Run("RoundTrip", testRoundTrip)
+	t.Run("Errors", testErrors)
+	t.Run("CipherState", testCipherState)
+	t.Run("WithPSK", testWithPSK)
+}
+
+type testPair struct {
+	initiator *HandshakeState
+	responder *HandshakeState
+
+	initiatorStatic *x25519.PrivateKey
+	responderStatic *x25519.PrivateKey
+}
+
+func newTestPair(t *testing.T, suite *CipherSuite, pattern *HandshakePattern) *testPair {
+	initiatorStatic, err := x25519.GeneratePrivateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	responderStatic, err := x25519.GeneratePrivateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	psk := make([]byte, PSKSize)
+	if _, err = rand.Read(psk); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+
+	initCfg := &Config{
+		CipherSuite:     suite,
+		Pattern:         pattern,
+		Initiator:       true,
+		Prologue:        []byte("test prologue"),
+		StaticKey:       initiatorStatic,
+		RemoteStaticKey: responderStatic.Public(),
+		PresharedKey:    psk,
+	}
+	respCfg := &Config{
+		CipherSuite:     suite,
+		Pattern:         pattern,
+		Prologue:        []byte("test prologue"),
+		StaticKey:       responderStatic,
+		RemoteStaticKey: initiatorStatic.Public(),
+		PresharedKey:    psk,
+	}
+
+	p := &testPair{
+		initiatorStatic: initiatorStatic,
+		responderStatic: responderStatic,
+	}
+	if p.initiator, err = NewHandshakeState(initCfg); err != nil {
+		t.Fatalf("NewHandshakeState(initiator): %v", err)
+	}
+	if p.responder, err = NewHandshakeState(respCfg); err != nil {
+		t.Fatalf("NewHandshakeState(responder): %v", err)
+	}
+
+	return p
+}
+
+// handshake runs the handshake to completion, and returns the
+// initiator's and responder's CipherStates.
+func (p *testPair) handshake(t *testing.T) ([2]*CipherState, [2]*CipherState) {
+	var initCs, respCs [2]*CipherState
+	for i := 0; !p.initiator.IsComplete(); i++ {
+		writer, reader := p.initiator, p.responder
+		writerCs, readerCs := &initCs, &respCs
+		if i%2 != 0 {
+			writer, reader = p.responder, p.initiator
+			writerCs, readerCs = &respCs, &initCs
+		}
+
+		payload := []byte("handshake payload")
+		msg, c1, c2, err := writer.WriteMessage(nil, payload)
+		if err != nil {
+			t.Fatalf("msg %d: WriteMessage: %v", i, err)
+		}
+		*writerCs = [2]*CipherState{c1, c2}
+
+		b, c1, c2, err := reader.ReadMessage(nil, msg)
+		if err != nil {
+			t.Fatalf("msg %d: ReadMessage: %v", i, err)
+		}
+		if !bytes.Equal(b, payload) {
+			t.Fatalf("msg %d: payload mismatch", i)
+		}
+		*readerCs = [2]*CipherState{c1, c2}
+	}
+	if !p.responder.IsComplete() {
+		t.Fatalf("responder handshake not complete")
+	}
+
+	return initCs, respCs
+}
+
+func testRoundTrip(t *testing.T) {
+	suites := []*CipherSuite{
+		{CipherChaChaPoly, HashSHA256},
+		{CipherChaChaPoly, HashBLAKE2s},
+		{CipherAESGCM, HashSHA256},
+		{CipherAESGCM, HashBLAKE2s},
+	}
+
+	for _, suite := range suites {
+		for _, basePattern := range testPatterns {
+			psk0, err := basePattern.WithPSK(0)
+			if err != nil {
+				t.Fatalf("WithPSK(0): %v", err)
+			}
+			pskN, err := basePattern.WithPSK(len(basePattern.Messages))
+			if err != nil {
+				t.Fatalf("WithPSK(%d): %v", len(basePattern.Messages), err)
+			}
+
+			for _, pattern := range []*HandshakePattern{basePattern, psk0, pskN} {
+				pattern, suite := pattern, suite
+				t.Run(pattern.Name+"_"+suite.String(), func(t *testing.T) {
+					testRoundTripPattern(t, suite, pattern)
+				})
+			}
+		}
+	}
+}
+
+func testRoundTripPattern(t *testing.T, suite *CipherSuite, pattern *HandshakePattern) {
+	p := newTestPair(t, suite, pattern)
+	initCs, respCs := p.handshake(t)
+
+	if !bytes.Equal(p.initiator.HandshakeHash(), p.responder.HandshakeHash()) {
+		t.Fatalf("handshake hash mismatch")
+	}
+	if l := len(p.initiator.HandshakeHash()); l != suite.Hash.size {
+		t.Fatalf("unexpected handshake hash length: %d", l)
+	}
+
+	// Both parties must know the other's static key, if the pattern
+	// involves one.
+	if pattern.needsLocalStatic(false) {
+		if rs := p.initiator.RemoteStaticKey(); rs == nil || *rs != *p.responderStatic.Public() {
+			t.Fatalf("initiator has incorrect remote static key")
+		}
+	}
+	if pattern.needsLocalStatic(true) {
+		if rs := p.responder.RemoteStaticKey(); rs == nil || *rs != *p.initiatorStatic.Public() {
+			t.Fatalf("responder has incorrect remote static key")
+		}
+	}
+
+	msg := []byte("transport message")
+	ad := []byte("additional data")
+
+	directions := []struct {
+		name     string
+		enc, dec *CipherState
+	}{
+		{"InitiatorToResponder", initCs[0], respCs[0]},
+		{"ResponderToInitiator", initCs[1], respCs[1]},
+	}
+	if pattern.IsOneWay() {
+		directions = directions[:1]
+	}
+	for _, d := range directions {
+		for i := 0; i < 3; i++ {
+			ct, err := d.enc.Encrypt(nil, ad, msg)
+			if err != nil {
+				t.Fatalf("%s: Encrypt: %v", d.name, err)
+			}
+			if len(ct) != len(msg)+TagSize {
+				t.Fatalf("%s: unexpected ciphertext length: %d", d.name, len(ct))
+			}
+			pt, err := d.dec.Decrypt(nil, ad, ct)
+			if err != nil {
+				t.Fatalf("%s: Decrypt: %v", d.name, err)
+			}
+			if !bytes.Equal(pt, msg) {
+				t.Fatalf("%s: plaintext mismatch", d.name)
+			}
+		}
+	}
+}
+
+func testErrors(t *testing.T) {
+	suite := &CipherSuite{CipherChaChaPoly, HashSHA256}
+
+	t.Run("Turn", func(t *testing.T) {
+		p := newTestPair(t, suite, PatternXX)
+
+		if _, _, _, err := p.initiator.ReadMessage(nil, nil); err == nil {
+			t.Fatalf("initiator ReadMessage before WriteMessage succeeded")
+		}
+		if _, _, _, err := p.responder.WriteMessage(nil, nil); err == nil {
+			t.Fatalf("responder WriteMessage before ReadMessage succeeded")
+		}
+
+		_, _ = p.handshake(t)
+		if _, _, _, err := p.initiator.WriteMessage(nil, nil); err == nil {
+			t.Fatalf("WriteMessage after completion succeeded")
+		}
+		if _, _, _, err := p.responder.ReadMessage(nil, nil); err == nil {
+			t.Fatalf("ReadMessage after completion succeeded")
+		}
+	})
+	t.Run("Tampered", func(t *testing.T) {
+		p := newTestPair(t, suite, PatternXX)
+
+		msg, _, _, err := p.initiator.WriteMessage(nil, nil)
+		if err != nil {
+			t.Fatalf("WriteMessage: %v", err)
+		}
+		if _, _, _, err = p.responder.ReadMessage(nil, msg); err != nil {
+			t.Fatalf("ReadMessage: %v", err)
+		}
+		if msg, _, _, err = p.responder.WriteMessage(nil, []byte("payload")); err != nil {
+			t.Fatalf("WriteMessage: %v", err)
+		}
+		msg[len(msg)-1] ^= 0x01
+		if _, _, _, err = p.initiator.ReadMessage(nil, msg); err == nil {
+			t.Fatalf("ReadMessage(tampered) succeeded")
+		}
+
+		// Failures are fatal to the handshake.
+		msg[len(msg)-1] ^= 0x01
+		if _, _, _, err = p.initiator.ReadMessage(nil, msg); err == nil {
+			t.Fatalf("ReadMessage after failure succeeded")
+		}
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		p := newTestPair(t, suite, PatternNN)
+
+		if _, _, _, err := p.responder.ReadMessage(nil, make([]byte, DHLen-1)); err == nil {
+			t.Fatalf("ReadMessage(truncated) succeeded")
+		}
+	})
+	t.Run("PSKMismatch", func(t *testing.T) {
+		pattern, err := PatternNN.WithPSK(0)
+		if err != nil {
+			t.Fatalf("WithPSK: %v", err)
+		}
+		p := newTestPair(t, suite, pattern)
+		p.responder.psk[0] ^= 0x01
+
+		msg, _, _, err := p.initiator.WriteMessage(nil, nil)
+		if err != nil {
+			t.Fatalf("WriteMessage: %v", err)
+		}
+		if _, _, _, err = p.responder.ReadMessage(nil, msg); err == nil {
+			t.Fatalf("ReadMessage(bad psk) succeeded")
+		}
+	})
+	t.Run("Config", func(t *testing.T) {
+		pskPattern, err := PatternNN.WithPSK(0)
+		if err != nil {
+			t.Fatalf("WithPSK: %v", err)
+		}
+
+		for _, v := range []struct {
+			name string
+			cfg  *Config
+		}{
+			{"NoSuite", &Config{Pattern: PatternNN}},
+			{"NoPattern", &Config{CipherSuite: suite}},
+			{"NoStaticKey", &Config{CipherSuite: suite, Pattern: PatternXX, Initiator: true}},
+			{"NoRemoteStaticKey", &Config{CipherSuite: suite, Pattern: PatternNK, Initiator: true}},
+			{"BadPSK", &Config{CipherSuite: suite, Pattern: pskPattern, PresharedKey: make([]byte, 16)}},
+		} {
+			if _, err := NewHandshakeState(v.cfg); err == nil {
+				t.Fatalf("%s: NewHandshakeState succeeded", v.name)
+			}
+		}
+	})
+}
+
+func testCipherState(t *testing.T) {
+	suite := &CipherSuite{CipherAESGCM, HashBLAKE2s}
+	p := newTestPair(t, suite, PatternNN)
+	initCs, respCs := p.handshake(t)
+	enc, dec := initCs[0], respCs[0]
+
+	if !enc.HasKey() || !dec.HasKey() {
+		t.Fatalf("CipherState has no key")
+	}
+
+	// Rekey
+	msg := []byte("rekeyed message")
+	if err := enc.Rekey(); err != nil {
+		t.Fatalf("Rekey: %v", err)
+	}
+	ct, err := enc.Encrypt(nil, nil, msg)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err = dec.Decrypt(nil, nil, ct); err == nil {
+		t.Fatalf("Decrypt with stale key succeeded")
+	}
+	if err = dec.Rekey(); err != nil {
+		t.Fatalf("Rekey: %v", err)
+	}
+	if pt, err := dec.Decrypt(nil, nil, ct); err != nil || !bytes.Equal(pt, msg) {
+		t.Fatalf("Decrypt after Rekey: %v", err)
+	}
+
+	// Out of order nonces
+	enc.SetNonce(42)
+	if ct, err = enc.Encrypt(nil, nil, msg); err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err = dec.Decrypt(nil, nil, ct); err == nil {
+		t.Fatalf("Decrypt with wrong nonce succeeded")
+	}
+	dec.SetNonce(42)
+	if _, err = dec.Decrypt(nil, nil, ct); err != nil {
+		t.Fatalf("Decrypt after SetNonce: %v", err)
+	}
+	if n := dec.Nonce(); n != 43 {
+		t.Fatalf("unexpected nonce: %d", n)
+	}
+
+	// Nonce exhaustion
+	enc.SetNonce(math.MaxUint64 - 1)
+	if _, err = enc.Encrypt(nil, nil, msg); err != nil {
+		t.Fatalf("Encrypt(MaxUint64 - 1): %v", err)
+	}
+	if _, err = enc.Encrypt(nil, nil, msg); !errors.Is(err, ErrNonceExhausted) {
+		t.Fatalf("Encrypt(MaxUint64): %v", err)
+	}
+	dec.SetNonce(math.MaxUint64)
+	if _, err = dec.Decrypt(nil, nil, ct); !errors.Is(err, ErrNonceExhausted) {
+		t.Fatalf("Decrypt(MaxUint64): %v", err)
+	}
+
+	// No key
+	var cs CipherState
+	if ct, err = cs.Encrypt(nil, nil, msg); err != nil || !bytes.Equal(ct, msg) {
+		t.Fatalf("Encrypt(no key): %v", err)
+	}
+	if err = cs.Rekey(); err == nil {
+		t.Fatalf("Rekey(no key) succeeded")
+	}
+}
+
+func testWithPSK(t *testing.T) {
+	pattern, err := PatternXX.WithPSK(0, 3)
+	if err != nil {
+		t.Fatalf("WithPSK: %v", err)
+	}
+	if pattern.Name != "XXpsk0+psk3" {
+		t.Fatalf("unexpected name: %s", pattern.Name)
+	}
+	if pattern.Messages[0][0] != TokenPSK || pattern.Messages[2][len(pattern.Messages[2])-1] != TokenPSK {
+		t.Fatalf("psk tokens not placed correctly: %v", pattern.Messages)
+	}
+	if PatternXX.hasPSK() {
+		t.Fatalf("WithPSK modified the base pattern")
+	}
+
+	if pattern, err = pattern.WithPSK(1); err != nil {
+		t.Fatalf("WithPSK: %v", err)
+	}
+	if pattern.Name != "XXpsk0+psk3+psk1" {
+		t.Fatalf("unexpected name: %s", pattern.Name)
+	}
+
+	for _, v := range [][]int{
+		nil,
+		{-1},
+		{4},
+	} {
+		if _, err = PatternXX.WithPSK(v...); err == nil {
+			t.Fatalf("WithPSK(%v) succeeded", v)
+		}
+	}
+}
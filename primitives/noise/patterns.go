@@ -0,0 +1,356 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package noise
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Token is a handshake pattern token.
+type Token uint8
+
+const (
+	// TokenE is the "e" token.
+	TokenE Token = iota
+	// TokenS is the "s" token.
+	TokenS
+	// TokenEE is the "ee" token.
+	TokenEE
+	// TokenES is the "es" token.
+	TokenES
+	// TokenSE is the "se" token.
+	TokenSE
+	// TokenSS is the "ss" token.
+	TokenSS
+	// TokenPSK is the "psk" token.
+	TokenPSK
+)
+
+// String returns the string representation of a Token.
+func (t Token) String() string {
+	switch t {
+	case TokenE:
+		return "e"
+	case TokenS:
+		return "s"
+	case TokenEE:
+		return "ee"
+	case TokenES:
+		return "es"
+	case TokenSE:
+		return "se"
+	case TokenSS:
+		return "ss"
+	case TokenPSK:
+		return "psk"
+	default:
+		return fmt.Sprintf("[unknown token: %d]", uint8(t))
+	}
+}
+
+// HandshakePattern is a Noise handshake pattern.
+type HandshakePattern struct {
+	// Name is the name of the pattern, including any modifiers.
+	Name string
+
+	// InitiatorPreMessage is the initiator's pre-message, which may only
+	// contain the "s" token.
+	InitiatorPreMessage []Token
+
+	// ResponderPreMessage is the responder's pre-message, which may only
+	// contain the "s" token.
+	ResponderPreMessage []Token
+
+	// Messages are the message patterns, starting with a message from
+	// the initiator, and alternating between the parties.
+	Messages [][]Token
+}
+
+// WithPSK returns a copy of the pattern with the pskN modifiers applied,
+// in order.  psk0 places a "psk" token at the beginning of the first
+// message, and pskN for N > 0 places a "psk" token at the end of the
+// N-th message.
+func (p *HandshakePattern) WithPSK(modifiers ...int) (*HandshakePattern, error) {
+	if len(modifiers) == 0 {
+		return nil, fmt.Errorf("noise: no psk modifiers")
+	}
+
+	np := &HandshakePattern{
+		InitiatorPreMessage: append([]Token{}, p.InitiatorPreMessage...),
+		ResponderPreMessage: append([]Token{}, p.ResponderPreMessage...),
+		Messages:            make([][]Token, 0, len(p.Messages)),
+	}
+	for _, msg := range p.Messages {
+		np.Messages = append(np.Messages, append([]Token{}, msg...))
+	}
+
+	var names []string
+	for _, n := range modifiers {
+		switch {
+		case n == 0:
+			np.Messages[0] = append([]Token{TokenPSK}, np.Messages[0]...)
+		case n > 0 && n <= len(np.Messages):
+			np.Messages[n-1] = append(np.Messages[n-1], TokenPSK)
+		default:
+			return nil, fmt.Errorf("noise: invalid psk modifier: psk%d", n)
+		}
+		names = append(names, fmt.Sprintf("psk%d", n))
+	}
+
+	sep := ""
+	if p.hasPSK() {
+		sep = "+"
+	}
+	np.Name = p.Name + sep + strings.Join(names, "+")
+
+	return np, nil
+}
+
+// IsOneWay returns true iff the pattern is a one-way pattern.
+func (p *HandshakePattern) IsOneWay() bool {
+	return len(p.Messages) == 1
+}
+
+func (p *HandshakePattern) hasPSK() bool {
+	for _, msg := range p.Messages {
+		for _, t := range msg {
+			if t == TokenPSK {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *HandshakePattern) validate() error {
+	for _, preMsg := range [][]Token{p.InitiatorPreMessage, p.ResponderPreMessage} {
+		for _, t := range preMsg {
+			if t != TokenS {
+				return fmt.Errorf("noise: unsupported pre-message token: %v", t)
+			}
+		}
+	}
+	if len(p.Messages) == 0 {
+		return fmt.Errorf("noise: no handshake messages")
+	}
+	for _, msg := range p.Messages {
+		for _, t := range msg {
+			if t > TokenPSK {
+				return fmt.Errorf("noise: invalid token: %v", t)
+			}
+		}
+	}
+	return nil
+}
+
+// needsLocalStatic returns true iff the party requires a static key.
+func (p *HandshakePattern) needsLocalStatic(initiator bool) bool {
+	preMsg := p.ResponderPreMessage
+	if initiator {
+		preMsg = p.InitiatorPreMessage
+	}
+	if len(preMsg) > 0 {
+		return true
+	}
+	return p.sendsToken(initiator, TokenS)
+}
+
+// needsRemoteStatic returns true iff the party requires the peer's static
+// key in advance.
+func (p *HandshakePattern) needsRemoteStatic(initiator bool) bool {
+	preMsg := p.InitiatorPreMessage
+	if initiator {
+		preMsg = p.ResponderPreMessage
+	}
+	return len(preMsg) > 0
+}
+
+func (p *HandshakePattern) sendsToken(initiator bool, token Token) bool {
+	for i, msg := range p.Messages {
+		if (i%2 == 0) != initiator {
+			continue
+		}
+		for _, t := range msg {
+			if t == token {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	// PatternN is the N one-way pattern.
+	PatternN = &HandshakePattern{
+		Name:                "N",
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES},
+		},
+	}
+
+	// PatternK is the K one-way pattern.
+	PatternK = &HandshakePattern{
+		Name:                "K",
+		InitiatorPreMessage: []Token{TokenS},
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES, TokenSS},
+		},
+	}
+
+	// PatternX is the X one-way pattern.
+	PatternX = &HandshakePattern{
+		Name:                "X",
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES, TokenS, TokenSS},
+		},
+	}
+
+	// PatternNN is the NN interactive pattern.
+	PatternNN = &HandshakePattern{
+		Name: "NN",
+		Messages: [][]Token{
+			{TokenE},
+			{TokenE, TokenEE},
+		},
+	}
+
+	// PatternNK is the NK interactive pattern.
+	PatternNK = &HandshakePattern{
+		Name:                "NK",
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES},
+			{TokenE, TokenEE},
+		},
+	}
+
+	// PatternNX is the NX interactive pattern.
+	PatternNX = &HandshakePattern{
+		Name: "NX",
+		Messages: [][]Token{
+			{TokenE},
+			{TokenE, TokenEE, TokenS, TokenES},
+		},
+	}
+
+	// PatternKN is the KN interactive pattern.
+	PatternKN = &HandshakePattern{
+		Name:                "KN",
+		InitiatorPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE},
+			{TokenE, TokenEE, TokenSE},
+		},
+	}
+
+	// PatternKK is the KK interactive pattern.
+	PatternKK = &HandshakePattern{
+		Name:                "KK",
+		InitiatorPreMessage: []Token{TokenS},
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES, TokenSS},
+			{TokenE, TokenEE, TokenSE},
+		},
+	}
+
+	// PatternKX is the KX interactive pattern.
+	PatternKX = &HandshakePattern{
+		Name:                "KX",
+		InitiatorPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE},
+			{TokenE, TokenEE, TokenSE, TokenS, TokenES},
+		},
+	}
+
+	// PatternXN is the XN interactive pattern.
+	PatternXN = &HandshakePattern{
+		Name: "XN",
+		Messages: [][]Token{
+			{TokenE},
+			{TokenE, TokenEE},
+			{TokenS, TokenSE},
+		},
+	}
+
+	// PatternXK is the XK interactive pattern.
+	PatternXK = &HandshakePattern{
+		Name:                "XK",
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES},
+			{TokenE, TokenEE},
+			{TokenS, TokenSE},
+		},
+	}
+
+	// PatternXX is the XX interactive pattern.
+	PatternXX = &HandshakePattern{
+		Name: "XX",
+		Messages: [][]Token{
+			{TokenE},
+			{TokenE, TokenEE, TokenS, TokenES},
+			{TokenS, TokenSE},
+		},
+	}
+
+	// PatternIN is the IN interactive pattern.
+	PatternIN = &HandshakePattern{
+		Name: "IN",
+		Messages: [][]Token{
+			{TokenE, TokenS},
+			{TokenE, TokenEE, TokenSE},
+		},
+	}
+
+	// PatternIK is the IK interactive pattern.
+	PatternIK = &HandshakePattern{
+		Name:                "IK",
+		ResponderPreMessage: []Token{TokenS},
+		Messages: [][]Token{
+			{TokenE, TokenES, TokenS, TokenSS},
+			{TokenE, TokenEE, TokenSE},
+		},
+	}
+
+	// PatternIX is the IX interactive pattern.
+	PatternIX = &HandshakePattern{
+		Name: "IX",
+		Messages: [][]Token{
+			{TokenE, TokenS},
+			{TokenE, TokenEE, TokenSE, TokenS, TokenES},
+		},
+	}
+)
@@ -0,0 +1,577 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package noise
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+var (
+	// ErrNonceExhausted is the error returned when a CipherState's nonce
+	// has reached the maximum value.
+	ErrNonceExhausted = errors.New("noise: nonce exhausted")
+
+	// ErrMessageSize is the error returned when a message exceeds
+	// MaxMessageSize.
+	ErrMessageSize = errors.New("noise: message too large")
+
+	errDecryptionFailed = errors.New("noise: decryption failed")
+)
+
+// CipherState is a Noise CipherState, which encrypts and decrypts data
+// with a key and a nonce.
+type CipherState struct {
+	cipherFn *CipherFunc
+	aead     cipher.AEAD
+	k        [KeySize]byte
+	n        uint64
+}
+
+// HasKey returns true iff the CipherState has a key.
+func (cs *CipherState) HasKey() bool {
+	return cs.aead != nil
+}
+
+// SetNonce sets the CipherState's nonce.
+func (cs *CipherState) SetNonce(n uint64) {
+	cs.n = n
+}
+
+// Nonce returns the CipherState's nonce.
+func (cs *CipherState) Nonce() uint64 {
+	return cs.n
+}
+
+// Encrypt encrypts and authenticates plaintext and authenticates ad,
+// appends the result to out, and returns the updated slice.  If the
+// CipherState does not have a key, the plaintext is appended as is.
+func (cs *CipherState) Encrypt(out, ad, plaintext []byte) ([]byte, error) {
+	if !cs.HasKey() {
+		return append(out, plaintext...), nil
+	}
+	if cs.n == math.MaxUint64 {
+		return nil, ErrNonceExhausted
+	}
+
+	out = cs.aead.Seal(out, cs.nonce(cs.n), plaintext, ad)
+	cs.n++
+
+	return out, nil
+}
+
+// Decrypt authenticates and decrypts ciphertext and authenticates ad,
+// appends the result to out, and returns the updated slice.  If the
+// CipherState does not have a key, the ciphertext is appended as is.
+func (cs *CipherState) Decrypt(out, ad, ciphertext []byte) ([]byte, error) {
+	if !cs.HasKey() {
+		return append(out, ciphertext...), nil
+	}
+	if cs.n == math.MaxUint64 {
+		return nil, ErrNonceExhausted
+	}
+
+	out, err := cs.aead.Open(out, cs.nonce(cs.n), ciphertext, ad)
+	if err != nil {
+		return nil, errDecryptionFailed
+	}
+	cs.n++
+
+	return out, nil
+}
+
+// Rekey updates the CipherState's key to a pseudorandom function of the
+// current key.
+func (cs *CipherState) Rekey() error {
+	if !cs.HasKey() {
+		return fmt.Errorf("noise: no key to rekey")
+	}
+
+	var zeros [KeySize]byte
+	k := cs.aead.Seal(nil, cs.nonce(math.MaxUint64), zeros[:], nil)
+
+	return cs.initializeKey(k[:KeySize])
+}
+
+func (cs *CipherState) initializeKey(k []byte) error {
+	copy(cs.k[:], k)
+	cs.n = 0
+
+	aead, err := cs.cipherFn.newAEAD(cs.k[:])
+	if err != nil {
+		return fmt.Errorf("noise: failed to initialize cipher: %w", err)
+	}
+	cs.aead = aead
+
+	return nil
+}
+
+func (cs *CipherState) nonce(n uint64) []byte {
+	var nonce [12]byte
+	cs.cipherFn.encodeNonce(nonce[:], n)
+	return nonce[:]
+}
+
+// symmetricState is a Noise SymmetricState.
+type symmetricState struct {
+	suite *CipherSuite
+	cs    CipherState
+	ck    []byte
+	h     []byte
+}
+
+func (ss *symmetricState) initialize(suite *CipherSuite, protocolName []byte) {
+	hashLen := suite.Hash.size
+
+	ss.suite = suite
+	ss.cs = CipherState{
+		cipherFn: suite.Cipher,
+	}
+	if len(protocolName) <= hashLen {
+		ss.h = make([]byte, hashLen)
+		copy(ss.h, protocolName)
+	} else {
+		h := suite.Hash.new()
+		_, _ = h.Write(protocolName)
+		ss.h = h.Sum(nil)
+	}
+	ss.ck = append([]byte{}, ss.h...)
+}
+
+func (ss *symmetricState) mixKey(ikm []byte) error {
+	outputs := ss.hkdf(ikm, 2)
+	ss.ck = outputs[0]
+	return ss.cs.initializeKey(outputs[1][:KeySize])
+}
+
+func (ss *symmetricState) mixHash(data []byte) {
+	h := ss.suite.Hash.new()
+	_, _ = h.Write(ss.h)
+	_, _ = h.Write(data)
+	ss.h = h.Sum(ss.h[:0])
+}
+
+func (ss *symmetricState) mixKeyAndHash(ikm []byte) error {
+	outputs := ss.hkdf(ikm, 3)
+	ss.ck = outputs[0]
+	ss.mixHash(outputs[1])
+	return ss.cs.initializeKey(outputs[2][:KeySize])
+}
+
+func (ss *symmetricState) encryptAndHash(out, plaintext []byte) ([]byte, error) {
+	off := len(out)
+	out, err := ss.cs.Encrypt(out, ss.h, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	ss.mixHash(out[off:])
+	return out, nil
+}
+
+func (ss *symmetricState) decryptAndHash(out, ciphertext []byte) ([]byte, error) {
+	out, err := ss.cs.Decrypt(out, ss.h, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	ss.mixHash(ciphertext)
+	return out, nil
+}
+
+func (ss *symmetricState) split() (*CipherState, *CipherState, error) {
+	outputs := ss.hkdf(nil, 2)
+
+	c1 := &CipherState{
+		cipherFn: ss.suite.Cipher,
+	}
+	if err := c1.initializeKey(outputs[0][:KeySize]); err != nil {
+		return nil, nil, err
+	}
+	c2 := &CipherState{
+		cipherFn: ss.suite.Cipher,
+	}
+	if err := c2.initializeKey(outputs[1][:KeySize]); err != nil {
+		return nil, nil, err
+	}
+
+	return c1, c2, nil
+}
+
+// hkdf is the Noise HKDF function, which returns numOutputs outputs
+// derived from the chaining key and ikm.
+func (ss *symmetricState) hkdf(ikm []byte, numOutputs int) [][]byte {
+	mac := hmac.New(ss.suite.Hash.new, ss.ck)
+	_, _ = mac.Write(ikm)
+	tempKey := mac.Sum(nil)
+
+	mac = hmac.New(ss.suite.Hash.new, tempKey)
+	outputs := make([][]byte, 0, numOutputs)
+	var prev []byte
+	for i := 1; i <= numOutputs; i++ {
+		mac.Reset()
+		_, _ = mac.Write(prev)
+		_, _ = mac.Write([]byte{byte(i)})
+		prev = mac.Sum(nil)
+		outputs = append(outputs, prev)
+	}
+
+	return outputs
+}
+
+// Config is the configuration of a HandshakeState.
+type Config struct {
+	// CipherSuite is the cipher suite.
+	CipherSuite *CipherSuite
+
+	// Pattern is the handshake pattern.
+	Pattern *HandshakePattern
+
+	// Initiator is true iff the party is the initiator.
+	Initiator bool
+
+	// Prologue is the optional prologue.
+	Prologue []byte
+
+	// StaticKey is the party's static private key (s), if required by
+	// the pattern.
+	StaticKey *x25519.PrivateKey
+
+	// RemoteStaticKey is the peer's static public key (rs), if required
+	// in advance by the pattern.
+	RemoteStaticKey *x25519.PublicKey
+
+	// PresharedKey is the pre-shared key, if required by the pattern.
+	PresharedKey []byte
+
+	// Rand is the entropy source used to generate ephemeral keys.  If
+	// nil, crypto/rand.Reader will be used.  The private keys are read
+	// directly from Rand, without any post-processing.
+	Rand io.Reader
+}
+
+// HandshakeState is a Noise HandshakeState.
+type HandshakeState struct {
+	ss symmetricState
+
+	messages  [][]Token
+	initiator bool
+	isPSK     bool
+	rand      io.Reader
+	psk       []byte
+
+	s  *x25519.PrivateKey
+	sp *x25519.PublicKey
+	e  *x25519.PrivateKey
+	ep *x25519.PublicKey
+	rs *x25519.PublicKey
+	re *x25519.PublicKey
+
+	msgIdx int
+	err    error
+}
+
+// NewHandshakeState creates a new HandshakeState.
+func NewHandshakeState(cfg *Config) (*HandshakeState, error) {
+	if cfg.CipherSuite == nil || cfg.CipherSuite.Cipher == nil || cfg.CipherSuite.Hash == nil {
+		return nil, fmt.Errorf("noise: invalid cipher suite")
+	}
+	if cfg.Pattern == nil {
+		return nil, fmt.Errorf("noise: no handshake pattern")
+	}
+	if err := cfg.Pattern.validate(); err != nil {
+		return nil, err
+	}
+
+	hs := &HandshakeState{
+		messages:  cfg.Pattern.Messages,
+		initiator: cfg.Initiator,
+		isPSK:     cfg.Pattern.hasPSK(),
+		rand:      cfg.Rand,
+	}
+	if hs.rand == nil {
+		hs.rand = cryptorand.Reader
+	}
+
+	if cfg.Pattern.needsLocalStatic(cfg.Initiator) {
+		if cfg.StaticKey == nil {
+			return nil, fmt.Errorf("noise: pattern requires a static key")
+		}
+		sk := *cfg.StaticKey
+		hs.s = &sk
+		hs.sp = sk.Public()
+	}
+	if cfg.Pattern.needsRemoteStatic(cfg.Initiator) {
+		if cfg.RemoteStaticKey == nil {
+			return nil, fmt.Errorf("noise: pattern requires the remote static key")
+		}
+		rs := *cfg.RemoteStaticKey
+		hs.rs = &rs
+	}
+	if hs.isPSK {
+		if l := len(cfg.PresharedKey); l != PSKSize {
+			return nil, fmt.Errorf("noise: invalid pre-shared key length: %d", l)
+		}
+		hs.psk = append([]byte{}, cfg.PresharedKey...)
+	}
+
+	protocolName := "Noise_" + cfg.Pattern.Name + "_" + cfg.CipherSuite.String()
+	hs.ss.initialize(cfg.CipherSuite, []byte(protocolName))
+	hs.ss.mixHash(cfg.Prologue)
+
+	// The pre-messages may only contain "s", which is enforced by
+	// HandshakePattern.validate.
+	if len(cfg.Pattern.InitiatorPreMessage) > 0 {
+		if hs.initiator {
+			hs.ss.mixHash(hs.sp[:])
+		} else {
+			hs.ss.mixHash(hs.rs[:])
+		}
+	}
+	if len(cfg.Pattern.ResponderPreMessage) > 0 {
+		if hs.initiator {
+			hs.ss.mixHash(hs.rs[:])
+		} else {
+			hs.ss.mixHash(hs.sp[:])
+		}
+	}
+
+	return hs, nil
+}
+
+// WriteMessage writes the next handshake message with the payload, appends
+// it to out, and returns the updated slice.  When the handshake is complete,
+// it also returns the CipherStates for initiator to responder, and for
+// responder to initiator traffic respectively.
+func (hs *HandshakeState) WriteMessage(out, payload []byte) ([]byte, *CipherState, *CipherState, error) {
+	if err := hs.checkTurn(true); err != nil {
+		return nil, nil, nil, err
+	}
+
+	off := len(out)
+	var err error
+	for _, t := range hs.messages[hs.msgIdx] {
+		switch t {
+		case TokenE:
+			if hs.e, err = generateEphemeral(hs.rand); err != nil {
+				return nil, nil, nil, hs.fail(err)
+			}
+			hs.ep = hs.e.Public()
+			out = append(out, hs.ep[:]...)
+			hs.ss.mixHash(hs.ep[:])
+			if hs.isPSK {
+				err = hs.ss.mixKey(hs.ep[:])
+			}
+		case TokenS:
+			out, err = hs.ss.encryptAndHash(out, hs.sp[:])
+		case TokenPSK:
+			err = hs.ss.mixKeyAndHash(hs.psk)
+		default:
+			err = hs.mixDH(t)
+		}
+		if err != nil {
+			return nil, nil, nil, hs.fail(err)
+		}
+	}
+
+	if out, err = hs.ss.encryptAndHash(out, payload); err != nil {
+		return nil, nil, nil, hs.fail(err)
+	}
+	if len(out)-off > MaxMessageSize {
+		return nil, nil, nil, hs.fail(ErrMessageSize)
+	}
+
+	return hs.advance(out)
+}
+
+// ReadMessage reads the next handshake message, appends the payload to out,
+// and returns the updated slice.  When the handshake is complete, it also
+// returns the CipherStates for initiator to responder, and for responder
+// to initiator traffic respectively.
+//
+// Any failure is fatal to the handshake.
+func (hs *HandshakeState) ReadMessage(out, message []byte) ([]byte, *CipherState, *CipherState, error) {
+	if err := hs.checkTurn(false); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(message) > MaxMessageSize {
+		return nil, nil, nil, hs.fail(ErrMessageSize)
+	}
+
+	var err error
+	for _, t := range hs.messages[hs.msgIdx] {
+		switch t {
+		case TokenE:
+			if len(message) < DHLen {
+				return nil, nil, nil, hs.fail(fmt.Errorf("noise: truncated message"))
+			}
+			var re x25519.PublicKey
+			copy(re[:], message[:DHLen])
+			message = message[DHLen:]
+			hs.re = &re
+			hs.ss.mixHash(re[:])
+			if hs.isPSK {
+				err = hs.ss.mixKey(re[:])
+			}
+		case TokenS:
+			sLen := DHLen
+			if hs.ss.cs.HasKey() {
+				sLen += TagSize
+			}
+			if len(message) < sLen {
+				return nil, nil, nil, hs.fail(fmt.Errorf("noise: truncated message"))
+			}
+			var rs []byte
+			if rs, err = hs.ss.decryptAndHash(nil, message[:sLen]); err == nil {
+				hs.rs = new(x25519.PublicKey)
+				copy(hs.rs[:], rs)
+			}
+			message = message[sLen:]
+		case TokenPSK:
+			err = hs.ss.mixKeyAndHash(hs.psk)
+		default:
+			err = hs.mixDH(t)
+		}
+		if err != nil {
+			return nil, nil, nil, hs.fail(err)
+		}
+	}
+
+	if out, err = hs.ss.decryptAndHash(out, message); err != nil {
+		return nil, nil, nil, hs.fail(err)
+	}
+
+	return hs.advance(out)
+}
+
+// HandshakeHash returns the handshake hash (h), which may be used for
+// channel binding once the handshake is complete.
+func (hs *HandshakeState) HandshakeHash() []byte {
+	return append([]byte{}, hs.ss.h...)
+}
+
+// RemoteStaticKey returns the peer's static public key (rs), if known.
+func (hs *HandshakeState) RemoteStaticKey() *x25519.PublicKey {
+	if hs.rs == nil {
+		return nil
+	}
+	rs := *hs.rs
+	return &rs
+}
+
+// IsComplete returns true iff the handshake is complete.
+func (hs *HandshakeState) IsComplete() bool {
+	return hs.msgIdx >= len(hs.messages)
+}
+
+func (hs *HandshakeState) checkTurn(write bool) error {
+	switch {
+	case hs.err != nil:
+		return hs.err
+	case hs.IsComplete():
+		return fmt.Errorf("noise: handshake is complete")
+	}
+
+	initiatorTurn := hs.msgIdx%2 == 0
+	if (initiatorTurn == hs.initiator) != write {
+		if write {
+			return fmt.Errorf("noise: not our turn to write")
+		}
+		return fmt.Errorf("noise: not our turn to read")
+	}
+
+	return nil
+}
+
+func (hs *HandshakeState) advance(out []byte) ([]byte, *CipherState, *CipherState, error) {
+	hs.msgIdx++
+	if !hs.IsComplete() {
+		return out, nil, nil, nil
+	}
+
+	c1, c2, err := hs.ss.split()
+	if err != nil {
+		return nil, nil, nil, hs.fail(err)
+	}
+
+	return out, c1, c2, nil
+}
+
+func (hs *HandshakeState) fail(err error) error {
+	hs.err = fmt.Errorf("noise: handshake failed: %w", err)
+	return err
+}
+
+func (hs *HandshakeState) mixDH(t Token) error {
+	// The initiator's keys are the first component of the token, and the
+	// responder's keys are the second component.
+	var (
+		priv *x25519.PrivateKey
+		pub  *x25519.PublicKey
+	)
+	switch t {
+	case TokenEE:
+		priv, pub = hs.e, hs.re
+	case TokenES:
+		if hs.initiator {
+			priv, pub = hs.e, hs.rs
+		} else {
+			priv, pub = hs.s, hs.re
+		}
+	case TokenSE:
+		if hs.initiator {
+			priv, pub = hs.s, hs.re
+		} else {
+			priv, pub = hs.e, hs.rs
+		}
+	case TokenSS:
+		priv, pub = hs.s, hs.rs
+	default:
+		return fmt.Errorf("noise: invalid token: %v", t)
+	}
+	if priv == nil || pub == nil {
+		return fmt.Errorf("noise: missing key for token: %v", t)
+	}
+
+	return hs.ss.mixKey(priv.DiffieHellman(pub)[:])
+}
+
+func generateEphemeral(rand io.Reader) (*x25519.PrivateKey, error) {
+	var e x25519.PrivateKey
+	if _, err := io.ReadFull(rand, e[:]); err != nil {
+		return nil, fmt.Errorf("noise: failed to generate ephemeral key: %w", err)
+	}
+	return &e, nil
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+var (
+	testPatterns = map[string]*HandshakePattern{
+		"N":  PatternN,
+		"K":  PatternK,
+		"X":  PatternX,
+		"NN": PatternNN,
+		"NK": PatternNK,
+		"NX": PatternNX,
+		"KN": PatternKN,
+		"KK": PatternKK,
+		"KX": PatternKX,
+		"XN": PatternXN,
+		"XK": PatternXK,
+		"XX": PatternXX,
+		"IN": PatternIN,
+		"IK": PatternIK,
+		"IX": PatternIX,
+	}
+	testCiphers = map[string]*CipherFunc{
+		"ChaChaPoly": CipherChaChaPoly,
+		"AESGCM":     CipherAESGCM,
+	}
+	testHashes = map[string]*HashFunc{
+		"SHA256":  HashSHA256,
+		"BLAKE2s": HashBLAKE2s,
+	}
+)
+
+type testVector struct {
+	name string
+
+	pattern *HandshakePattern
+	suite   *CipherSuite
+
+	initEphemeral []byte
+	respEphemeral []byte
+	initStatic    *x25519.PrivateKey
+	respStatic    *x25519.PrivateKey
+	psk           []byte
+	prologue      []byte
+
+	payloads      [][]byte
+	ciphertexts   [][]byte
+	handshakeHash []byte
+}
+
+func (v *testVector) Run(t *testing.T) {
+	initCfg := &Config{
+		CipherSuite:  v.suite,
+		Pattern:      v.pattern,
+		Initiator:    true,
+		Prologue:     v.prologue,
+		StaticKey:    v.initStatic,
+		PresharedKey: v.psk,
+		Rand:         bytes.NewReader(v.initEphemeral),
+	}
+	respCfg := &Config{
+		CipherSuite:  v.suite,
+		Pattern:      v.pattern,
+		Prologue:     v.prologue,
+		StaticKey:    v.respStatic,
+		PresharedKey: v.psk,
+		Rand:         bytes.NewReader(v.respEphemeral),
+	}
+	if v.pattern.needsRemoteStatic(true) {
+		initCfg.RemoteStaticKey = v.respStatic.Public()
+	}
+	if v.pattern.needsRemoteStatic(false) {
+		respCfg.RemoteStaticKey = v.initStatic.Public()
+	}
+
+	initHs, err := NewHandshakeState(initCfg)
+	if err != nil {
+		t.Fatalf("NewHandshakeState(initiator): %v", err)
+	}
+	respHs, err := NewHandshakeState(respCfg)
+	if err != nil {
+		t.Fatalf("NewHandshakeState(responder): %v", err)
+	}
+
+	var (
+		numHandshakeMsgs = len(v.pattern.Messages)
+		writeCs, readCs  [2]*CipherState
+	)
+	for i, payload := range v.payloads {
+		if i >= numHandshakeMsgs {
+			// Transport messages alternate between the CipherStates,
+			// starting with the initiator to responder one.
+			idx := (i - numHandshakeMsgs) % 2
+			ciphertext, err := writeCs[idx].Encrypt(nil, nil, payload)
+			if err != nil {
+				t.Fatalf("msg %d: Encrypt: %v", i, err)
+			}
+			if !bytes.Equal(ciphertext, v.ciphertexts[i]) {
+				t.Fatalf("msg %d: ciphertext mismatch (Got: %x, Expected: %x)", i, ciphertext, v.ciphertexts[i])
+			}
+			plaintext, err := readCs[idx].Decrypt(nil, nil, ciphertext)
+			if err != nil {
+				t.Fatalf("msg %d: Decrypt: %v", i, err)
+			}
+			if !bytes.Equal(plaintext, payload) {
+				t.Fatalf("msg %d: plaintext mismatch", i)
+			}
+			continue
+		}
+
+		writer, reader := initHs, respHs
+		if i%2 != 0 {
+			writer, reader = respHs, initHs
+		}
+
+		msg, c1, c2, err := writer.WriteMessage(nil, payload)
+		if err != nil {
+			t.Fatalf("msg %d: WriteMessage: %v", i, err)
+		}
+		if !bytes.Equal(msg, v.ciphertexts[i]) {
+			t.Fatalf("msg %d: message mismatch (Got: %x, Expected: %x)", i, msg, v.ciphertexts[i])
+		}
+		writeCs = [2]*CipherState{c1, c2}
+
+		plaintext, c1, c2, err := reader.ReadMessage(nil, msg)
+		if err != nil {
+			t.Fatalf("msg %d: ReadMessage: %v", i, err)
+		}
+		if !bytes.Equal(plaintext, payload) {
+			t.Fatalf("msg %d: payload mismatch", i)
+		}
+		readCs = [2]*CipherState{c1, c2}
+	}
+
+	if !initHs.IsComplete() || !respHs.IsComplete() {
+		t.Fatalf("handshake not complete")
+	}
+	if !bytes.Equal(initHs.HandshakeHash(), respHs.HandshakeHash()) {
+		t.Fatalf("handshake hash mismatch")
+	}
+	if !bytes.Equal(initHs.HandshakeHash(), v.handshakeHash) {
+		t.Fatalf("handshake hash: got %x, expected %x", initHs.HandshakeHash(), v.handshakeHash)
+	}
+}
+
+func TestVectors(t *testing.T) {
+	testVectors := loadTestVectors(t, "testdata/vectors.txt.gz")
+	for _, v := range testVectors {
+		v := v
+		t.Run(v.name, v.Run)
+	}
+}
+
+func loadTestVectors(t *testing.T, fn string) []*testVector {
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rd, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+
+	var (
+		testVectors []*testVector
+		v           *testVector
+	)
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		splitLine := strings.SplitN(line, "=", 2)
+		if len(splitLine) != 2 {
+			t.Fatalf("malformed line: '%s'", line)
+		}
+		key := splitLine[0]
+		value, err := hex.DecodeString(splitLine[1])
+		if err != nil && key != "handshake" {
+			t.Fatalf("malformed value: '%s': %v", line, err)
+		}
+
+		switch {
+		case key == "handshake":
+			v = parseTestVectorName(t, splitLine[1])
+			testVectors = append(testVectors, v)
+		case v == nil:
+			t.Fatalf("field before handshake: '%s'", line)
+		case key == "gen_init_ephemeral":
+			v.initEphemeral = value
+		case key == "gen_resp_ephemeral":
+			v.respEphemeral = value
+		case key == "init_static":
+			v.initStatic = new(x25519.PrivateKey)
+			copy(v.initStatic[:], value)
+		case key == "resp_static":
+			v.respStatic = new(x25519.PrivateKey)
+			copy(v.respStatic[:], value)
+		case key == "preshared_key":
+			v.psk = value
+		case key == "prologue":
+			v.prologue = value
+		case strings.HasPrefix(key, "msg_") && strings.HasSuffix(key, "_payload"):
+			v.payloads = append(v.payloads, value)
+		case strings.HasPrefix(key, "msg_") && strings.HasSuffix(key, "_ciphertext"):
+			v.ciphertexts = append(v.ciphertexts, value)
+		case key == "handshake_hash":
+			v.handshakeHash = value
+		default:
+			t.Fatalf("unknown field: '%s'", key)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return testVectors
+}
+
+func parseTestVectorName(t *testing.T, name string) *testVector {
+	// Noise_<pattern>[psk<n>[+psk<n>...]]_25519_<cipher>_<hash>
+	components := strings.Split(name, "_")
+	if len(components) != 5 || components[0] != "Noise" || components[2] != "25519" {
+		t.Fatalf("malformed protocol name: '%s'", name)
+	}
+
+	v := &testVector{
+		name: name,
+		suite: &CipherSuite{
+			Cipher: testCiphers[components[3]],
+			Hash:   testHashes[components[4]],
+		},
+	}
+	if v.suite.Cipher == nil || v.suite.Hash == nil {
+		t.Fatalf("unsupported cipher suite: '%s'", name)
+	}
+
+	patternComponents := strings.SplitN(components[1], "psk", 2)
+	if v.pattern = testPatterns[patternComponents[0]]; v.pattern == nil {
+		t.Fatalf("unsupported pattern: '%s'", name)
+	}
+	if len(patternComponents) == 2 {
+		var modifiers []int
+		for _, s := range strings.Split("psk"+patternComponents[1], "+") {
+			n, err := strconv.Atoi(strings.TrimPrefix(s, "psk"))
+			if err != nil {
+				t.Fatalf("malformed psk modifier: '%s'", name)
+			}
+			modifiers = append(modifiers, n)
+		}
+
+		var err error
+		if v.pattern, err = v.pattern.WithPSK(modifiers...); err != nil {
+			t.Fatalf("WithPSK: %v", err)
+		}
+	}
+	if n := "Noise_" + v.pattern.Name + "_" + v.suite.String(); n != name {
+		t.Fatalf("protocol name mismatch: '%s' != '%s'", n, name)
+	}
+
+	return v
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package sodium implements libsodium compatible constructions, so that
+// data can be exchanged with software that uses libsodium.
+//
+// The box functions produce the "easy" (combined) format, where the MAC
+// is prepended to the ciphertext.  The legacy crypto_box format is the
+// same, with an additional crypto_box_BOXZEROBYTES (16) zero bytes prefix.
+package sodium
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/salsa20/salsa"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// BoxSeedSize is the size, in bytes, of a crypto_box key pair seed
+	// (crypto_box_SEEDBYTES).
+	BoxSeedSize = 32
+
+	// BoxBeforeNMSize is the size, in bytes, of a crypto_box precomputed
+	// shared key (crypto_box_BEFORENMBYTES).
+	BoxBeforeNMSize = 32
+
+	// BoxNonceSize is the size, in bytes, of a crypto_box nonce
+	// (crypto_box_NONCEBYTES).
+	BoxNonceSize = 24
+
+	// BoxMACSize is the size, in bytes, of a crypto_box MAC
+	// (crypto_box_MACBYTES).
+	BoxMACSize = secretbox.Overhead
+
+	// BoxSealOverhead is the size, in bytes, of the crypto_box_seal
+	// overhead (crypto_box_SEALBYTES).
+	BoxSealOverhead = x25519.PublicKeySize + BoxMACSize
+)
+
+// ErrOpen is the error returned when a box fails to open.
+var ErrOpen = errors.New("sodium: failed to open box")
+
+// BoxKeyPair generates a crypto_box key pair using entropy from rand, like
+// crypto_box_keypair.  If rand is nil, crypto/rand.Reader will be used.
+func BoxKeyPair(rand io.Reader) (*x25519.PublicKey, *x25519.PrivateKey, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var privateKey x25519.PrivateKey
+	if _, err := io.ReadFull(rand, privateKey[:]); err != nil {
+		return nil, nil, fmt.Errorf("sodium: failed to read entropy: %w", err)
+	}
+
+	return privateKey.Public(), &privateKey, nil
+}
+
+// BoxSeedKeyPair deterministically derives a crypto_box key pair from a
+// seed, like crypto_box_seed_keypair.
+func BoxSeedKeyPair(seed []byte) (*x25519.PublicKey, *x25519.PrivateKey, error) {
+	if l := len(seed); l != BoxSeedSize {
+		return nil, nil, fmt.Errorf("sodium: invalid seed length: %d", l)
+	}
+
+	digest := sha512.Sum512(seed)
+	defer func() {
+		for i := range digest {
+			digest[i] = 0
+		}
+	}()
+
+	var privateKey x25519.PrivateKey
+	copy(privateKey[:], digest[:])
+
+	return privateKey.Public(), &privateKey, nil
+}
+
+// BoxBeforeNM computes the shared key used by BoxAfterNM and BoxOpenAfterNM,
+// like crypto_box_beforenm.  It returns an error if the public key is of
+// low order.
+func BoxBeforeNM(publicKey *x25519.PublicKey, privateKey *x25519.PrivateKey) (*[BoxBeforeNMSize]byte, error) {
+	dh, err := x25519.X25519(privateKey[:], publicKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("sodium: X25519 failed: %w", err)
+	}
+
+	var (
+		k, s  [BoxBeforeNMSize]byte
+		zeros [16]byte
+	)
+	copy(s[:], dh)
+	salsa.HSalsa20(&k, &zeros, &s, &salsa.Sigma)
+	for i := range s {
+		s[i] = 0
+		dh[i] = 0
+	}
+
+	return &k, nil
+}
+
+// Box encrypts and authenticates message with the recipient's public key
+// and the sender's private key, appends the result to out, and returns
+// the updated slice, like crypto_box_easy.
+func Box(out, message []byte, nonce *[BoxNonceSize]byte, publicKey *x25519.PublicKey, privateKey *x25519.PrivateKey) ([]byte, error) {
+	k, err := BoxBeforeNM(publicKey, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(k)
+
+	return BoxAfterNM(out, message, nonce, k), nil
+}
+
+// BoxOpen authenticates and decrypts a box with the sender's public key
+// and the recipient's private key, appends the message to out, and returns
+// the updated slice, like crypto_box_open_easy.
+func BoxOpen(out, box []byte, nonce *[BoxNonceSize]byte, publicKey *x25519.PublicKey, privateKey *x25519.PrivateKey) ([]byte, error) {
+	k, err := BoxBeforeNM(publicKey, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(k)
+
+	return BoxOpenAfterNM(out, box, nonce, k)
+}
+
+// BoxAfterNM encrypts and authenticates message with a shared key computed
+// by BoxBeforeNM, appends the result to out, and returns the updated slice,
+// like crypto_box_easy_afternm.
+func BoxAfterNM(out, message []byte, nonce *[BoxNonceSize]byte, k *[BoxBeforeNMSize]byte) []byte {
+	return secretbox.Seal(out, message, nonce, k)
+}
+
+// BoxOpenAfterNM authenticates and decrypts a box with a shared key computed
+// by BoxBeforeNM, appends the message to out, and returns the updated slice,
+// like crypto_box_open_easy_afternm.
+func BoxOpenAfterNM(out, box []byte, nonce *[BoxNonceSize]byte, k *[BoxBeforeNMSize]byte) ([]byte, error) {
+	out, ok := secretbox.Open(out, box, nonce, k)
+	if !ok {
+		return nil, ErrOpen
+	}
+	return out, nil
+}
+
+// BoxSeal anonymously encrypts message to the recipient's public key with
+// an ephemeral key pair generated using entropy from rand, appends the
+// result to out, and returns the updated slice, like crypto_box_seal.  If
+// rand is nil, crypto/rand.Reader will be used.
+func BoxSeal(out, message []byte, publicKey *x25519.PublicKey, rand io.Reader) ([]byte, error) {
+	ephemeralPublicKey, ephemeralPrivateKey, err := BoxKeyPair(rand)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range ephemeralPrivateKey {
+			ephemeralPrivateKey[i] = 0
+		}
+	}()
+
+	nonce := boxSealNonce(ephemeralPublicKey, publicKey)
+	out = append(out, ephemeralPublicKey[:]...)
+
+	return Box(out, message, nonce, publicKey, ephemeralPrivateKey)
+}
+
+// BoxSealOpen decrypts a box produced by BoxSeal with the recipient's key
+// pair, appends the message to out, and returns the updated slice, like
+// crypto_box_seal_open.
+func BoxSealOpen(out, box []byte, publicKey *x25519.PublicKey, privateKey *x25519.PrivateKey) ([]byte, error) {
+	if len(box) < BoxSealOverhead {
+		return nil, ErrOpen
+	}
+
+	var ephemeralPublicKey x25519.PublicKey
+	copy(ephemeralPublicKey[:], box[:x25519.PublicKeySize])
+	nonce := boxSealNonce(&ephemeralPublicKey, publicKey)
+
+	return BoxOpen(out, box[x25519.PublicKeySize:], nonce, &ephemeralPublicKey, privateKey)
+}
+
+func boxSealNonce(ephemeralPublicKey, publicKey *x25519.PublicKey) *[BoxNonceSize]byte {
+	// nonce = BLAKE2b-192(epk || pk)
+	h, err := blake2b.New(BoxNonceSize, nil)
+	if err != nil {
+		panic("sodium: failed to initialize BLAKE2b: " + err.Error())
+	}
+	_, _ = h.Write(ephemeralPublicKey[:])
+	_, _ = h.Write(publicKey[:])
+
+	var nonce [BoxNonceSize]byte
+	copy(nonce[:], h.Sum(nil))
+
+	return &nonce
+}
+
+func zeroKey(k *[BoxBeforeNMSize]byte) {
+	for i := range k {
+		k[i] = 0
+	}
+}
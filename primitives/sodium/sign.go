@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package sodium
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+// SignOverhead is the size, in bytes, of the crypto_sign signed message
+// overhead (crypto_sign_BYTES).
+const SignOverhead = ed25519.SignatureSize
+
+// ErrVerify is the error returned when a signed message fails to verify.
+var ErrVerify = errors.New("sodium: failed to verify signed message")
+
+var signOpts = &ed25519.Options{
+	Verify: ed25519.VerifyOptionsLibsodium,
+}
+
+// Sign signs message, appends the signed message (the signature followed
+// by the message) to out, and returns the updated slice, like crypto_sign.
+func Sign(out, message []byte, privateKey ed25519.PrivateKey) []byte {
+	out = append(out, ed25519.Sign(privateKey, message)...)
+	return append(out, message...)
+}
+
+// SignOpen verifies a signed message produced by Sign, appends the message
+// to out, and returns the updated slice, like crypto_sign_open.  The
+// verification semantics match those of libsodium, and the returned error
+// wraps ErrVerify on failure.
+func SignOpen(out, signedMessage []byte, publicKey ed25519.PublicKey) ([]byte, error) {
+	if len(signedMessage) < SignOverhead {
+		return nil, ErrVerify
+	}
+
+	sig, message := signedMessage[:SignOverhead], signedMessage[SignOverhead:]
+	if err := ed25519.VerifyWithError(publicKey, message, sig, signOpts); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerify, err)
+	}
+
+	return append(out, message...), nil
+}
+
+// Ed25519SkToCurve25519 converts an Ed25519 private key to a X25519 private
+// key, like crypto_sign_ed25519_sk_to_curve25519.
+func Ed25519SkToCurve25519(privateKey ed25519.PrivateKey) (*x25519.PrivateKey, error) {
+	if l := len(privateKey); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("sodium: invalid Ed25519 private key length: %d", l)
+	}
+
+	var x25519Key x25519.PrivateKey
+	sk := x25519.EdPrivateKeyToX25519(privateKey)
+	copy(x25519Key[:], sk)
+	for i := range sk {
+		sk[i] = 0
+	}
+
+	return &x25519Key, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package sodium
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+func TestSodium(t *testing.T) {
+	t.Run("Box", testBox)
+	t.Run("BoxSeal", testBoxSeal)
+	t.Run("Sign", testSign)
+}
+
+func testBoxKeyPair(t *testing.T) (*x25519.PublicKey, *x25519.PrivateKey) {
+	pk, sk, err := BoxKeyPair(nil)
+	if err != nil {
+		t.Fatalf("BoxKeyPair: %v", err)
+	}
+	return pk, sk
+}
+
+func testBox(t *testing.T) {
+	senderPk, senderSk := testBoxKeyPair(t)
+	recipientPk, recipientSk := testBoxKeyPair(t)
+	nonce := [BoxNonceSize]byte{0x01}
+	msg := []byte("crypto_box test message")
+
+	c, err := Box([]byte("prefix"), msg, &nonce, recipientPk, senderSk)
+	if err != nil {
+		t.Fatalf("Box: %v", err)
+	}
+	if !bytes.HasPrefix(c, []byte("prefix")) {
+		t.Fatalf("Box: did not append to out")
+	}
+	c = c[len("prefix"):]
+	if len(c) != len(msg)+BoxMACSize {
+		t.Fatalf("Box: unexpected length: %d", len(c))
+	}
+
+	// x/crypto/nacl/box implements the same construction.
+	naclC := box.Seal(nil, msg, &nonce, (*[32]byte)(recipientPk), (*[32]byte)(senderSk))
+	if !bytes.Equal(c, naclC) {
+		t.Fatalf("Box: x/crypto/nacl/box mismatch")
+	}
+
+	if _, err = BoxOpen(nil, c, &nonce, senderPk, recipientSk); err != nil {
+		t.Fatalf("BoxOpen: %v", err)
+	}
+
+	tampered := append([]byte{}, c...)
+	tampered[len(tampered)-1] ^= 0x01
+	if _, err = BoxOpen(nil, tampered, &nonce, senderPk, recipientSk); !errors.Is(err, ErrOpen) {
+		t.Fatalf("BoxOpen(tampered): %v", err)
+	}
+	otherNonce := [BoxNonceSize]byte{0x02}
+	if _, err = BoxOpen(nil, c, &otherNonce, senderPk, recipientSk); !errors.Is(err, ErrOpen) {
+		t.Fatalf("BoxOpen(wrong nonce): %v", err)
+	}
+	if _, err = BoxOpen(nil, c[:BoxMACSize-1], &nonce, senderPk, recipientSk); !errors.Is(err, ErrOpen) {
+		t.Fatalf("BoxOpen(truncated): %v", err)
+	}
+
+	// Like libsodium, low-order public keys are rejected.
+	var lowOrder x25519.PublicKey
+	if _, err = BoxBeforeNM(&lowOrder, senderSk); err == nil {
+		t.Fatalf("BoxBeforeNM(low order) succeeded")
+	}
+	if _, err = Box(nil, msg, &nonce, &lowOrder, senderSk); err == nil {
+		t.Fatalf("Box(low order) succeeded")
+	}
+
+	if _, _, err = BoxSeedKeyPair(make([]byte, BoxSeedSize-1)); err == nil {
+		t.Fatalf("BoxSeedKeyPair(short seed) succeeded")
+	}
+}
+
+func testBoxSeal(t *testing.T) {
+	pk, sk := testBoxKeyPair(t)
+	_, otherSk := testBoxKeyPair(t)
+	msg := []byte("crypto_box_seal test message")
+
+	c, err := BoxSeal(nil, msg, pk, nil)
+	if err != nil {
+		t.Fatalf("BoxSeal: %v", err)
+	}
+	if len(c) != len(msg)+BoxSealOverhead {
+		t.Fatalf("BoxSeal: unexpected length: %d", len(c))
+	}
+
+	m, err := BoxSealOpen(nil, c, pk, sk)
+	if err != nil {
+		t.Fatalf("BoxSealOpen: %v", err)
+	}
+	if !bytes.Equal(m, msg) {
+		t.Fatalf("BoxSealOpen: message mismatch")
+	}
+
+	// x/crypto/nacl/box implements the same construction.
+	if m, ok := box.OpenAnonymous(nil, c, (*[32]byte)(pk), (*[32]byte)(sk)); !ok || !bytes.Equal(m, msg) {
+		t.Fatalf("box.OpenAnonymous failed")
+	}
+
+	if _, err = BoxSealOpen(nil, c, pk, otherSk); !errors.Is(err, ErrOpen) {
+		t.Fatalf("BoxSealOpen(wrong key): %v", err)
+	}
+	if _, err = BoxSealOpen(nil, c[:BoxSealOverhead-1], pk, sk); !errors.Is(err, ErrOpen) {
+		t.Fatalf("BoxSealOpen(truncated): %v", err)
+	}
+	tampered := append([]byte{}, c...)
+	tampered[0] ^= 0x01
+	if _, err = BoxSealOpen(nil, tampered, pk, sk); err == nil {
+		t.Fatalf("BoxSealOpen(tampered ephemeral key) succeeded")
+	}
+}
+
+func testSign(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	msg := []byte("crypto_sign test message")
+
+	sm := Sign(nil, msg, sk)
+	if len(sm) != len(msg)+SignOverhead {
+		t.Fatalf("Sign: unexpected length: %d", len(sm))
+	}
+	m, err := SignOpen(nil, sm, pk)
+	if err != nil {
+		t.Fatalf("SignOpen: %v", err)
+	}
+	if !bytes.Equal(m, msg) {
+		t.Fatalf("SignOpen: message mismatch")
+	}
+
+	if _, err = SignOpen(nil, sm[:SignOverhead-1], pk); !errors.Is(err, ErrVerify) {
+		t.Fatalf("SignOpen(truncated): %v", err)
+	}
+	if _, err = SignOpen(nil, sm, pk[:31]); !errors.Is(err, ErrVerify) {
+		t.Fatalf("SignOpen(short public key): %v", err)
+	}
+
+	if _, err = Ed25519SkToCurve25519(sk[:32]); err == nil {
+		t.Fatalf("Ed25519SkToCurve25519(seed) succeeded")
+	}
+
+	// Boxes between converted Ed25519 keys must work.
+	x25519Sk, err := Ed25519SkToCurve25519(sk)
+	if err != nil {
+		t.Fatalf("Ed25519SkToCurve25519: %v", err)
+	}
+	b, ok := x25519.EdPublicKeyToX25519(pk)
+	if !ok {
+		t.Fatalf("EdPublicKeyToX25519 failed")
+	}
+	var x25519Pk x25519.PublicKey
+	copy(x25519Pk[:], b)
+
+	c, err := BoxSeal(nil, msg, &x25519Pk, nil)
+	if err != nil {
+		t.Fatalf("BoxSeal: %v", err)
+	}
+	if _, err = BoxSealOpen(nil, c, &x25519Pk, x25519Sk); err != nil {
+		t.Fatalf("BoxSealOpen: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package sodium
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+// The test vectors were generated with libsodium 1.0.18, with a
+// deterministic randombytes implementation so that the crypto_box_seal
+// ephemeral private keys are known.
+type testVectors struct {
+	Box      []boxTestVector      `json:"box"`
+	BoxSeal  []boxSealTestVector  `json:"box_seal"`
+	Sign     []signTestVector     `json:"sign"`
+	SignOpen []signOpenTestVector `json:"sign_open"`
+}
+
+type boxTestVector struct {
+	SenderSk    string `json:"sender_sk"`
+	SenderPk    string `json:"sender_pk"`
+	RecipientSk string `json:"recipient_sk"`
+	RecipientPk string `json:"recipient_pk"`
+	Nonce       string `json:"nonce"`
+	BeforeNM    string `json:"beforenm"`
+	Msg         string `json:"msg"`
+	Ciphertext  string `json:"ciphertext"`
+}
+
+type boxSealTestVector struct {
+	RecipientSk string `json:"recipient_sk"`
+	RecipientPk string `json:"recipient_pk"`
+	EphemeralSk string `json:"ephemeral_sk"`
+	Msg         string `json:"msg"`
+	Ciphertext  string `json:"ciphertext"`
+}
+
+type signTestVector struct {
+	Seed         string `json:"seed"`
+	Pk           string `json:"pk"`
+	Sk           string `json:"sk"`
+	Curve25519Sk string `json:"curve25519_sk"`
+	Curve25519Pk string `json:"curve25519_pk"`
+	Msg          string `json:"msg"`
+	SignedMsg    string `json:"signed_msg"`
+}
+
+type signOpenTestVector struct {
+	Name      string `json:"name"`
+	Pk        string `json:"pk"`
+	SignedMsg string `json:"signed_msg"`
+	Valid     bool   `json:"valid"`
+}
+
+func TestVectors(t *testing.T) {
+	f, err := os.Open("testdata/libsodium.json.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rd, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+
+	var vectors testVectors
+
+	dec := json.NewDecoder(rd)
+	if err = dec.Decode(&vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, vec := range vectors.Box {
+		vec := vec
+		t.Run(fmt.Sprintf("Box/%d", i), func(t *testing.T) {
+			testBoxVector(t, &vec)
+		})
+	}
+	for i, vec := range vectors.BoxSeal {
+		vec := vec
+		t.Run(fmt.Sprintf("BoxSeal/%d", i), func(t *testing.T) {
+			testBoxSealVector(t, &vec)
+		})
+	}
+	for i, vec := range vectors.Sign {
+		vec := vec
+		t.Run(fmt.Sprintf("Sign/%d", i), func(t *testing.T) {
+			testSignVector(t, &vec)
+		})
+	}
+	for _, vec := range vectors.SignOpen {
+		vec := vec
+		t.Run("SignOpen/"+vec.Name, func(t *testing.T) {
+			pk := testhelpers.MustUnhex(t, vec.Pk)
+			_, err := SignOpen(nil, testhelpers.MustUnhex(t, vec.SignedMsg), pk)
+			if valid := err == nil; valid != vec.Valid {
+				t.Fatalf("SignOpen: %v (Expected valid: %v)", err, vec.Valid)
+			}
+		})
+	}
+}
+
+func unhexX25519Keys(t *testing.T, pk, sk string) (*x25519.PublicKey, *x25519.PrivateKey) {
+	var (
+		publicKey  x25519.PublicKey
+		privateKey x25519.PrivateKey
+	)
+	copy(publicKey[:], testhelpers.MustUnhex(t, pk))
+	copy(privateKey[:], testhelpers.MustUnhex(t, sk))
+	if *privateKey.Public() != publicKey {
+		t.Fatalf("public key mismatch")
+	}
+	return &publicKey, &privateKey
+}
+
+func testBoxVector(t *testing.T, vec *boxTestVector) {
+	senderPk, senderSk := unhexX25519Keys(t, vec.SenderPk, vec.SenderSk)
+	recipientPk, recipientSk := unhexX25519Keys(t, vec.RecipientPk, vec.RecipientSk)
+
+	var nonce [BoxNonceSize]byte
+	copy(nonce[:], testhelpers.MustUnhex(t, vec.Nonce))
+	msg := testhelpers.MustUnhex(t, vec.Msg)
+	expected := testhelpers.MustUnhex(t, vec.Ciphertext)
+
+	k, err := BoxBeforeNM(recipientPk, senderSk)
+	if err != nil {
+		t.Fatalf("BoxBeforeNM: %v", err)
+	}
+	if !bytes.Equal(k[:], testhelpers.MustUnhex(t, vec.BeforeNM)) {
+		t.Fatalf("BoxBeforeNM: shared key mismatch (Got: %x)", k[:])
+	}
+	if k2, err := BoxBeforeNM(senderPk, recipientSk); err != nil || *k2 != *k {
+		t.Fatalf("BoxBeforeNM: recipient shared key mismatch: %v", err)
+	}
+
+	c, err := Box(nil, msg, &nonce, recipientPk, senderSk)
+	if err != nil {
+		t.Fatalf("Box: %v", err)
+	}
+	if !bytes.Equal(c, expected) {
+		t.Fatalf("Box: ciphertext mismatch (Got: %x)", c)
+	}
+	if c = BoxAfterNM(nil, msg, &nonce, k); !bytes.Equal(c, expected) {
+		t.Fatalf("BoxAfterNM: ciphertext mismatch (Got: %x)", c)
+	}
+
+	m, err := BoxOpen(nil, expected, &nonce, senderPk, recipientSk)
+	if err != nil {
+		t.Fatalf("BoxOpen: %v", err)
+	}
+	if !bytes.Equal(m, msg) {
+		t.Fatalf("BoxOpen: message mismatch (Got: %x)", m)
+	}
+	if m, err = BoxOpenAfterNM(nil, expected, &nonce, k); err != nil || !bytes.Equal(m, msg) {
+		t.Fatalf("BoxOpenAfterNM: %v", err)
+	}
+}
+
+func testBoxSealVector(t *testing.T, vec *boxSealTestVector) {
+	pk, sk := unhexX25519Keys(t, vec.RecipientPk, vec.RecipientSk)
+	msg := testhelpers.MustUnhex(t, vec.Msg)
+	expected := testhelpers.MustUnhex(t, vec.Ciphertext)
+
+	c, err := BoxSeal(nil, msg, pk, bytes.NewReader(testhelpers.MustUnhex(t, vec.EphemeralSk)))
+	if err != nil {
+		t.Fatalf("BoxSeal: %v", err)
+	}
+	if !bytes.Equal(c, expected) {
+		t.Fatalf("BoxSeal: ciphertext mismatch (Got: %x)", c)
+	}
+
+	m, err := BoxSealOpen(nil, expected, pk, sk)
+	if err != nil {
+		t.Fatalf("BoxSealOpen: %v", err)
+	}
+	if !bytes.Equal(m, msg) {
+		t.Fatalf("BoxSealOpen: message mismatch (Got: %x)", m)
+	}
+}
+
+func testSignVector(t *testing.T, vec *signTestVector) {
+	sk := ed25519.NewKeyFromSeed(testhelpers.MustUnhex(t, vec.Seed))
+	if !bytes.Equal(sk, testhelpers.MustUnhex(t, vec.Sk)) {
+		t.Fatalf("NewKeyFromSeed: private key mismatch (Got: %x)", []byte(sk))
+	}
+	pk := testhelpers.MustUnhex(t, vec.Pk)
+	msg := testhelpers.MustUnhex(t, vec.Msg)
+	expected := testhelpers.MustUnhex(t, vec.SignedMsg)
+
+	if sm := Sign(nil, msg, sk); !bytes.Equal(sm, expected) {
+		t.Fatalf("Sign: signed message mismatch (Got: %x)", sm)
+	}
+	m, err := SignOpen(nil, expected, pk)
+	if err != nil {
+		t.Fatalf("SignOpen: %v", err)
+	}
+	if !bytes.Equal(m, msg) {
+		t.Fatalf("SignOpen: message mismatch (Got: %x)", m)
+	}
+
+	x25519Sk, err := Ed25519SkToCurve25519(sk)
+	if err != nil {
+		t.Fatalf("Ed25519SkToCurve25519: %v", err)
+	}
+	if !bytes.Equal(x25519Sk[:], testhelpers.MustUnhex(t, vec.Curve25519Sk)) {
+		t.Fatalf("Ed25519SkToCurve25519: private key mismatch (Got: %x)", x25519Sk[:])
+	}
+	if x25519Pk := x25519Sk.Public(); !bytes.Equal(x25519Pk[:], testhelpers.MustUnhex(t, vec.Curve25519Pk)) {
+		t.Fatalf("Ed25519SkToCurve25519: public key mismatch (Got: %x)", x25519Pk[:])
+	}
+
+	// The converted keys must also match EdPublicKeyToX25519.
+	x25519Pk, ok := x25519.EdPublicKeyToX25519(pk)
+	if !ok || !bytes.Equal(x25519Pk, testhelpers.MustUnhex(t, vec.Curve25519Pk)) {
+		t.Fatalf("EdPublicKeyToX25519: public key mismatch (Got: %x)", x25519Pk)
+	}
+}
@@ -45,7 +45,8 @@ import (
 type BatchVerifier struct {
 	entries []entry
 
-	anyInvalid bool
+	anyInvalid    bool
+	deterministic bool
 }
 
 type entry struct {
@@ -111,11 +112,18 @@ func (v *BatchVerifier) Add(pk *PublicKey, transcript *SigningTranscript, signat
 // VerifyBatchOnly checks all entries in the current batch using entropy
 // from rand, returning true if all entries are valid and false if any one
 // entry is invalid.  If rand is nil, crypto/rand.Reader will be used.
+// If the batch is deterministic, rand is ignored.
 //
 // If a failure arises it is unknown which entry failed, the caller must
 // verify each entry individually.
 func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
-	if rand == nil {
+	switch {
+	case v.deterministic:
+		// The transcript is bound to every entry's S, in addition
+		// to everything else, so the lack of external entropy is
+		// fine.
+		rand = zeroreader.ZeroReader{}
+	case rand == nil:
 		rand = cryptorand.Reader
 	}
 
@@ -178,6 +186,15 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 	for i := range v.entries {
 		zs_t.commitBytes("", v.entries[i].witnessBytes[:])
 	}
+	if v.deterministic {
+		var sBytes [scalar.ScalarSize]byte
+		for i := range v.entries {
+			if err := v.entries[i].S.ToBytes(sBytes[:]); err != nil {
+				panic("sr25519: failed to serialize S: " + err.Error())
+			}
+			zs_t.commitBytes("", sBytes[:])
+		}
+	}
 	zs_rng, err := zs_t.witnessRng("", nil, rand)
 	if err != nil {
 		panic("sr25519: failed to instantiate delinearization rng: " + err.Error())
@@ -226,6 +243,7 @@ func (v *BatchVerifier) VerifyBatchOnly(rand io.Reader) bool {
 // more signature is invalid, each entry in the batch will be verified
 // serially, and the returned bit-vector will provide information about
 // each individual entry.  If rand is nil, crypto/rand.Reader will be used.
+// If the batch is deterministic, rand is ignored.
 //
 // Note: This method is only faster than individually verifying each
 // signature if every signature is valid.  That said, this method will
@@ -276,6 +294,17 @@ func (v *BatchVerifier) Verify(rand io.Reader) (bool, []bool) {
 	return allValid, valid
 }
 
+// SetDeterministic sets if batch verification will derive the random
+// scalars solely from a transcript over every entry (public keys,
+// signing transcripts, and signatures), instead of using an external
+// entropy source.  This is intended for environments where an entropy
+// source is unavailable, or where verification must be reproducible.
+// This setting will persist across Reset.
+func (v *BatchVerifier) SetDeterministic(deterministic bool) *BatchVerifier {
+	v.deterministic = deterministic
+	return v
+}
+
 // Reset resets a batch for reuse.
 //
 // Note: This method will reuse the existing entires slice to reduce memory
@@ -30,8 +30,10 @@
 package sr25519
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"testing/iotest"
 )
 
 var benchBatchSizes = []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 384, 512, 768, 1024}
@@ -182,6 +184,23 @@ func (tc *batchVerifierTestCase) run(t *testing.T) {
 			t.Errorf("bit-vector %d incorrect (Got: %v)", i, sigValid)
 		}
 	}
+
+	// Finally test that deterministic batch verification returns the
+	// same results, without touching the entropy source.
+	brokenRand := iotest.ErrReader(errors.New("entropy source used"))
+	v.SetDeterministic(true)
+	if v.VerifyBatchOnly(brokenRand) != expectedBatchOk {
+		t.Errorf("deterministic: %s", tc.details)
+	}
+	allValid2, valid2 := v.Verify(brokenRand)
+	if allValid2 != allValid {
+		t.Errorf("deterministic Verify returned incorrect summary (Got: %v)", allValid2)
+	}
+	for i := range valid {
+		if valid[i] != valid2[i] {
+			t.Errorf("deterministic bit-vector %d incorrect (Got: %v)", i, valid2[i])
+		}
+	}
 }
 
 func TestBatchVerifier(t *testing.T) {
@@ -0,0 +1,377 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package sr25519
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/merlin"
+)
+
+const (
+	// MuSig2PublicNonceSize is the size of a serialized MuSig2 public
+	// nonce in bytes.
+	MuSig2PublicNonceSize = 64
+
+	// MuSig2PartialSignatureSize is the size of a serialized MuSig2
+	// partial signature in bytes.
+	MuSig2PartialSignatureSize = 32
+
+	musig2KeyAggLabel    = "MuSig2-aggregate-public_key"
+	musig2PkSetLabel     = "pk-set"
+	musig2PkChoiceLabel  = "pk-choice"
+	musig2NonceLabel     = "MuSig2-nonce"
+	musig2R1Label        = "musig2:R1"
+	musig2R2Label        = "musig2:R2"
+	musig2BLabel         = "musig2:b"
+	musig2WitnessR1Label = "musig2:r1"
+	musig2WitnessR2Label = "musig2:r2"
+)
+
+// MuSig2AggregateKey is a MuSig2 aggregate public key, along with the
+// key aggregation coefficient of each signer.
+type MuSig2AggregateKey struct {
+	publicKeys   []*PublicKey
+	coefficients []*scalar.Scalar
+	pk           *PublicKey
+}
+
+// PublicKey returns the aggregate public key.  Signatures produced by
+// a MuSig2Session verify against it with PublicKey.Verify.
+func (ak *MuSig2AggregateKey) PublicKey() *PublicKey {
+	return ak.pk
+}
+
+// PublicKeys returns the signers' public keys, in the order used for
+// key aggregation.
+func (ak *MuSig2AggregateKey) PublicKeys() []*PublicKey {
+	return append([]*PublicKey{}, ak.publicKeys...)
+}
+
+func (ak *MuSig2AggregateKey) index(pk *PublicKey) int {
+	for i, v := range ak.publicKeys {
+		if v.Equal(pk) {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewMuSig2AggregateKey aggregates the public keys of the signers into
+// a single public key.  The order of publicKeys is significant, and
+// must be the same for all of the signers.
+//
+// Each signer's key is weighted by a coefficient derived from a merlin
+// transcript over the entire set of public keys, which prevents rogue
+// key attacks.
+func NewMuSig2AggregateKey(publicKeys []*PublicKey) (*MuSig2AggregateKey, error) {
+	if len(publicKeys) == 0 {
+		return nil, fmt.Errorf("sr25519: no MuSig2 public keys")
+	}
+
+	t := merlin.NewTranscript(musig2KeyAggLabel)
+	for i, pk := range publicKeys {
+		if pk == nil || pk.point == nil {
+			return nil, fmt.Errorf("sr25519: uninitialized MuSig2 public key: %d", i)
+		}
+		for _, v := range publicKeys[:i] {
+			if v.Equal(pk) {
+				return nil, fmt.Errorf("sr25519: duplicate MuSig2 public key: %d", i)
+			}
+		}
+		t.AppendMessage(musig2PkSetLabel, pk.compressed[:])
+	}
+
+	ak := &MuSig2AggregateKey{
+		publicKeys:   append([]*PublicKey{}, publicKeys...),
+		coefficients: make([]*scalar.Scalar, 0, len(publicKeys)),
+	}
+	points := make([]*curve.RistrettoPoint, 0, len(publicKeys))
+	for _, pk := range publicKeys {
+		st := &SigningTranscript{
+			t: t.Clone(),
+		}
+		st.commitPoint(musig2PkChoiceLabel, &pk.compressed)
+		ak.coefficients = append(ak.coefficients, st.challengeScalar(musig2PkChoiceLabel))
+		points = append(points, pk.point)
+	}
+
+	var aggPoint curve.RistrettoPoint
+	aggPoint.MultiscalarMulVartime(ak.coefficients, points)
+	if aggPoint.IsIdentity() {
+		return nil, fmt.Errorf("sr25519: MuSig2 aggregate public key is the identity")
+	}
+	ak.pk = newPublicKeyFromPoint(&aggPoint)
+
+	return ak, nil
+}
+
+type musig2State int
+
+const (
+	musig2StateCommit musig2State = iota
+	musig2StateSign
+	musig2StateSigned
+)
+
+// MuSig2Session is a single MuSig2 signing session for one signer.  A
+// session MUST NOT be reused to sign more than one message.
+//
+// The protocol proceeds as follows:
+//
+//  1. Each signer creates a session with MuSig2AggregateKey.NewSession,
+//     and broadcasts PublicNonce.
+//  2. Each signer passes every signer's public nonce to SetPublicNonces,
+//     then broadcasts the output of PartialSign.
+//  3. Any signer combines the partial signatures with Aggregate.
+type MuSig2Session struct {
+	aggKey     *MuSig2AggregateKey
+	transcript *SigningTranscript
+	index      int
+	key        *scalar.Scalar
+
+	r1, r2      *scalar.Scalar
+	publicNonce [MuSig2PublicNonceSize]byte
+
+	publicNonces [][2]*curve.RistrettoPoint
+	b, c         *scalar.Scalar
+	rCompressed  curve.CompressedRistretto
+
+	state musig2State
+}
+
+// NewSession creates a new MuSig2 signing session over transcript, for
+// the signer with the key pair kp.  If rng is nil, crypto/rand.Reader
+// will be used.
+func (ak *MuSig2AggregateKey) NewSession(rng io.Reader, kp *KeyPair, transcript *SigningTranscript) (*MuSig2Session, error) {
+	if kp == nil || kp.sk == nil || kp.pk == nil {
+		return nil, fmt.Errorf("sr25519: uninitialized MuSig2 key pair")
+	}
+	idx := ak.index(kp.pk)
+	if idx < 0 {
+		return nil, fmt.Errorf("sr25519: key pair is not a MuSig2 signer")
+	}
+
+	// The nonces are derived from the transcript, the signer's secret
+	// nonce seed and fresh entropy.  Unlike regular signing, MuSig2
+	// nonces MUST NOT be deterministic, so rng is always mixed in.
+	t := transcript.clone()
+	t.protoName(musig2NonceLabel)
+	t.commitPoint(aLabel, &ak.pk.compressed)
+	nonceSeeds := [][]byte{kp.sk.nonce[:], kp.pk.compressed[:]}
+
+	r1, err := t.witnessScalar(musig2WitnessR1Label, nonceSeeds, rng)
+	if err != nil {
+		return nil, fmt.Errorf("sr25519: failed to generate MuSig2 nonce: %w", err)
+	}
+	r2, err := t.witnessScalar(musig2WitnessR2Label, nonceSeeds, rng)
+	if err != nil {
+		return nil, fmt.Errorf("sr25519: failed to generate MuSig2 nonce: %w", err)
+	}
+
+	s := &MuSig2Session{
+		aggKey:     ak,
+		transcript: transcript.clone(),
+		index:      idx,
+		key:        scalar.New().Set(kp.sk.key),
+		r1:         r1,
+		r2:         r2,
+	}
+
+	var (
+		R          curve.RistrettoPoint
+		compressed curve.CompressedRistretto
+	)
+	compressed.SetRistrettoPoint(R.MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, r1))
+	copy(s.publicNonce[:32], compressed[:])
+	compressed.SetRistrettoPoint(R.MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, r2))
+	copy(s.publicNonce[32:], compressed[:])
+
+	return s, nil
+}
+
+// PublicNonce returns the signer's public nonce, to be sent to all of
+// the other signers.
+func (s *MuSig2Session) PublicNonce() []byte {
+	return append([]byte{}, s.publicNonce[:]...)
+}
+
+// SetPublicNonces sets the public nonces of all of the signers, in the
+// same order as the aggregate key's public keys.  This signer's own
+// nonce must be included.
+func (s *MuSig2Session) SetPublicNonces(publicNonces [][]byte) error {
+	if s.state != musig2StateCommit {
+		return fmt.Errorf("sr25519: MuSig2 public nonces already set")
+	}
+	if l, n := len(publicNonces), len(s.aggKey.publicKeys); l != n {
+		return fmt.Errorf("sr25519: invalid number of MuSig2 public nonces: %d (expected %d)", l, n)
+	}
+
+	var (
+		nonces    = make([][2]*curve.RistrettoPoint, 0, len(publicNonces))
+		aggNonces [2]curve.RistrettoPoint
+	)
+	aggNonces[0].Identity()
+	aggNonces[1].Identity()
+	for i, b := range publicNonces {
+		if l := len(b); l != MuSig2PublicNonceSize {
+			return fmt.Errorf("sr25519: bad MuSig2 public nonce size: %d (signer %d)", l, i)
+		}
+		if i == s.index && !bytes.Equal(b, s.publicNonce[:]) {
+			return fmt.Errorf("sr25519: MuSig2 public nonce mismatch for own signer")
+		}
+
+		var nonce [2]*curve.RistrettoPoint
+		for j := range nonce {
+			var compressed curve.CompressedRistretto
+			if _, err := compressed.SetBytes(b[j*32 : (j+1)*32]); err != nil {
+				return fmt.Errorf("sr25519: failed to deserialize MuSig2 public nonce (signer %d): %w", i, err)
+			}
+			nonce[j] = curve.NewRistrettoPoint()
+			if _, err := nonce[j].SetCompressed(&compressed); err != nil {
+				return fmt.Errorf("sr25519: failed to decompress MuSig2 public nonce (signer %d): %w", i, err)
+			}
+			aggNonces[j].Add(&aggNonces[j], nonce[j])
+		}
+		nonces = append(nonces, nonce)
+	}
+
+	var r1Compressed, r2Compressed curve.CompressedRistretto
+	r1Compressed.SetRistrettoPoint(&aggNonces[0])
+	r2Compressed.SetRistrettoPoint(&aggNonces[1])
+
+	t := s.transcript.clone()
+	t.protoName(musig2NonceLabel)
+	t.commitPoint(aLabel, &s.aggKey.pk.compressed)
+	t.commitPoint(musig2R1Label, &r1Compressed)
+	t.commitPoint(musig2R2Label, &r2Compressed)
+	s.b = t.challengeScalar(musig2BLabel)
+
+	var R curve.RistrettoPoint
+	R.Mul(&aggNonces[1], s.b)
+	R.Add(&R, &aggNonces[0])
+	s.rCompressed.SetRistrettoPoint(&R)
+
+	sig := Signature{
+		rCompressed: s.rCompressed,
+	}
+	s.c = deriveVerifyChallengeScalar(s.aggKey.pk, s.transcript, &sig)
+	s.publicNonces = nonces
+	s.state = musig2StateSign
+
+	return nil
+}
+
+// PartialSign returns this signer's partial signature.  It may only be
+// called once per session, after SetPublicNonces, and erases the secret
+// nonces from the session.
+func (s *MuSig2Session) PartialSign() ([]byte, error) {
+	switch s.state {
+	case musig2StateCommit:
+		return nil, fmt.Errorf("sr25519: MuSig2 public nonces not set")
+	case musig2StateSigned:
+		return nil, fmt.Errorf("sr25519: MuSig2 session already signed")
+	}
+
+	// s_i = r_1 + b * r_2 + c * a_i * x_i
+	partial := scalar.New().Mul(s.c, s.aggKey.coefficients[s.index])
+	partial.Mul(partial, s.key)
+	tmp := scalar.New().Mul(s.b, s.r2)
+	partial.Add(partial, tmp)
+	partial.Add(partial, s.r1)
+
+	s.r1.Zero()
+	s.r2.Zero()
+	s.key.Zero()
+	s.state = musig2StateSigned
+
+	return partial.MarshalBinary()
+}
+
+// VerifyPartialSignature verifies the partial signature produced by
+// the i-th signer.
+func (s *MuSig2Session) VerifyPartialSignature(i int, partialSignature []byte) bool {
+	if s.state == musig2StateCommit || i < 0 || i >= len(s.publicNonces) {
+		return false
+	}
+
+	partial, err := scalar.NewFromCanonicalBytes(partialSignature)
+	if err != nil {
+		return false
+	}
+	return s.verifyPartial(i, partial)
+}
+
+func (s *MuSig2Session) verifyPartial(i int, partial *scalar.Scalar) bool {
+	// s_i * B == R_{i,1} + b * R_{i,2} + (c * a_i) * X_i
+	ca := scalar.New().Mul(s.c, s.aggKey.coefficients[i])
+
+	var lhs, rhs curve.RistrettoPoint
+	lhs.MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, partial)
+	rhs.MultiscalarMulVartime(
+		[]*scalar.Scalar{scalar.New().One(), s.b, ca},
+		[]*curve.RistrettoPoint{s.publicNonces[i][0], s.publicNonces[i][1], s.aggKey.publicKeys[i].point},
+	)
+
+	return lhs.Equal(&rhs) == 1
+}
+
+// Aggregate verifies and combines the partial signatures of all of the
+// signers, in the same order as the aggregate key's public keys, into
+// a signature that verifies against the aggregate public key.
+func (s *MuSig2Session) Aggregate(partialSignatures [][]byte) (*Signature, error) {
+	if s.state == musig2StateCommit {
+		return nil, fmt.Errorf("sr25519: MuSig2 public nonces not set")
+	}
+	if l, n := len(partialSignatures), len(s.publicNonces); l != n {
+		return nil, fmt.Errorf("sr25519: invalid number of MuSig2 partial signatures: %d (expected %d)", l, n)
+	}
+
+	sum := scalar.New()
+	for i, b := range partialSignatures {
+		partial, err := scalar.NewFromCanonicalBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("sr25519: failed to deserialize MuSig2 partial signature (signer %d): %w", i, err)
+		}
+		if !s.verifyPartial(i, partial) {
+			return nil, fmt.Errorf("sr25519: invalid MuSig2 partial signature (signer %d)", i)
+		}
+		sum.Add(sum, partial)
+	}
+
+	return &Signature{
+		rCompressed: s.rCompressed,
+		s:           sum,
+	}, nil
+}
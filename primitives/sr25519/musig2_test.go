@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package sr25519
+
+import (
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+)
+
+func TestMuSig2(t *testing.T) {
+	signingCtx := NewSigningContext([]byte("test MuSig2"))
+	msg := []byte("The roots of education are bitter, but the fruit is sweet.")
+
+	for _, n := range []int{1, 2, 5} {
+		n := n
+		t.Run("Sign", func(t *testing.T) {
+			kps, ak := testMuSig2Keys(t, n)
+			sessions := testMuSig2Sessions(t, ak, kps, signingCtx.NewTranscriptBytes(msg))
+
+			var partials [][]byte
+			for i, s := range sessions {
+				partial, err := s.PartialSign()
+				if err != nil {
+					t.Fatalf("PartialSign(%d): %v", i, err)
+				}
+				if l := len(partial); l != MuSig2PartialSignatureSize {
+					t.Fatalf("invalid partial signature length: %v", l)
+				}
+				partials = append(partials, partial)
+			}
+
+			for i, s := range sessions {
+				for j, partial := range partials {
+					if !s.VerifyPartialSignature(j, partial) {
+						t.Fatalf("session %d: VerifyPartialSignature(%d): false", i, j)
+					}
+				}
+			}
+
+			sig, err := sessions[0].Aggregate(partials)
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if !ak.PublicKey().Verify(signingCtx.NewTranscriptBytes(msg), sig) {
+				t.Fatalf("Verify(aggregate): false")
+			}
+			if ak.PublicKey().Verify(signingCtx.NewTranscriptBytes([]byte("wrong message")), sig) {
+				t.Fatalf("Verify(aggregate, wrong message): true")
+			}
+
+			// Round trip the signature through the serialized form.
+			b, err := sig.MarshalBinary()
+			if err != nil {
+				t.Fatalf("sig.MarshalBinary: %v", err)
+			}
+			sig2, err := NewSignatureFromBytes(b)
+			if err != nil {
+				t.Fatalf("NewSignatureFromBytes: %v", err)
+			}
+			if !ak.PublicKey().Verify(signingCtx.NewTranscriptBytes(msg), sig2) {
+				t.Fatalf("Verify(deserialized aggregate): false")
+			}
+		})
+	}
+
+	t.Run("BadPartialSignature", func(t *testing.T) {
+		kps, ak := testMuSig2Keys(t, 3)
+		sessions := testMuSig2Sessions(t, ak, kps, signingCtx.NewTranscriptBytes(msg))
+
+		var partials [][]byte
+		for i, s := range sessions {
+			partial, err := s.PartialSign()
+			if err != nil {
+				t.Fatalf("PartialSign(%d): %v", i, err)
+			}
+			partials = append(partials, partial)
+		}
+
+		if sessions[0].VerifyPartialSignature(1, partials[2]) {
+			t.Fatalf("VerifyPartialSignature(wrong signer): true")
+		}
+
+		partials[1][0] ^= 0x01
+		if sessions[0].VerifyPartialSignature(1, partials[1]) {
+			t.Fatalf("VerifyPartialSignature(corrupted): true")
+		}
+		if _, err := sessions[0].Aggregate(partials); err == nil {
+			t.Fatalf("Aggregate(corrupted): expected error")
+		}
+	})
+
+	t.Run("State", func(t *testing.T) {
+		kps, ak := testMuSig2Keys(t, 2)
+		transcript := signingCtx.NewTranscriptBytes(msg)
+
+		s, err := ak.NewSession(nil, kps[0], transcript)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		if _, err = s.PartialSign(); err == nil {
+			t.Fatalf("PartialSign(no nonces): expected error")
+		}
+		if _, err = s.Aggregate(nil); err == nil {
+			t.Fatalf("Aggregate(no nonces): expected error")
+		}
+
+		s2, err := ak.NewSession(nil, kps[1], transcript)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		if err = s.SetPublicNonces([][]byte{s2.PublicNonce(), s2.PublicNonce()}); err == nil {
+			t.Fatalf("SetPublicNonces(wrong own nonce): expected error")
+		}
+		if err = s.SetPublicNonces([][]byte{s.PublicNonce()}); err == nil {
+			t.Fatalf("SetPublicNonces(too few): expected error")
+		}
+
+		nonces := [][]byte{s.PublicNonce(), s2.PublicNonce()}
+		if err = s.SetPublicNonces(nonces); err != nil {
+			t.Fatalf("SetPublicNonces: %v", err)
+		}
+		if err = s.SetPublicNonces(nonces); err == nil {
+			t.Fatalf("SetPublicNonces(again): expected error")
+		}
+		if _, err = s.PartialSign(); err != nil {
+			t.Fatalf("PartialSign: %v", err)
+		}
+		if _, err = s.PartialSign(); err == nil {
+			t.Fatalf("PartialSign(again): expected error")
+		}
+
+		// Sessions must not produce the same nonce twice.
+		s3, err := ak.NewSession(nil, kps[0], transcript)
+		if err != nil {
+			t.Fatalf("NewSession: %v", err)
+		}
+		if string(s3.PublicNonce()) == string(s.PublicNonce()) {
+			t.Fatalf("NewSession: public nonce reused")
+		}
+	})
+
+	t.Run("KeyAggregation", func(t *testing.T) {
+		_, ak := testMuSig2Keys(t, 3)
+
+		pks := ak.PublicKeys()
+		if _, err := NewMuSig2AggregateKey(nil); err == nil {
+			t.Fatalf("NewMuSig2AggregateKey(nil): expected error")
+		}
+		if _, err := NewMuSig2AggregateKey([]*PublicKey{pks[0], pks[1], pks[0]}); err == nil {
+			t.Fatalf("NewMuSig2AggregateKey(duplicate): expected error")
+		}
+
+		// The order of the keys is significant.
+		ak2, err := NewMuSig2AggregateKey([]*PublicKey{pks[2], pks[1], pks[0]})
+		if err != nil {
+			t.Fatalf("NewMuSig2AggregateKey: %v", err)
+		}
+		if ak2.PublicKey().Equal(ak.PublicKey()) {
+			t.Fatalf("NewMuSig2AggregateKey: aggregate key independent of order")
+		}
+
+		// The aggregate key is not the plain sum of the public keys.
+		sum := curve.NewRistrettoPoint().Identity()
+		for _, pk := range pks {
+			sum.Add(sum, pk.point)
+		}
+		if newPublicKeyFromPoint(sum).Equal(ak.PublicKey()) {
+			t.Fatalf("NewMuSig2AggregateKey: aggregate key is the sum of the public keys")
+		}
+
+		other, err := GenerateKeyPair(nil)
+		if err != nil {
+			t.Fatalf("GenerateKeyPair: %v", err)
+		}
+		if _, err = ak.NewSession(nil, other, NewSigningContext(nil).NewTranscriptBytes(nil)); err == nil {
+			t.Fatalf("NewSession(non-signer): expected error")
+		}
+	})
+}
+
+func testMuSig2Keys(t *testing.T, n int) ([]*KeyPair, *MuSig2AggregateKey) {
+	var (
+		kps []*KeyPair
+		pks []*PublicKey
+	)
+	for i := 0; i < n; i++ {
+		kp, err := GenerateKeyPair(nil)
+		if err != nil {
+			t.Fatalf("GenerateKeyPair: %v", err)
+		}
+		kps = append(kps, kp)
+		pks = append(pks, kp.PublicKey())
+	}
+
+	ak, err := NewMuSig2AggregateKey(pks)
+	if err != nil {
+		t.Fatalf("NewMuSig2AggregateKey: %v", err)
+	}
+
+	return kps, ak
+}
+
+func testMuSig2Sessions(t *testing.T, ak *MuSig2AggregateKey, kps []*KeyPair, transcript *SigningTranscript) []*MuSig2Session {
+	var (
+		sessions []*MuSig2Session
+		nonces   [][]byte
+	)
+	for i, kp := range kps {
+		s, err := ak.NewSession(nil, kp, transcript)
+		if err != nil {
+			t.Fatalf("NewSession(%d): %v", i, err)
+		}
+		sessions = append(sessions, s)
+		nonces = append(nonces, s.PublicNonce())
+	}
+
+	for i, s := range sessions {
+		if err := s.SetPublicNonces(nonces); err != nil {
+			t.Fatalf("SetPublicNonces(%d): %v", i, err)
+		}
+	}
+
+	return sessions
+}
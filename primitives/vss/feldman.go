@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package vss
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+)
+
+const pointSize = 32
+
+// EdwardsFeldmanCommitment is a Feldman commitment to a polynomial, over
+// the Edwards group.
+type EdwardsFeldmanCommitment struct {
+	points []curve.EdwardsPoint
+}
+
+// Threshold returns the threshold of the committed polynomial.
+func (c *EdwardsFeldmanCommitment) Threshold() int {
+	return len(c.points)
+}
+
+// Evaluate returns the commitment to the share with the given index (ie:
+// the public key corresponding to the share).
+func (c *EdwardsFeldmanCommitment) Evaluate(index uint32) *curve.EdwardsPoint {
+	var p curve.EdwardsPoint
+	return p.MultiscalarMulVartime(indexPowers(index, len(c.points)), c.pointPtrs())
+}
+
+// Verify returns true iff the share is consistent with the commitment.
+func (c *EdwardsFeldmanCommitment) Verify(share *Share) bool {
+	if share.Index == 0 || len(c.points) == 0 {
+		return false
+	}
+
+	// sum([i^j]C_j) - [s_i]B = 0
+	var negValue scalar.Scalar
+	scalars := append(indexPowers(share.Index, len(c.points)), negValue.Neg(&share.Value))
+	points := append(c.pointPtrs(), curve.ED25519_BASEPOINT_POINT)
+
+	var p curve.EdwardsPoint
+	return p.MultiscalarMulVartime(scalars, points).IsIdentity()
+}
+
+// Refresh updates the commitment with the commitment to a zero polynomial
+// used to refresh the shares.
+func (c *EdwardsFeldmanCommitment) Refresh(delta *EdwardsFeldmanCommitment) error {
+	if len(delta.points) != len(c.points) {
+		return fmt.Errorf("vss: mismatched commitment threshold")
+	}
+	if !delta.points[0].IsIdentity() {
+		return fmt.Errorf("vss: refresh commitment has non-zero constant term")
+	}
+	for i := range c.points {
+		c.points[i].Add(&c.points[i], &delta.points[i])
+	}
+	return nil
+}
+
+// MarshalBinary encodes c into binary form and returns the result.
+func (c *EdwardsFeldmanCommitment) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(c.points)*pointSize)
+	for i := range c.points {
+		var compressed curve.CompressedEdwardsY
+		compressed.SetEdwardsPoint(&c.points[i])
+		b = append(b, compressed[:]...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a binary marshaled commitment into c.  Points
+// that are not canonically encoded, or that have a torsion component
+// are rejected.
+func (c *EdwardsFeldmanCommitment) UnmarshalBinary(data []byte) error {
+	if l := len(data); l == 0 || l%pointSize != 0 {
+		return fmt.Errorf("vss: bad commitment length: %d", l)
+	}
+
+	points := make([]curve.EdwardsPoint, len(data)/pointSize)
+	for i := range points {
+		var compressed curve.CompressedEdwardsY
+		if _, err := compressed.SetBytes(data[i*pointSize : (i+1)*pointSize]); err != nil {
+			return fmt.Errorf("vss: failed to deserialize commitment: %w", err)
+		}
+		if !compressed.IsCanonicalVartime() {
+			return fmt.Errorf("vss: non-canonical commitment")
+		}
+		if _, err := points[i].SetCompressedY(&compressed); err != nil {
+			return fmt.Errorf("vss: failed to decompress commitment: %w", err)
+		}
+		if !points[i].IsTorsionFree() {
+			return fmt.Errorf("vss: commitment not in prime-order subgroup")
+		}
+	}
+	c.points = points
+
+	return nil
+}
+
+func (c *EdwardsFeldmanCommitment) pointPtrs() []*curve.EdwardsPoint {
+	ptrs := make([]*curve.EdwardsPoint, 0, len(c.points)+1)
+	for i := range c.points {
+		ptrs = append(ptrs, &c.points[i])
+	}
+	return ptrs
+}
+
+// NewEdwardsFeldmanCommitment creates a Feldman commitment to p over the
+// Edwards group.
+func NewEdwardsFeldmanCommitment(p *Polynomial) *EdwardsFeldmanCommitment {
+	c := &EdwardsFeldmanCommitment{
+		points: make([]curve.EdwardsPoint, len(p.coefficients)),
+	}
+	for i := range p.coefficients {
+		c.points[i].MulBasepoint(curve.ED25519_BASEPOINT_TABLE, p.coefficients[i])
+	}
+	return c
+}
+
+// RistrettoFeldmanCommitment is a Feldman commitment to a polynomial, over
+// the ristretto255 group.
+type RistrettoFeldmanCommitment struct {
+	points []curve.RistrettoPoint
+}
+
+// Threshold returns the threshold of the committed polynomial.
+func (c *RistrettoFeldmanCommitment) Threshold() int {
+	return len(c.points)
+}
+
+// Evaluate returns the commitment to the share with the given index (ie:
+// the public key corresponding to the share).
+func (c *RistrettoFeldmanCommitment) Evaluate(index uint32) *curve.RistrettoPoint {
+	var p curve.RistrettoPoint
+	return p.MultiscalarMulVartime(indexPowers(index, len(c.points)), c.pointPtrs())
+}
+
+// Verify returns true iff the share is consistent with the commitment.
+func (c *RistrettoFeldmanCommitment) Verify(share *Share) bool {
+	if share.Index == 0 || len(c.points) == 0 {
+		return false
+	}
+
+	// sum([i^j]C_j) - [s_i]B = 0
+	var negValue scalar.Scalar
+	scalars := append(indexPowers(share.Index, len(c.points)), negValue.Neg(&share.Value))
+	points := append(c.pointPtrs(), curve.RISTRETTO_BASEPOINT_POINT)
+
+	var p curve.RistrettoPoint
+	return p.MultiscalarMulVartime(scalars, points).IsIdentity()
+}
+
+// Refresh updates the commitment with the commitment to a zero polynomial
+// used to refresh the shares.
+func (c *RistrettoFeldmanCommitment) Refresh(delta *RistrettoFeldmanCommitment) error {
+	if len(delta.points) != len(c.points) {
+		return fmt.Errorf("vss: mismatched commitment threshold")
+	}
+	if !delta.points[0].IsIdentity() {
+		return fmt.Errorf("vss: refresh commitment has non-zero constant term")
+	}
+	for i := range c.points {
+		c.points[i].Add(&c.points[i], &delta.points[i])
+	}
+	return nil
+}
+
+// MarshalBinary encodes c into binary form and returns the result.
+func (c *RistrettoFeldmanCommitment) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(c.points)*pointSize)
+	for i := range c.points {
+		var compressed curve.CompressedRistretto
+		compressed.SetRistrettoPoint(&c.points[i])
+		b = append(b, compressed[:]...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a binary marshaled commitment into c.
+func (c *RistrettoFeldmanCommitment) UnmarshalBinary(data []byte) error {
+	if l := len(data); l == 0 || l%pointSize != 0 {
+		return fmt.Errorf("vss: bad commitment length: %d", l)
+	}
+
+	points := make([]curve.RistrettoPoint, len(data)/pointSize)
+	for i := range points {
+		var compressed curve.CompressedRistretto
+		if _, err := compressed.SetBytes(data[i*pointSize : (i+1)*pointSize]); err != nil {
+			return fmt.Errorf("vss: failed to deserialize commitment: %w", err)
+		}
+		if _, err := points[i].SetCompressed(&compressed); err != nil {
+			return fmt.Errorf("vss: failed to decompress commitment: %w", err)
+		}
+	}
+	c.points = points
+
+	return nil
+}
+
+func (c *RistrettoFeldmanCommitment) pointPtrs() []*curve.RistrettoPoint {
+	ptrs := make([]*curve.RistrettoPoint, 0, len(c.points)+1)
+	for i := range c.points {
+		ptrs = append(ptrs, &c.points[i])
+	}
+	return ptrs
+}
+
+// NewRistrettoFeldmanCommitment creates a Feldman commitment to p over the
+// ristretto255 group.
+func NewRistrettoFeldmanCommitment(p *Polynomial) *RistrettoFeldmanCommitment {
+	c := &RistrettoFeldmanCommitment{
+		points: make([]curve.RistrettoPoint, len(p.coefficients)),
+	}
+	for i := range p.coefficients {
+		c.points[i].MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, p.coefficients[i])
+	}
+	return c
+}
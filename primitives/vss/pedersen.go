@@ -0,0 +1,265 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package vss
+
+import (
+	"crypto"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/primitives/h2c"
+)
+
+var (
+	pedersenDomainSeparator = []byte("curve25519-voi/vss: Pedersen generator")
+
+	edwardsGeneratorOnce sync.Once
+	edwardsGenerator     curve.EdwardsPoint
+
+	ristrettoGeneratorOnce sync.Once
+	ristrettoGenerator     curve.RistrettoPoint
+)
+
+// EdwardsPedersenGenerator returns the second generator H used for
+// Pedersen commitments over the Edwards group, derived via hash-to-curve
+// such that its discrete logarithm is unknown.
+func EdwardsPedersenGenerator() *curve.EdwardsPoint {
+	edwardsGeneratorOnce.Do(func() {
+		p, err := h2c.Edwards25519_XMD_SHA512_ELL2_RO(pedersenDomainSeparator, []byte("H"))
+		if err != nil {
+			panic("vss: failed to derive Pedersen generator: " + err.Error())
+		}
+		edwardsGenerator.Set(p)
+	})
+	return curve.NewEdwardsPoint().Set(&edwardsGenerator)
+}
+
+// RistrettoPedersenGenerator returns the second generator H used for
+// Pedersen commitments over the ristretto255 group, derived via
+// hash-to-curve such that its discrete logarithm is unknown.
+func RistrettoPedersenGenerator() *curve.RistrettoPoint {
+	ristrettoGeneratorOnce.Do(func() {
+		p, err := h2c.Ristretto255_XMD_R255MAP_RO(crypto.SHA512, pedersenDomainSeparator, []byte("H"))
+		if err != nil {
+			panic("vss: failed to derive Pedersen generator: " + err.Error())
+		}
+		ristrettoGenerator.Set(p)
+	})
+	return curve.NewRistrettoPoint().Set(&ristrettoGenerator)
+}
+
+// PedersenShare is a Pedersen verifiable secret share.
+type PedersenShare struct {
+	// Index is the non-zero x-coordinate of the share.
+	Index uint32
+
+	// Value is the share value, f(Index).
+	Value scalar.Scalar
+
+	// Blinding is the share blinding factor, r(Index).
+	Blinding scalar.Scalar
+}
+
+// Share returns the Shamir secret share corresponding to s.
+func (s *PedersenShare) Share() *Share {
+	return &Share{
+		Index: s.Index,
+		Value: s.Value,
+	}
+}
+
+// PedersenSplit splits secret into n Pedersen shares, any threshold of
+// which can be used to reconstruct the secret.  The value and blinding
+// polynomials are returned so that the caller may create commitments to
+// them, and should be zeroized after use.
+func PedersenSplit(rand io.Reader, secret *scalar.Scalar, threshold, n int) ([]*PedersenShare, *Polynomial, *Polynomial, error) {
+	shares, value, err := Split(rand, secret, threshold, n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var blindingSecret scalar.Scalar
+	if _, err = blindingSecret.SetRandom(rand); err != nil {
+		return nil, nil, nil, fmt.Errorf("vss: failed to generate blinding secret: %w", err)
+	}
+	blinding, err := NewRandomPolynomial(rand, &blindingSecret, threshold)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pShares := make([]*PedersenShare, 0, n)
+	for _, share := range shares {
+		pShare := &PedersenShare{
+			Index: share.Index,
+			Value: share.Value,
+		}
+		pShare.Blinding.Set(blinding.Evaluate(share.Index))
+		pShares = append(pShares, pShare)
+	}
+
+	return pShares, value, blinding, nil
+}
+
+// EdwardsPedersenCommitment is a Pedersen commitment to a polynomial, over
+// the Edwards group.
+type EdwardsPedersenCommitment struct {
+	points []curve.EdwardsPoint
+}
+
+// Threshold returns the threshold of the committed polynomial.
+func (c *EdwardsPedersenCommitment) Threshold() int {
+	return len(c.points)
+}
+
+// Verify returns true iff the share is consistent with the commitment.
+func (c *EdwardsPedersenCommitment) Verify(share *PedersenShare) bool {
+	if share.Index == 0 || len(c.points) == 0 {
+		return false
+	}
+
+	// sum([i^j]C_j) - [s_i]B - [t_i]H = 0
+	var negValue, negBlinding scalar.Scalar
+	scalars := append(indexPowers(share.Index, len(c.points)), negValue.Neg(&share.Value), negBlinding.Neg(&share.Blinding))
+	points := make([]*curve.EdwardsPoint, 0, len(c.points)+2)
+	for i := range c.points {
+		points = append(points, &c.points[i])
+	}
+	points = append(points, curve.ED25519_BASEPOINT_POINT, EdwardsPedersenGenerator())
+
+	var p curve.EdwardsPoint
+	return p.MultiscalarMulVartime(scalars, points).IsIdentity()
+}
+
+// MarshalBinary encodes c into binary form and returns the result.
+func (c *EdwardsPedersenCommitment) MarshalBinary() ([]byte, error) {
+	return (&EdwardsFeldmanCommitment{points: c.points}).MarshalBinary()
+}
+
+// UnmarshalBinary decodes a binary marshaled commitment into c.  Points
+// that are not canonically encoded, or that have a torsion component
+// are rejected.
+func (c *EdwardsPedersenCommitment) UnmarshalBinary(data []byte) error {
+	var tmp EdwardsFeldmanCommitment
+	if err := tmp.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	c.points = tmp.points
+	return nil
+}
+
+// NewEdwardsPedersenCommitment creates a Pedersen commitment to the value
+// and blinding polynomials over the Edwards group.
+func NewEdwardsPedersenCommitment(value, blinding *Polynomial) (*EdwardsPedersenCommitment, error) {
+	if value.Threshold() != blinding.Threshold() {
+		return nil, fmt.Errorf("vss: mismatched polynomial thresholds")
+	}
+
+	// C_j = [a_j]B + [b_j]H
+	H := EdwardsPedersenGenerator()
+	c := &EdwardsPedersenCommitment{
+		points: make([]curve.EdwardsPoint, value.Threshold()),
+	}
+	for i := range c.points {
+		var bH curve.EdwardsPoint
+		c.points[i].MulBasepoint(curve.ED25519_BASEPOINT_TABLE, value.coefficients[i])
+		c.points[i].Add(&c.points[i], bH.Mul(H, blinding.coefficients[i]))
+	}
+
+	return c, nil
+}
+
+// RistrettoPedersenCommitment is a Pedersen commitment to a polynomial,
+// over the ristretto255 group.
+type RistrettoPedersenCommitment struct {
+	points []curve.RistrettoPoint
+}
+
+// Threshold returns the threshold of the committed polynomial.
+func (c *RistrettoPedersenCommitment) Threshold() int {
+	return len(c.points)
+}
+
+// Verify returns true iff the share is consistent with the commitment.
+func (c *RistrettoPedersenCommitment) Verify(share *PedersenShare) bool {
+	if share.Index == 0 || len(c.points) == 0 {
+		return false
+	}
+
+	// sum([i^j]C_j) - [s_i]B - [t_i]H = 0
+	var negValue, negBlinding scalar.Scalar
+	scalars := append(indexPowers(share.Index, len(c.points)), negValue.Neg(&share.Value), negBlinding.Neg(&share.Blinding))
+	points := make([]*curve.RistrettoPoint, 0, len(c.points)+2)
+	for i := range c.points {
+		points = append(points, &c.points[i])
+	}
+	points = append(points, curve.RISTRETTO_BASEPOINT_POINT, RistrettoPedersenGenerator())
+
+	var p curve.RistrettoPoint
+	return p.MultiscalarMulVartime(scalars, points).IsIdentity()
+}
+
+// MarshalBinary encodes c into binary form and returns the result.
+func (c *RistrettoPedersenCommitment) MarshalBinary() ([]byte, error) {
+	return (&RistrettoFeldmanCommitment{points: c.points}).MarshalBinary()
+}
+
+// UnmarshalBinary decodes a binary marshaled commitment into c.
+func (c *RistrettoPedersenCommitment) UnmarshalBinary(data []byte) error {
+	var tmp RistrettoFeldmanCommitment
+	if err := tmp.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	c.points = tmp.points
+	return nil
+}
+
+// NewRistrettoPedersenCommitment creates a Pedersen commitment to the
+// value and blinding polynomials over the ristretto255 group.
+func NewRistrettoPedersenCommitment(value, blinding *Polynomial) (*RistrettoPedersenCommitment, error) {
+	if value.Threshold() != blinding.Threshold() {
+		return nil, fmt.Errorf("vss: mismatched polynomial thresholds")
+	}
+
+	// C_j = [a_j]B + [b_j]H
+	H := RistrettoPedersenGenerator()
+	c := &RistrettoPedersenCommitment{
+		points: make([]curve.RistrettoPoint, value.Threshold()),
+	}
+	for i := range c.points {
+		var bH curve.RistrettoPoint
+		c.points[i].MulBasepoint(curve.RISTRETTO_BASEPOINT_TABLE, value.coefficients[i])
+		c.points[i].Add(&c.points[i], bH.Mul(H, blinding.coefficients[i]))
+	}
+
+	return c, nil
+}
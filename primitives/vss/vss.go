@@ -0,0 +1,286 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package vss implements Shamir secret sharing over scalar.Scalar, along
+// with Feldman and Pedersen verifiable secret sharing over the Edwards
+// and ristretto255 groups.
+//
+// Shares may be proactively refreshed (re-randomized while preserving the
+// secret and threshold), and reshared to a new set of share holders with
+// a potentially different threshold.
+package vss
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/polynomial"
+)
+
+// Share is a Shamir secret share.
+type Share struct {
+	// Index is the non-zero x-coordinate of the share.
+	Index uint32
+
+	// Value is the share value, f(Index).
+	Value scalar.Scalar
+}
+
+var (
+	_ Commitment = (*EdwardsFeldmanCommitment)(nil)
+	_ Commitment = (*RistrettoFeldmanCommitment)(nil)
+	_ Commitment = (*EdwardsPedersenCommitment)(nil)
+	_ Commitment = (*RistrettoPedersenCommitment)(nil)
+)
+
+// Commitment is a Feldman or Pedersen commitment to a polynomial.
+type Commitment interface {
+	// Threshold returns the threshold of the committed polynomial.
+	Threshold() int
+}
+
+// Polynomial is a secret polynomial over the scalar field.
+type Polynomial struct {
+	coefficients []*scalar.Scalar
+}
+
+// Threshold returns the number of shares required to reconstruct the
+// constant term of p.
+func (p *Polynomial) Threshold() int {
+	return len(p.coefficients)
+}
+
+// Evaluate returns p(x).
+func (p *Polynomial) Evaluate(x uint32) *scalar.Scalar {
+	return polynomial.Evaluate(scalar.NewFromUint64(uint64(x)), p.coefficients)
+}
+
+// Shares returns the shares of p at the indexes 1 through n.
+func (p *Polynomial) Shares(n int) []*Share {
+	shares := make([]*Share, 0, n)
+	for i := 1; i <= n; i++ {
+		share := &Share{
+			Index: uint32(i),
+		}
+		share.Value.Set(p.Evaluate(uint32(i)))
+		shares = append(shares, share)
+	}
+	return shares
+}
+
+// Zeroize clears the coefficients of p.
+func (p *Polynomial) Zeroize() {
+	for i := range p.coefficients {
+		p.coefficients[i].Zero()
+	}
+}
+
+// NewRandomPolynomial returns a random polynomial with the given constant
+// term, such that threshold evaluations are required to reconstruct the
+// constant term.  The randomness is sourced from rand, which will be
+// crypto/rand.Reader if nil.
+func NewRandomPolynomial(rand io.Reader, constant *scalar.Scalar, threshold int) (*Polynomial, error) {
+	if threshold < 1 {
+		return nil, fmt.Errorf("vss: invalid threshold: %d", threshold)
+	}
+
+	p := &Polynomial{
+		coefficients: make([]*scalar.Scalar, 0, threshold),
+	}
+	p.coefficients = append(p.coefficients, scalar.New().Set(constant))
+	for i := 1; i < threshold; i++ {
+		coefficient, err := scalar.New().SetRandom(rand)
+		if err != nil {
+			return nil, fmt.Errorf("vss: failed to generate coefficient: %w", err)
+		}
+		p.coefficients = append(p.coefficients, coefficient)
+	}
+
+	return p, nil
+}
+
+// Split splits secret into n shares, any threshold of which can be used
+// to reconstruct the secret.  The polynomial is returned so that the
+// caller may create commitments to it, and should be zeroized after use.
+func Split(rand io.Reader, secret *scalar.Scalar, threshold, n int) ([]*Share, *Polynomial, error) {
+	if err := checkParams(threshold, n); err != nil {
+		return nil, nil, err
+	}
+
+	p, err := NewRandomPolynomial(rand, secret, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return p.Shares(n), p, nil
+}
+
+// Reconstruct reconstructs the secret from shares.  If fewer than the
+// threshold number of shares are provided, the result will be incorrect.
+func Reconstruct(shares []*Share) (*scalar.Scalar, error) {
+	indexes, err := shareIndexes(shares)
+	if err != nil {
+		return nil, err
+	}
+	xs := make([]*scalar.Scalar, 0, len(indexes))
+	for _, index := range indexes {
+		xs = append(xs, scalar.NewFromUint64(uint64(index)))
+	}
+
+	secret := scalar.New()
+	for i, share := range shares {
+		lambda := polynomial.LagrangeCoefficient(xs, i)
+		secret.Add(secret, lambda.Mul(lambda, &share.Value))
+	}
+
+	return secret, nil
+}
+
+// Refresh proactively refreshes shares, by adding shares of a random
+// polynomial with a zero constant term.  The refreshed shares reconstruct
+// the same secret, but can not be combined with the old shares.  The
+// zero polynomial is returned so that the caller may update commitments.
+//
+// The threshold of the zero polynomial is taken from commitment, the
+// existing commitment to the shared polynomial, so that refreshing the
+// shares never changes the threshold.  Use Reshare to change it.
+//
+// Note: In a deployment where the share holders are distinct parties,
+// each share holder should generate a zero polynomial, and distribute
+// the corresponding shares to the other share holders.
+func Refresh(rand io.Reader, shares []*Share, commitment Commitment) ([]*Share, *Polynomial, error) {
+	if _, err := shareIndexes(shares); err != nil {
+		return nil, nil, err
+	}
+
+	delta, err := NewRandomPolynomial(rand, scalar.New(), commitment.Threshold())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refreshed := make([]*Share, 0, len(shares))
+	for _, share := range shares {
+		newShare := &Share{
+			Index: share.Index,
+		}
+		newShare.Value.Add(&share.Value, delta.Evaluate(share.Index))
+		refreshed = append(refreshed, newShare)
+	}
+
+	return refreshed, delta, nil
+}
+
+// Reshare splits an existing share into n sub-shares for a new set of
+// share holders, any newThreshold of which can reconstruct the secret,
+// once each new share holder combines the sub-shares from at least the
+// old threshold number of old share holders with CombineReshares.
+func Reshare(rand io.Reader, share *Share, newThreshold, n int) ([]*Share, *Polynomial, error) {
+	if share.Index == 0 {
+		return nil, nil, fmt.Errorf("vss: invalid share index: 0")
+	}
+	return Split(rand, &share.Value, newThreshold, n)
+}
+
+// CombineReshares combines the sub-shares received by a new share holder,
+// where subShares[i] was produced by Reshare by the old share holder with
+// index dealers[i].
+func CombineReshares(dealers []uint32, subShares []*Share) (*Share, error) {
+	if len(dealers) != len(subShares) || len(subShares) == 0 {
+		return nil, fmt.Errorf("vss: mismatched dealers and sub-shares")
+	}
+
+	dealerShares := make([]*Share, 0, len(dealers))
+	for i, index := range dealers {
+		if subShares[i].Index != subShares[0].Index {
+			return nil, fmt.Errorf("vss: sub-shares for different indexes")
+		}
+		dealerShares = append(dealerShares, &Share{
+			Index: index,
+			Value: subShares[i].Value,
+		})
+	}
+
+	// The new share is the interpolation of the sub-shares at 0, with
+	// respect to the old share holders' indexes.
+	value, err := Reconstruct(dealerShares)
+	if err != nil {
+		return nil, err
+	}
+
+	share := &Share{
+		Index: subShares[0].Index,
+	}
+	share.Value.Set(value)
+
+	return share, nil
+}
+
+func checkParams(threshold, n int) error {
+	if threshold < 1 || threshold > n {
+		return fmt.Errorf("vss: invalid threshold: %d of %d", threshold, n)
+	}
+	if uint64(n) > uint64(^uint32(0)) {
+		return fmt.Errorf("vss: too many shares: %d", n)
+	}
+	return nil
+}
+
+func shareIndexes(shares []*Share) ([]uint32, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("vss: no shares")
+	}
+
+	seen := make(map[uint32]bool)
+	indexes := make([]uint32, 0, len(shares))
+	for _, share := range shares {
+		if share.Index == 0 {
+			return nil, fmt.Errorf("vss: invalid share index: 0")
+		}
+		if seen[share.Index] {
+			return nil, fmt.Errorf("vss: duplicate share index: %d", share.Index)
+		}
+		seen[share.Index] = true
+		indexes = append(indexes, share.Index)
+	}
+
+	return indexes, nil
+}
+
+// indexPowers returns [1, x, x^2, ..., x^(n-1)].
+func indexPowers(index uint32, n int) []*scalar.Scalar {
+	x := scalar.NewFromUint64(uint64(index))
+	powers := make([]*scalar.Scalar, 0, n)
+	xPow := scalar.One()
+	for i := 0; i < n; i++ {
+		powers = append(powers, scalar.New().Set(xPow))
+		xPow.Mul(xPow, x)
+	}
+	return powers
+}
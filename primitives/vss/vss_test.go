@@ -0,0 +1,291 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package vss
+
+import (
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+)
+
+func TestShamir(t *testing.T) {
+	const (
+		threshold = 3
+		n         = 5
+	)
+	secret := mustRandomScalar(t)
+	shares, p, err := Split(nil, secret, threshold, n)
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+	defer p.Zeroize()
+
+	t.Run("Reconstruct", func(t *testing.T) {
+		for _, subset := range [][]*Share{
+			shares[:3],
+			shares[2:],
+			{shares[4], shares[0], shares[2]},
+			shares,
+		} {
+			recovered, err := Reconstruct(subset)
+			if err != nil {
+				t.Fatalf("Reconstruct: %v", err)
+			}
+			if recovered.Equal(secret) != 1 {
+				t.Fatalf("Reconstruct: mismatch")
+			}
+		}
+
+		recovered, err := Reconstruct(shares[:2])
+		if err != nil {
+			t.Fatalf("Reconstruct: %v", err)
+		}
+		if recovered.Equal(secret) == 1 {
+			t.Fatalf("Reconstruct: recovered secret from too few shares")
+		}
+
+		if _, err = Reconstruct([]*Share{shares[0], shares[0], shares[1]}); err == nil {
+			t.Fatalf("Reconstruct: accepted duplicate shares")
+		}
+		if _, err = Reconstruct([]*Share{{}}); err == nil {
+			t.Fatalf("Reconstruct: accepted zero index")
+		}
+	})
+	t.Run("Feldman", func(t *testing.T) {
+		edwards := NewEdwardsFeldmanCommitment(p)
+		ristretto := NewRistrettoFeldmanCommitment(p)
+
+		var edwards2 EdwardsFeldmanCommitment
+		var ristretto2 RistrettoFeldmanCommitment
+		testRoundTrip(t, edwards, &edwards2)
+		testRoundTrip(t, ristretto, &ristretto2)
+
+		for _, share := range shares {
+			if !edwards2.Verify(share) {
+				t.Fatalf("EdwardsFeldmanCommitment.Verify(%d): failed", share.Index)
+			}
+			if !ristretto2.Verify(share) {
+				t.Fatalf("RistrettoFeldmanCommitment.Verify(%d): failed", share.Index)
+			}
+
+			var expected curve.EdwardsPoint
+			expected.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &share.Value)
+			if edwards2.Evaluate(share.Index).Equal(&expected) != 1 {
+				t.Fatalf("EdwardsFeldmanCommitment.Evaluate(%d): mismatch", share.Index)
+			}
+		}
+
+		// Non-canonical and torsioned points must be rejected.
+		b, _ := edwards.MarshalBinary()
+		var torsioned curve.EdwardsPoint
+		var compressed curve.CompressedEdwardsY
+		compressed.SetEdwardsPoint(torsioned.Add(&edwards.points[0], curve.EIGHT_TORSION[1]))
+		for _, tc := range []struct {
+			name  string
+			point []byte
+		}{
+			{"NonCanonical", testhelpers.MustUnhex(t, "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")},
+			{"Torsioned", compressed[:]},
+		} {
+			bad := append([]byte{}, b...)
+			copy(bad, tc.point)
+			if err := edwards2.UnmarshalBinary(bad); err == nil {
+				t.Fatalf("EdwardsFeldmanCommitment.UnmarshalBinary(%s): accepted invalid point", tc.name)
+			}
+			var pedersen EdwardsPedersenCommitment
+			if err := pedersen.UnmarshalBinary(bad); err == nil {
+				t.Fatalf("EdwardsPedersenCommitment.UnmarshalBinary(%s): accepted invalid point", tc.name)
+			}
+		}
+
+		badShare := &Share{
+			Index: shares[0].Index,
+		}
+		badShare.Value.Add(&shares[0].Value, scalar.One())
+		if edwards.Verify(badShare) || ristretto.Verify(badShare) {
+			t.Fatalf("Verify: accepted invalid share")
+		}
+	})
+	t.Run("Pedersen", func(t *testing.T) {
+		pShares, value, blinding, err := PedersenSplit(nil, secret, threshold, n)
+		if err != nil {
+			t.Fatalf("PedersenSplit: %v", err)
+		}
+		edwards, err := NewEdwardsPedersenCommitment(value, blinding)
+		if err != nil {
+			t.Fatalf("NewEdwardsPedersenCommitment: %v", err)
+		}
+		ristretto, err := NewRistrettoPedersenCommitment(value, blinding)
+		if err != nil {
+			t.Fatalf("NewRistrettoPedersenCommitment: %v", err)
+		}
+
+		var edwards2 EdwardsPedersenCommitment
+		var ristretto2 RistrettoPedersenCommitment
+		testRoundTrip(t, edwards, &edwards2)
+		testRoundTrip(t, ristretto, &ristretto2)
+
+		var plainShares []*Share
+		for _, share := range pShares {
+			if !edwards2.Verify(share) {
+				t.Fatalf("EdwardsPedersenCommitment.Verify(%d): failed", share.Index)
+			}
+			if !ristretto2.Verify(share) {
+				t.Fatalf("RistrettoPedersenCommitment.Verify(%d): failed", share.Index)
+			}
+			plainShares = append(plainShares, share.Share())
+		}
+
+		badShare := *pShares[1]
+		badShare.Blinding.Add(&badShare.Blinding, scalar.One())
+		if edwards.Verify(&badShare) || ristretto.Verify(&badShare) {
+			t.Fatalf("Verify: accepted invalid share")
+		}
+
+		recovered, err := Reconstruct(plainShares[1:4])
+		if err != nil {
+			t.Fatalf("Reconstruct: %v", err)
+		}
+		if recovered.Equal(secret) != 1 {
+			t.Fatalf("Reconstruct: mismatch")
+		}
+
+		H := EdwardsPedersenGenerator()
+		if H.IsSmallOrder() || !H.IsTorsionFree() || H.Equal(curve.ED25519_BASEPOINT_POINT) == 1 {
+			t.Fatalf("EdwardsPedersenGenerator: invalid generator")
+		}
+		if RistrettoPedersenGenerator().Equal(curve.RISTRETTO_BASEPOINT_POINT) == 1 {
+			t.Fatalf("RistrettoPedersenGenerator: invalid generator")
+		}
+	})
+	t.Run("Refresh", func(t *testing.T) {
+		c := NewEdwardsFeldmanCommitment(p)
+		refreshed, delta, err := Refresh(nil, shares, c)
+		if err != nil {
+			t.Fatalf("Refresh: %v", err)
+		}
+		if delta.Threshold() != threshold {
+			t.Fatalf("Refresh: threshold changed: %d", delta.Threshold())
+		}
+
+		recovered, err := Reconstruct(refreshed[1:4])
+		if err != nil {
+			t.Fatalf("Reconstruct: %v", err)
+		}
+		if recovered.Equal(secret) != 1 {
+			t.Fatalf("Reconstruct: mismatch after refresh")
+		}
+
+		// Mixing old and new shares must not reconstruct the secret.
+		recovered, _ = Reconstruct([]*Share{shares[0], refreshed[1], refreshed[2]})
+		if recovered.Equal(secret) == 1 {
+			t.Fatalf("Reconstruct: old and new shares are compatible")
+		}
+
+		if err = c.Refresh(NewEdwardsFeldmanCommitment(delta)); err != nil {
+			t.Fatalf("EdwardsFeldmanCommitment.Refresh: %v", err)
+		}
+		for _, share := range refreshed {
+			if !c.Verify(share) {
+				t.Fatalf("EdwardsFeldmanCommitment.Verify(%d): failed after refresh", share.Index)
+			}
+		}
+		if err = c.Refresh(NewEdwardsFeldmanCommitment(p)); err == nil {
+			t.Fatalf("EdwardsFeldmanCommitment.Refresh: accepted non-zero polynomial")
+		}
+	})
+	t.Run("Reshare", func(t *testing.T) {
+		const (
+			newThreshold = 2
+			newN         = 4
+		)
+
+		// Old share holders 1, 3 and 5 reshare to 4 new share holders.
+		oldCommitment := NewRistrettoFeldmanCommitment(p)
+		dealers := []uint32{1, 3, 5}
+		subShares := make([][]*Share, newN)
+		for _, index := range dealers {
+			s, q, err := Reshare(nil, shares[index-1], newThreshold, newN)
+			if err != nil {
+				t.Fatalf("Reshare: %v", err)
+			}
+
+			// The sub-sharing commitment must commit to the dealer's share.
+			subCommitment := NewRistrettoFeldmanCommitment(q)
+			if subCommitment.points[0].Equal(oldCommitment.Evaluate(index)) != 1 {
+				t.Fatalf("Reshare: sub-sharing does not commit to the share")
+			}
+
+			for j, share := range s {
+				if !subCommitment.Verify(share) {
+					t.Fatalf("RistrettoFeldmanCommitment.Verify: failed")
+				}
+				subShares[j] = append(subShares[j], share)
+			}
+		}
+
+		var newShares []*Share
+		for j := range subShares {
+			share, err := CombineReshares(dealers, subShares[j])
+			if err != nil {
+				t.Fatalf("CombineReshares: %v", err)
+			}
+			newShares = append(newShares, share)
+		}
+
+		recovered, err := Reconstruct(newShares[2:])
+		if err != nil {
+			t.Fatalf("Reconstruct: %v", err)
+		}
+		if recovered.Equal(secret) != 1 {
+			t.Fatalf("Reconstruct: mismatch after reshare")
+		}
+	})
+}
+
+func testRoundTrip(t *testing.T, m interface{ MarshalBinary() ([]byte, error) }, out interface{ UnmarshalBinary([]byte) error }) {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if err = out.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+}
+
+func mustRandomScalar(t *testing.T) *scalar.Scalar {
+	s, err := scalar.New().SetRandom(nil)
+	if err != nil {
+		t.Fatalf("SetRandom: %v", err)
+	}
+	return s
+}
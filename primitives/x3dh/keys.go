@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package x3dh
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/xeddsa"
+)
+
+// IdentityPublicKeySize is the size, in bytes, of identity public keys.
+const IdentityPublicKeySize = 32
+
+// SignatureScheme is the signature scheme used by an identity key to sign
+// prekeys.
+type SignatureScheme uint8
+
+const (
+	// XEdDSA is an X25519 identity key, that signs with XEdDSA.
+	XEdDSA SignatureScheme = iota
+
+	// Ed25519 is an Ed25519 identity key, that is converted to an
+	// X25519 key for Diffie-Hellman.
+	Ed25519
+)
+
+// String returns the string representation of a SignatureScheme.
+func (s SignatureScheme) String() string {
+	switch s {
+	case XEdDSA:
+		return "XEdDSA"
+	case Ed25519:
+		return "Ed25519"
+	default:
+		return fmt.Sprintf("[unknown signature scheme: %d]", uint8(s))
+	}
+}
+
+// IdentityKey is a long-term identity private key.
+type IdentityKey struct {
+	scheme     SignatureScheme
+	dhKey      x25519.PrivateKey
+	signingKey ed25519.PrivateKey
+	public     *IdentityPublicKey
+}
+
+// Public returns the identity public key.
+func (ik *IdentityKey) Public() *IdentityPublicKey {
+	return ik.public
+}
+
+// Sign signs message with the identity key.  If rand is nil,
+// crypto/rand.Reader will be used.
+func (ik *IdentityKey) Sign(rand io.Reader, message []byte) ([]byte, error) {
+	switch ik.scheme {
+	case XEdDSA:
+		return xeddsa.Sign(rand, &ik.dhKey, message)
+	case Ed25519:
+		return ed25519.Sign(ik.signingKey, message), nil
+	default:
+		return nil, fmt.Errorf("x3dh: invalid signature scheme: %v", ik.scheme)
+	}
+}
+
+// NewXEdDSAIdentityKey creates an identity key from an X25519 private key.
+func NewXEdDSAIdentityKey(privateKey *x25519.PrivateKey) *IdentityKey {
+	ik := &IdentityKey{
+		scheme: XEdDSA,
+		dhKey:  *privateKey,
+	}
+	ik.public = &IdentityPublicKey{
+		scheme: XEdDSA,
+		dhKey:  *privateKey.Public(),
+	}
+	ik.public.key = ik.public.dhKey
+
+	return ik
+}
+
+// NewEd25519IdentityKey creates an identity key from an Ed25519 private key.
+func NewEd25519IdentityKey(privateKey ed25519.PrivateKey) (*IdentityKey, error) {
+	if l := len(privateKey); l != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("x3dh: bad Ed25519 private key length: %d", l)
+	}
+
+	ik := &IdentityKey{
+		scheme:     Ed25519,
+		signingKey: append(ed25519.PrivateKey{}, privateKey...),
+	}
+	copy(ik.dhKey[:], x25519.EdPrivateKeyToX25519(privateKey))
+
+	var err error
+	if ik.public, err = NewIdentityPublicKey(Ed25519, privateKey[ed25519.SeedSize:]); err != nil {
+		return nil, err
+	}
+
+	return ik, nil
+}
+
+// GenerateIdentityKey generates a new identity key for the signature
+// scheme, using entropy from rand.  If rand is nil, crypto/rand.Reader
+// will be used.
+func GenerateIdentityKey(rand io.Reader, scheme SignatureScheme) (*IdentityKey, error) {
+	switch scheme {
+	case XEdDSA:
+		privateKey, err := x25519.GeneratePrivateKey(rand)
+		if err != nil {
+			return nil, fmt.Errorf("x3dh: failed to generate X25519 private key: %w", err)
+		}
+		return NewXEdDSAIdentityKey(privateKey), nil
+	case Ed25519:
+		_, privateKey, err := ed25519.GenerateKey(rand)
+		if err != nil {
+			return nil, fmt.Errorf("x3dh: failed to generate Ed25519 private key: %w", err)
+		}
+		return NewEd25519IdentityKey(privateKey)
+	default:
+		return nil, fmt.Errorf("x3dh: invalid signature scheme: %v", scheme)
+	}
+}
+
+// IdentityPublicKey is a long-term identity public key.
+type IdentityPublicKey struct {
+	scheme SignatureScheme
+	key    [IdentityPublicKeySize]byte
+	dhKey  x25519.PublicKey
+}
+
+// Scheme returns the signature scheme of the identity public key.
+func (pk *IdentityPublicKey) Scheme() SignatureScheme {
+	return pk.scheme
+}
+
+// Bytes returns the byte representation of the identity public key, which
+// is either an X25519 or an Ed25519 public key depending on the scheme.
+func (pk *IdentityPublicKey) Bytes() []byte {
+	return append([]byte{}, pk.key[:]...)
+}
+
+// DHPublicKey returns the X25519 public key used for Diffie-Hellman.
+func (pk *IdentityPublicKey) DHPublicKey() *x25519.PublicKey {
+	dhKey := pk.dhKey
+	return &dhKey
+}
+
+// Equal reports if pk and other are the same identity public key.
+func (pk *IdentityPublicKey) Equal(other *IdentityPublicKey) bool {
+	return pk.scheme == other.scheme && pk.key == other.key
+}
+
+// Verify reports whether signature is a valid signature of message by the
+// identity public key.
+func (pk *IdentityPublicKey) Verify(message, signature []byte) bool {
+	switch pk.scheme {
+	case XEdDSA:
+		return xeddsa.Verify(&pk.dhKey, message, signature)
+	case Ed25519:
+		return ed25519.Verify(pk.key[:], message, signature)
+	default:
+		return false
+	}
+}
+
+// NewIdentityPublicKey creates an identity public key for the signature
+// scheme from its byte representation.
+func NewIdentityPublicKey(scheme SignatureScheme, b []byte) (*IdentityPublicKey, error) {
+	if l := len(b); l != IdentityPublicKeySize {
+		return nil, fmt.Errorf("x3dh: bad identity public key length: %d", l)
+	}
+
+	pk := &IdentityPublicKey{
+		scheme: scheme,
+	}
+	copy(pk.key[:], b)
+	switch scheme {
+	case XEdDSA:
+		copy(pk.dhKey[:], b)
+	case Ed25519:
+		dhKey, ok := x25519.EdPublicKeyToX25519(b)
+		if !ok {
+			return nil, fmt.Errorf("x3dh: invalid Ed25519 identity public key")
+		}
+		copy(pk.dhKey[:], dhKey)
+	default:
+		return nil, fmt.Errorf("x3dh: invalid signature scheme: %v", scheme)
+	}
+
+	return pk, nil
+}
+
+// PreKey is a signed or one-time prekey.
+type PreKey struct {
+	// ID is the identifier of the prekey, used by the initiator to
+	// indicate which of the responder's prekeys it used.
+	ID uint32
+
+	// PrivateKey is the prekey's X25519 private key.
+	PrivateKey x25519.PrivateKey
+}
+
+// Public returns the prekey's X25519 public key.
+func (pk *PreKey) Public() *x25519.PublicKey {
+	return pk.PrivateKey.Public()
+}
+
+// GeneratePreKey generates a new prekey with the identifier id, using
+// entropy from rand.  If rand is nil, crypto/rand.Reader will be used.
+func GeneratePreKey(rand io.Reader, id uint32) (*PreKey, error) {
+	privateKey, err := x25519.GeneratePrivateKey(rand)
+	if err != nil {
+		return nil, fmt.Errorf("x3dh: failed to generate prekey: %w", err)
+	}
+
+	return &PreKey{
+		ID:         id,
+		PrivateKey: *privateKey,
+	}, nil
+}
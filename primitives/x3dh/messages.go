@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package x3dh
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// SignatureSize is the size, in bytes, of prekey signatures.
+	SignatureSize = 64
+
+	preKeyIDSize = 4
+
+	bundleBaseSize       = 1 + IdentityPublicKeySize + preKeyIDSize + EncodedPublicKeySize + SignatureSize + 1
+	bundleOneTimeKeySize = preKeyIDSize + EncodedPublicKeySize
+
+	initialMessageBaseSize = 1 + IdentityPublicKeySize + EncodedPublicKeySize + preKeyIDSize + 1
+)
+
+// PreKeyBundle is the set of public keys published by a responder, that
+// allows an initiator to perform a key agreement with it.
+type PreKeyBundle struct {
+	// IdentityKey is the responder's identity public key (IK_B).
+	IdentityKey *IdentityPublicKey
+
+	// SignedPreKeyID is the identifier of the signed prekey.
+	SignedPreKeyID uint32
+
+	// SignedPreKey is the signed prekey (SPK_B).
+	SignedPreKey x25519.PublicKey
+
+	// SignedPreKeySignature is the signature of Encode(SPK_B) by the
+	// identity key.
+	SignedPreKeySignature []byte
+
+	// OneTimePreKeyID is the identifier of the one-time prekey.
+	OneTimePreKeyID uint32
+
+	// OneTimePreKey is the optional one-time prekey (OPK_B).
+	OneTimePreKey *x25519.PublicKey
+}
+
+// Verify verifies the signed prekey signature of the bundle.
+func (b *PreKeyBundle) Verify() error {
+	if b.IdentityKey == nil {
+		return fmt.Errorf("x3dh: bundle missing identity key")
+	}
+	if !b.IdentityKey.Verify(EncodePublicKey(&b.SignedPreKey), b.SignedPreKeySignature) {
+		return fmt.Errorf("x3dh: invalid signed prekey signature")
+	}
+	return nil
+}
+
+// MarshalBinary encodes a PreKeyBundle into binary form.
+func (b *PreKeyBundle) MarshalBinary() ([]byte, error) {
+	if b.IdentityKey == nil {
+		return nil, fmt.Errorf("x3dh: bundle missing identity key")
+	}
+	if l := len(b.SignedPreKeySignature); l != SignatureSize {
+		return nil, fmt.Errorf("x3dh: bad signed prekey signature length: %d", l)
+	}
+
+	var idBytes [preKeyIDSize]byte
+	data := make([]byte, 0, bundleBaseSize+bundleOneTimeKeySize)
+	data = append(data, byte(b.IdentityKey.scheme))
+	data = append(data, b.IdentityKey.key[:]...)
+	binary.BigEndian.PutUint32(idBytes[:], b.SignedPreKeyID)
+	data = append(data, idBytes[:]...)
+	data = append(data, EncodePublicKey(&b.SignedPreKey)...)
+	data = append(data, b.SignedPreKeySignature...)
+	if b.OneTimePreKey == nil {
+		return append(data, 0), nil
+	}
+
+	data = append(data, 1)
+	binary.BigEndian.PutUint32(idBytes[:], b.OneTimePreKeyID)
+	data = append(data, idBytes[:]...)
+	data = append(data, EncodePublicKey(b.OneTimePreKey)...)
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a binary marshaled PreKeyBundle.  The signed
+// prekey signature is not verified.
+func (b *PreKeyBundle) UnmarshalBinary(data []byte) error {
+	l := len(data)
+	if l < bundleBaseSize {
+		return fmt.Errorf("x3dh: bad bundle length: %d", l)
+	}
+
+	identityKey, err := NewIdentityPublicKey(SignatureScheme(data[0]), data[1:1+IdentityPublicKeySize])
+	if err != nil {
+		return err
+	}
+	data = data[1+IdentityPublicKeySize:]
+	signedPreKeyID := binary.BigEndian.Uint32(data[:preKeyIDSize])
+	data = data[preKeyIDSize:]
+	signedPreKey, err := DecodePublicKey(data[:EncodedPublicKeySize])
+	if err != nil {
+		return err
+	}
+	data = data[EncodedPublicKeySize:]
+	signature := append([]byte{}, data[:SignatureSize]...)
+	data = data[SignatureSize:]
+
+	var (
+		oneTimePreKeyID uint32
+		oneTimePreKey   *x25519.PublicKey
+	)
+	switch data[0] {
+	case 0:
+		if l != bundleBaseSize {
+			return fmt.Errorf("x3dh: bad bundle length: %d", l)
+		}
+	case 1:
+		if l != bundleBaseSize+bundleOneTimeKeySize {
+			return fmt.Errorf("x3dh: bad bundle length: %d", l)
+		}
+		oneTimePreKeyID = binary.BigEndian.Uint32(data[1 : 1+preKeyIDSize])
+		if oneTimePreKey, err = DecodePublicKey(data[1+preKeyIDSize:]); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("x3dh: bad bundle one-time prekey flag: %d", data[0])
+	}
+
+	*b = PreKeyBundle{
+		IdentityKey:           identityKey,
+		SignedPreKeyID:        signedPreKeyID,
+		SignedPreKey:          *signedPreKey,
+		SignedPreKeySignature: signature,
+		OneTimePreKeyID:       oneTimePreKeyID,
+		OneTimePreKey:         oneTimePreKey,
+	}
+
+	return nil
+}
+
+// NewPreKeyBundle creates a prekey bundle for the responder's identity key,
+// signed prekey, and optional one-time prekey, signing the signed prekey
+// with the identity key.  If rand is nil, crypto/rand.Reader will be used.
+func NewPreKeyBundle(rand io.Reader, identityKey *IdentityKey, signedPreKey, oneTimePreKey *PreKey) (*PreKeyBundle, error) {
+	b := &PreKeyBundle{
+		IdentityKey:    identityKey.Public(),
+		SignedPreKeyID: signedPreKey.ID,
+		SignedPreKey:   *signedPreKey.Public(),
+	}
+
+	var err error
+	if b.SignedPreKeySignature, err = identityKey.Sign(rand, EncodePublicKey(&b.SignedPreKey)); err != nil {
+		return nil, fmt.Errorf("x3dh: failed to sign prekey: %w", err)
+	}
+	if oneTimePreKey != nil {
+		b.OneTimePreKeyID = oneTimePreKey.ID
+		b.OneTimePreKey = oneTimePreKey.Public()
+	}
+
+	return b, nil
+}
+
+// InitialMessage is the key agreement information sent by the initiator
+// to the responder.  The initial ciphertext encrypted with the shared key,
+// is sent alongside, and is out of the scope of this package.
+type InitialMessage struct {
+	// IdentityKey is the initiator's identity public key (IK_A).
+	IdentityKey *IdentityPublicKey
+
+	// EphemeralKey is the initiator's ephemeral public key (EK_A).
+	EphemeralKey x25519.PublicKey
+
+	// SignedPreKeyID is the identifier of the responder's signed
+	// prekey that was used.
+	SignedPreKeyID uint32
+
+	// HasOneTimePreKey is set iff one of the responder's one-time
+	// prekeys was used.
+	HasOneTimePreKey bool
+
+	// OneTimePreKeyID is the identifier of the responder's one-time
+	// prekey that was used.
+	OneTimePreKeyID uint32
+}
+
+// MarshalBinary encodes an InitialMessage into binary form.
+func (msg *InitialMessage) MarshalBinary() ([]byte, error) {
+	if msg.IdentityKey == nil {
+		return nil, fmt.Errorf("x3dh: initial message missing identity key")
+	}
+
+	var idBytes [preKeyIDSize]byte
+	data := make([]byte, 0, initialMessageBaseSize+preKeyIDSize)
+	data = append(data, byte(msg.IdentityKey.scheme))
+	data = append(data, msg.IdentityKey.key[:]...)
+	data = append(data, EncodePublicKey(&msg.EphemeralKey)...)
+	binary.BigEndian.PutUint32(idBytes[:], msg.SignedPreKeyID)
+	data = append(data, idBytes[:]...)
+	if !msg.HasOneTimePreKey {
+		return append(data, 0), nil
+	}
+
+	data = append(data, 1)
+	binary.BigEndian.PutUint32(idBytes[:], msg.OneTimePreKeyID)
+	data = append(data, idBytes[:]...)
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a binary marshaled InitialMessage.
+func (msg *InitialMessage) UnmarshalBinary(data []byte) error {
+	l := len(data)
+	if l < initialMessageBaseSize {
+		return fmt.Errorf("x3dh: bad initial message length: %d", l)
+	}
+
+	identityKey, err := NewIdentityPublicKey(SignatureScheme(data[0]), data[1:1+IdentityPublicKeySize])
+	if err != nil {
+		return err
+	}
+	data = data[1+IdentityPublicKeySize:]
+	ephemeralKey, err := DecodePublicKey(data[:EncodedPublicKeySize])
+	if err != nil {
+		return err
+	}
+	data = data[EncodedPublicKeySize:]
+
+	m := InitialMessage{
+		IdentityKey:    identityKey,
+		EphemeralKey:   *ephemeralKey,
+		SignedPreKeyID: binary.BigEndian.Uint32(data[:preKeyIDSize]),
+	}
+	data = data[preKeyIDSize:]
+	switch data[0] {
+	case 0:
+		if l != initialMessageBaseSize {
+			return fmt.Errorf("x3dh: bad initial message length: %d", l)
+		}
+	case 1:
+		if l != initialMessageBaseSize+preKeyIDSize {
+			return fmt.Errorf("x3dh: bad initial message length: %d", l)
+		}
+		m.HasOneTimePreKey = true
+		m.OneTimePreKeyID = binary.BigEndian.Uint32(data[1:])
+	default:
+		return fmt.Errorf("x3dh: bad initial message one-time prekey flag: %d", data[0])
+	}
+	*msg = m
+
+	return nil
+}
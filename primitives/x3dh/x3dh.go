@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package x3dh implements the Extended Triple Diffie-Hellman (X3DH) key
+// agreement protocol with X25519.  See https://signal.org/docs/specifications/x3dh/.
+//
+// Identity keys may either be X25519 keys that sign prekeys with XEdDSA
+// (as done by Signal), or Ed25519 keys that are converted to X25519 keys
+// for the Diffie-Hellman computations.
+package x3dh
+
+import (
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// SharedKeySize is the size, in bytes, of the shared key (SK).
+	SharedKeySize = 32
+
+	// EncodedPublicKeySize is the size, in bytes, of an encoded X25519
+	// public key.
+	EncodedPublicKeySize = 1 + x25519.PublicKeySize
+
+	// AssociatedDataSize is the size, in bytes, of the associated data
+	// (AD).
+	AssociatedDataSize = 2 * EncodedPublicKeySize
+
+	// curveTypeX25519 is the curve type prefix used when encoding X25519
+	// public keys, as used by Signal.
+	curveTypeX25519 = 0x05
+)
+
+// Config is the X3DH protocol configuration, which must be agreed upon by
+// all of the parties.
+type Config struct {
+	// Info is an ASCII string that identifies the application.
+	Info []byte
+
+	// Hash is the hash function used by the KDF, which must be either
+	// crypto.SHA256 or crypto.SHA512.
+	Hash crypto.Hash
+}
+
+func (cfg *Config) validate() error {
+	switch cfg.Hash {
+	case crypto.SHA256, crypto.SHA512:
+	default:
+		return fmt.Errorf("x3dh: unsupported hash: %v", cfg.Hash)
+	}
+	return nil
+}
+
+// Session is the result of a successful key agreement.
+type Session struct {
+	// SharedKey is the shared key (SK).
+	SharedKey []byte
+
+	// AssociatedData is the associated data (AD), which should be
+	// authenticated by the AEAD used to encrypt the messages that
+	// use SharedKey.
+	AssociatedData []byte
+}
+
+// Initiate performs the initiator's side of the key agreement, given the
+// responder's prekey bundle.  The returned InitialMessage must be sent to
+// the responder, along with the initial ciphertext.  If rand is nil,
+// crypto/rand.Reader will be used.
+func (cfg *Config) Initiate(rand io.Reader, identityKey *IdentityKey, bundle *PreKeyBundle) (*Session, *InitialMessage, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := bundle.Verify(); err != nil {
+		return nil, nil, err
+	}
+
+	ephemeralPublicKey, ephemeralKey, err := x25519.GenerateKey(rand)
+	if err != nil {
+		return nil, nil, fmt.Errorf("x3dh: failed to generate ephemeral key: %w", err)
+	}
+	defer func() {
+		for i := range ephemeralKey {
+			ephemeralKey[i] = 0
+		}
+	}()
+
+	return cfg.initiate(identityKey, bundle, ephemeralPublicKey, ephemeralKey)
+}
+
+func (cfg *Config) initiate(identityKey *IdentityKey, bundle *PreKeyBundle, ephemeralPublicKey *x25519.PublicKey, ephemeralKey *x25519.PrivateKey) (*Session, *InitialMessage, error) {
+	dhs := [][2][]byte{
+		{identityKey.dhKey[:], bundle.SignedPreKey[:]}, // DH1 = DH(IK_A, SPK_B)
+		{ephemeralKey[:], bundle.IdentityKey.dhKey[:]}, // DH2 = DH(EK_A, IK_B)
+		{ephemeralKey[:], bundle.SignedPreKey[:]},      // DH3 = DH(EK_A, SPK_B)
+	}
+	msg := &InitialMessage{
+		IdentityKey:    identityKey.Public(),
+		EphemeralKey:   *ephemeralPublicKey,
+		SignedPreKeyID: bundle.SignedPreKeyID,
+	}
+	if bundle.OneTimePreKey != nil {
+		dhs = append(dhs, [2][]byte{ephemeralKey[:], bundle.OneTimePreKey[:]}) // DH4 = DH(EK_A, OPK_B)
+		msg.OneTimePreKeyID = bundle.OneTimePreKeyID
+		msg.HasOneTimePreKey = true
+	}
+
+	sess, err := cfg.deriveSession(dhs, msg.IdentityKey, bundle.IdentityKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sess, msg, nil
+}
+
+// Respond performs the responder's side of the key agreement, given the
+// initiator's InitialMessage, and the private keys corresponding to the
+// prekeys referenced by the message.  oneTimePreKey must be nil iff the
+// message does not reference a one-time prekey, and the caller is
+// responsible for deleting the one-time prekey after use.
+func (cfg *Config) Respond(identityKey *IdentityKey, signedPreKey, oneTimePreKey *PreKey, msg *InitialMessage) (*Session, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if signedPreKey == nil || signedPreKey.ID != msg.SignedPreKeyID {
+		return nil, fmt.Errorf("x3dh: signed prekey mismatch")
+	}
+
+	dhs := [][2][]byte{
+		{signedPreKey.PrivateKey[:], msg.IdentityKey.dhKey[:]}, // DH1 = DH(SPK_B, IK_A)
+		{identityKey.dhKey[:], msg.EphemeralKey[:]},            // DH2 = DH(IK_B, EK_A)
+		{signedPreKey.PrivateKey[:], msg.EphemeralKey[:]},      // DH3 = DH(SPK_B, EK_A)
+	}
+	switch {
+	case msg.HasOneTimePreKey:
+		if oneTimePreKey == nil || oneTimePreKey.ID != msg.OneTimePreKeyID {
+			return nil, fmt.Errorf("x3dh: one-time prekey mismatch")
+		}
+		dhs = append(dhs, [2][]byte{oneTimePreKey.PrivateKey[:], msg.EphemeralKey[:]}) // DH4 = DH(OPK_B, EK_A)
+	case oneTimePreKey != nil:
+		return nil, fmt.Errorf("x3dh: unexpected one-time prekey")
+	}
+
+	return cfg.deriveSession(dhs, msg.IdentityKey, identityKey.Public())
+}
+
+func (cfg *Config) deriveSession(dhs [][2][]byte, initiator, responder *IdentityPublicKey) (*Session, error) {
+	// KM = DH1 || DH2 || DH3 [|| DH4]
+	//
+	// The KDF input is F || KM, where F is 32 0xFF bytes, which provides
+	// domain separation from XEdDSA.
+	ikm := make([]byte, 32, 32+len(dhs)*x25519.SharedSecretSize)
+	for i := range ikm {
+		ikm[i] = 0xff
+	}
+	for i, v := range dhs {
+		dh, err := x25519.X25519(v[0], v[1])
+		if err != nil {
+			return nil, fmt.Errorf("x3dh: DH%d failed: %w", i+1, err)
+		}
+		ikm = append(ikm, dh...)
+	}
+
+	// HKDF with a salt of zero bytes equal to the hash output length.
+	salt := make([]byte, cfg.Hash.Size())
+	sk := make([]byte, SharedKeySize)
+	r := hkdf.New(cfg.Hash.New, ikm, salt, cfg.Info)
+	_, err := io.ReadFull(r, sk)
+	for i := range ikm {
+		ikm[i] = 0
+	}
+	if err != nil {
+		return nil, fmt.Errorf("x3dh: failed to derive shared key: %w", err)
+	}
+
+	// AD = Encode(IK_A) || Encode(IK_B)
+	ad := make([]byte, 0, AssociatedDataSize)
+	ad = append(ad, EncodePublicKey(&initiator.dhKey)...)
+	ad = append(ad, EncodePublicKey(&responder.dhKey)...)
+
+	return &Session{
+		SharedKey:      sk,
+		AssociatedData: ad,
+	}, nil
+}
+
+// EncodePublicKey encodes an X25519 public key, as a single byte curve type
+// followed by the u-coordinate, as done by Signal.
+func EncodePublicKey(publicKey *x25519.PublicKey) []byte {
+	b := make([]byte, 0, EncodedPublicKeySize)
+	b = append(b, curveTypeX25519)
+	return append(b, publicKey[:]...)
+}
+
+// DecodePublicKey decodes an X25519 public key encoded by EncodePublicKey.
+func DecodePublicKey(b []byte) (*x25519.PublicKey, error) {
+	if l := len(b); l != EncodedPublicKeySize {
+		return nil, fmt.Errorf("x3dh: bad encoded public key length: %d", l)
+	}
+	if b[0] != curveTypeX25519 {
+		return nil, fmt.Errorf("x3dh: bad encoded public key curve type: %d", b[0])
+	}
+
+	var publicKey x25519.PublicKey
+	copy(publicKey[:], b[1:])
+
+	return &publicKey, nil
+}
@@ -0,0 +1,390 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package x3dh
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+func TestX3DH(t *testing.T) {
+	for _, scheme := range []SignatureScheme{XEdDSA, Ed25519} {
+		for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA512} {
+			for _, withOneTimePreKey := range []bool{false, true} {
+				cfg := &Config{
+					Info: []byte("curve25519-voi X3DH test"),
+					Hash: hash,
+				}
+				name := fmt.Sprintf("%v/%v/OPK=%v", scheme, hash, withOneTimePreKey)
+				t.Run(name, func(t *testing.T) {
+					testX3DHKeyAgreement(t, cfg, scheme, withOneTimePreKey)
+				})
+			}
+		}
+	}
+
+	t.Run("libsignal", testX3DHLibsignal)
+	t.Run("Malformed", testX3DHMalformed)
+}
+
+func testX3DHKeyAgreement(t *testing.T, cfg *Config, scheme SignatureScheme, withOneTimePreKey bool) {
+	aliceIdentity, err := GenerateIdentityKey(nil, scheme)
+	if err != nil {
+		t.Fatalf("GenerateIdentityKey(alice): %v", err)
+	}
+	bobIdentity, err := GenerateIdentityKey(nil, scheme)
+	if err != nil {
+		t.Fatalf("GenerateIdentityKey(bob): %v", err)
+	}
+	signedPreKey, err := GeneratePreKey(nil, 23)
+	if err != nil {
+		t.Fatalf("GeneratePreKey(signed): %v", err)
+	}
+	var oneTimePreKey *PreKey
+	if withOneTimePreKey {
+		if oneTimePreKey, err = GeneratePreKey(nil, 42); err != nil {
+			t.Fatalf("GeneratePreKey(one-time): %v", err)
+		}
+	}
+
+	bundle, err := NewPreKeyBundle(nil, bobIdentity, signedPreKey, oneTimePreKey)
+	if err != nil {
+		t.Fatalf("NewPreKeyBundle: %v", err)
+	}
+	if err = bundle.Verify(); err != nil {
+		t.Fatalf("bundle.Verify: %v", err)
+	}
+
+	// Round-trip the bundle through the serialized form.
+	b, err := bundle.MarshalBinary()
+	if err != nil {
+		t.Fatalf("bundle.MarshalBinary: %v", err)
+	}
+	var bundle2 PreKeyBundle
+	if err = bundle2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("bundle.UnmarshalBinary: %v", err)
+	}
+	if !bundle2.IdentityKey.Equal(bobIdentity.Public()) {
+		t.Fatalf("bundle.UnmarshalBinary: identity key mismatch")
+	}
+	if (bundle2.OneTimePreKey != nil) != withOneTimePreKey {
+		t.Fatalf("bundle.UnmarshalBinary: one-time prekey mismatch")
+	}
+
+	aliceSession, msg, err := cfg.Initiate(nil, aliceIdentity, &bundle2)
+	if err != nil {
+		t.Fatalf("Initiate: %v", err)
+	}
+
+	// Round-trip the initial message through the serialized form.
+	if b, err = msg.MarshalBinary(); err != nil {
+		t.Fatalf("msg.MarshalBinary: %v", err)
+	}
+	var msg2 InitialMessage
+	if err = msg2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("msg.UnmarshalBinary: %v", err)
+	}
+	if !msg2.IdentityKey.Equal(msg.IdentityKey) ||
+		msg2.EphemeralKey != msg.EphemeralKey ||
+		msg2.SignedPreKeyID != msg.SignedPreKeyID ||
+		msg2.HasOneTimePreKey != withOneTimePreKey ||
+		msg2.OneTimePreKeyID != msg.OneTimePreKeyID {
+		t.Fatalf("msg.UnmarshalBinary: mismatch")
+	}
+
+	bobSession, err := cfg.Respond(bobIdentity, signedPreKey, oneTimePreKey, &msg2)
+	if err != nil {
+		t.Fatalf("Respond: %v", err)
+	}
+
+	if l := len(aliceSession.SharedKey); l != SharedKeySize {
+		t.Fatalf("invalid shared key length: %d", l)
+	}
+	if !bytes.Equal(aliceSession.SharedKey, bobSession.SharedKey) {
+		t.Fatalf("shared key mismatch")
+	}
+	if !bytes.Equal(aliceSession.AssociatedData, bobSession.AssociatedData) {
+		t.Fatalf("associated data mismatch")
+	}
+	expectedAD := append(EncodePublicKey(aliceIdentity.Public().DHPublicKey()), EncodePublicKey(bobIdentity.Public().DHPublicKey())...)
+	if !bytes.Equal(aliceSession.AssociatedData, expectedAD) {
+		t.Fatalf("associated data mismatch (Got: %x, Expected: %x)", aliceSession.AssociatedData, expectedAD)
+	}
+
+	// Derive SK directly per the specification, from the responder's
+	// point of view.
+	dh := func(priv *x25519.PrivateKey, pub *x25519.PublicKey) []byte {
+		ss, dhErr := x25519.X25519(priv[:], pub[:])
+		if dhErr != nil {
+			t.Fatalf("X25519: %v", dhErr)
+		}
+		return ss
+	}
+	km := bytes.Repeat([]byte{0xff}, 32)
+	km = append(km, dh(&signedPreKey.PrivateKey, aliceIdentity.Public().DHPublicKey())...)
+	km = append(km, dh(&bobIdentity.dhKey, &msg.EphemeralKey)...)
+	km = append(km, dh(&signedPreKey.PrivateKey, &msg.EphemeralKey)...)
+	if withOneTimePreKey {
+		km = append(km, dh(&oneTimePreKey.PrivateKey, &msg.EphemeralKey)...)
+	}
+	expectedSK := make([]byte, SharedKeySize)
+	prk := hkdf.Extract(cfg.Hash.New, km, make([]byte, cfg.Hash.Size()))
+	if _, err = io.ReadFull(hkdf.Expand(cfg.Hash.New, prk, cfg.Info), expectedSK); err != nil {
+		t.Fatalf("hkdf: %v", err)
+	}
+	if !bytes.Equal(aliceSession.SharedKey, expectedSK) {
+		t.Fatalf("shared key mismatch (Got: %x, Expected: %x)", aliceSession.SharedKey, expectedSK)
+	}
+
+	// A different info string must result in a different key.
+	otherCfg := &Config{
+		Info: []byte("some other application"),
+		Hash: cfg.Hash,
+	}
+	otherSession, err := otherCfg.Respond(bobIdentity, signedPreKey, oneTimePreKey, &msg2)
+	if err != nil {
+		t.Fatalf("Respond(otherCfg): %v", err)
+	}
+	if bytes.Equal(aliceSession.SharedKey, otherSession.SharedKey) {
+		t.Fatalf("shared key independent of info string")
+	}
+
+	// Prekey mismatches are rejected.
+	otherPreKey, err := GeneratePreKey(nil, 69)
+	if err != nil {
+		t.Fatalf("GeneratePreKey: %v", err)
+	}
+	if _, err = cfg.Respond(bobIdentity, otherPreKey, oneTimePreKey, &msg2); err == nil {
+		t.Fatalf("Respond(wrong signed prekey): expected error")
+	}
+	if _, err = cfg.Respond(bobIdentity, signedPreKey, otherPreKey, &msg2); err == nil {
+		t.Fatalf("Respond(wrong one-time prekey): expected error")
+	}
+}
+
+func testX3DHLibsignal(t *testing.T) {
+	// Test vector taken from libsignal (Curve25519 testSignature), which
+	// is an XEdDSA signature of an encoded X25519 public key by an
+	// identity key, as used for signed prekeys.
+	identityKey, err := NewIdentityPublicKey(XEdDSA, testhelpers.MustUnhex(t, "ab7e717d4a163b7d9a1d8071dfe9dcf8cdcd1cea3339b6356be84d887e322c64"))
+	if err != nil {
+		t.Fatalf("NewIdentityPublicKey: %v", err)
+	}
+	signedPreKey, err := DecodePublicKey(testhelpers.MustUnhex(t, "05edce9d9c415ca78cb7252e72c2c4a554d3eb29485a0e1d503118d1a82d99fb4a"))
+	if err != nil {
+		t.Fatalf("DecodePublicKey: %v", err)
+	}
+	bundle := &PreKeyBundle{
+		IdentityKey:           identityKey,
+		SignedPreKeyID:        1,
+		SignedPreKey:          *signedPreKey,
+		SignedPreKeySignature: testhelpers.MustUnhex(t, "5de88ca9a89b4a115da79109c67c9c7464a3e4180274f1cb8c63c2984e286dfbede82deb9dcd9fae0bfbb821569b3d9001bd8130cd11d486cef047bd60b86e88"),
+	}
+	if err = bundle.Verify(); err != nil {
+		t.Fatalf("bundle.Verify: %v", err)
+	}
+
+	bundle.SignedPreKey[0] ^= 0x01
+	if err = bundle.Verify(); err == nil {
+		t.Fatalf("bundle.Verify(corrupted): expected error")
+	}
+
+	t.Run("KeyAgreement", testX3DHLibsignalKeyAgreement)
+}
+
+func testX3DHLibsignalKeyAgreement(t *testing.T) {
+	// Test vectors generated with go.mau.fi/libsignal v0.2.1
+	// (ratchet.CalculateSenderSession), which derives the root key
+	// as the first 32 bytes of HKDF-SHA256 with an all-zero salt and
+	// the info string "WhisperText", over F || DH1 || DH2 || DH3 [|| DH4].
+	// The associated data is the concatenation of the serialized
+	// (0x05 prefixed) identity keys.
+	cfg := &Config{
+		Info: []byte("WhisperText"),
+		Hash: crypto.SHA256,
+	}
+
+	privateKey := func(s string) *x25519.PrivateKey {
+		var k x25519.PrivateKey
+		copy(k[:], testhelpers.MustUnhex(t, s))
+		return &k
+	}
+	aliceIdentity := NewXEdDSAIdentityKey(privateKey("101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f"))
+	aliceEphemeral := privateKey("303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f")
+	bobIdentity := NewXEdDSAIdentityKey(privateKey("505152535455565758595a5b5c5d5e5f606162636465666768696a6b6c6d6e6f"))
+	signedPreKey := &PreKey{
+		ID:         1,
+		PrivateKey: *privateKey("707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f"),
+	}
+	oneTimePreKey := &PreKey{
+		ID:         2,
+		PrivateKey: *privateKey("909192939495969798999a9b9c9d9e9fa0a1a2a3a4a5a6a7a8a9aaabacadaeaf"),
+	}
+
+	expectedPublicKeys := []struct {
+		name     string
+		key      *x25519.PublicKey
+		expected string
+	}{
+		{"IK_A", aliceIdentity.Public().DHPublicKey(), "d89e3bad79437dbed9f843418304f460ff05c7fe81fe4a9577a804cb9367ff66"},
+		{"EK_A", aliceEphemeral.Public(), "34e42d4af5ef94a07a3a84201b889d4cd1a743cb27b11b6a10438a8feb8e5847"},
+		{"IK_B", bobIdentity.Public().DHPublicKey(), "392d174a38b3b1beafaf1fe824870841c5fa531bc6eafdb6402c124664488c1c"},
+		{"SPK_B", signedPreKey.Public(), "23b7bb8c91ae008711fb12846780bcdf1e065f821bdfec49f57e7c7dcd4c4823"},
+		{"OPK_B", oneTimePreKey.Public(), "9fd7ad6dcff4298dd3f96d5b1b2af910a0535b1488d7f8fabb349a982880b615"},
+	}
+	for _, v := range expectedPublicKeys {
+		if expected := testhelpers.MustUnhex(t, v.expected); !bytes.Equal(v.key[:], expected) {
+			t.Fatalf("%s: public key mismatch (Got: %x, Expected: %x)", v.name, v.key[:], expected)
+		}
+	}
+
+	expectedAD := testhelpers.MustUnhex(t, "05d89e3bad79437dbed9f843418304f460ff05c7fe81fe4a9577a804cb9367ff6605392d174a38b3b1beafaf1fe824870841c5fa531bc6eafdb6402c124664488c1c")
+
+	for _, v := range []struct {
+		name          string
+		oneTimePreKey *PreKey
+		expectedSK    string
+	}{
+		{"NoOPK", nil, "0c63cdd828407673c34f4e15458a2f4a07c9891a6ac78fdd5c08fc0764fcbdbe"},
+		{"OPK", oneTimePreKey, "9bbac44c968c97c338de0f61d416add94aae8966ca00c6a3d3c27f1d8c4de5c9"},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			bundle, err := NewPreKeyBundle(nil, bobIdentity, signedPreKey, v.oneTimePreKey)
+			if err != nil {
+				t.Fatalf("NewPreKeyBundle: %v", err)
+			}
+
+			aliceSession, msg, err := cfg.initiate(aliceIdentity, bundle, aliceEphemeral.Public(), aliceEphemeral)
+			if err != nil {
+				t.Fatalf("initiate: %v", err)
+			}
+			bobSession, err := cfg.Respond(bobIdentity, signedPreKey, v.oneTimePreKey, msg)
+			if err != nil {
+				t.Fatalf("Respond: %v", err)
+			}
+
+			expectedSK := testhelpers.MustUnhex(t, v.expectedSK)
+			for _, sess := range []struct {
+				name string
+				s    *Session
+			}{
+				{"initiator", aliceSession},
+				{"responder", bobSession},
+			} {
+				if !bytes.Equal(sess.s.SharedKey, expectedSK) {
+					t.Fatalf("%s: shared key mismatch (Got: %x, Expected: %x)", sess.name, sess.s.SharedKey, expectedSK)
+				}
+				if !bytes.Equal(sess.s.AssociatedData, expectedAD) {
+					t.Fatalf("%s: associated data mismatch (Got: %x, Expected: %x)", sess.name, sess.s.AssociatedData, expectedAD)
+				}
+			}
+		})
+	}
+}
+
+func testX3DHMalformed(t *testing.T) {
+	cfg := &Config{
+		Info: []byte("curve25519-voi X3DH test"),
+		Hash: crypto.SHA256,
+	}
+
+	aliceIdentity, err := GenerateIdentityKey(nil, XEdDSA)
+	if err != nil {
+		t.Fatalf("GenerateIdentityKey(alice): %v", err)
+	}
+	bobIdentity, err := GenerateIdentityKey(nil, XEdDSA)
+	if err != nil {
+		t.Fatalf("GenerateIdentityKey(bob): %v", err)
+	}
+	signedPreKey, err := GeneratePreKey(nil, 1)
+	if err != nil {
+		t.Fatalf("GeneratePreKey: %v", err)
+	}
+	bundle, err := NewPreKeyBundle(nil, bobIdentity, signedPreKey, nil)
+	if err != nil {
+		t.Fatalf("NewPreKeyBundle: %v", err)
+	}
+
+	badCfg := &Config{
+		Info: cfg.Info,
+		Hash: crypto.MD5,
+	}
+	if _, _, err = badCfg.Initiate(nil, aliceIdentity, bundle); err == nil {
+		t.Fatalf("Initiate(bad hash): expected error")
+	}
+
+	// The signed prekey must be signed by the identity key.
+	badBundle := *bundle
+	badBundle.IdentityKey = aliceIdentity.Public()
+	if _, _, err = cfg.Initiate(nil, aliceIdentity, &badBundle); err == nil {
+		t.Fatalf("Initiate(bad signature): expected error")
+	}
+
+	// Low order prekeys are rejected.
+	badBundle = *bundle
+	badBundle.SignedPreKey = x25519.PublicKey{}
+	if badBundle.SignedPreKeySignature, err = bobIdentity.Sign(nil, EncodePublicKey(&badBundle.SignedPreKey)); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, _, err = cfg.Initiate(nil, aliceIdentity, &badBundle); err == nil {
+		t.Fatalf("Initiate(low order prekey): expected error")
+	}
+
+	b, err := bundle.MarshalBinary()
+	if err != nil {
+		t.Fatalf("bundle.MarshalBinary: %v", err)
+	}
+	var bundle2 PreKeyBundle
+	for _, v := range [][]byte{
+		b[:len(b)-1],
+		append(append([]byte{}, b...), 0),
+	} {
+		if err = bundle2.UnmarshalBinary(v); err == nil {
+			t.Fatalf("bundle.UnmarshalBinary(bad length): expected error")
+		}
+	}
+	badB := append([]byte{}, b...)
+	badB[0] = 0xff
+	if err = bundle2.UnmarshalBinary(badB); err == nil {
+		t.Fatalf("bundle.UnmarshalBinary(bad scheme): expected error")
+	}
+
+	if _, err = DecodePublicKey(append([]byte{0x06}, signedPreKey.Public()[:]...)); err == nil {
+		t.Fatalf("DecodePublicKey(bad curve type): expected error")
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package xeddsa
+
+import (
+	"crypto/sha512"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/elligator"
+	"github.com/oasisprotocol/curve25519-voi/internal/field"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// VRFSignatureSize is the size, in bytes, of VXEdDSA signatures.
+	VRFSignatureSize = 96
+
+	// VRFOutputSize is the size, in bytes, of VXEdDSA VRF outputs.
+	VRFOutputSize = 32
+)
+
+// VRFSign signs the message with privateKey and returns a VXEdDSA signature
+// and the corresponding VRF output, using entropy from rand.  If rand is
+// nil, crypto/rand.Reader will be used.
+//
+// While the signature is randomized, the VRF output is a deterministic
+// function of the key pair and message.
+func VRFSign(rand io.Reader, privateKey *x25519.PrivateKey, message []byte) ([]byte, []byte, error) {
+	var Z [RandomSize]byte
+	if err := readRandom(rand, Z[:]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		A curve.CompressedEdwardsY
+		a scalar.Scalar
+	)
+	calculateKeyPair(&A, &a, privateKey)
+	aBytes, err := a.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Bv = hash_to_point(A || M)
+	var Bv curve.EdwardsPoint
+	hashToPoint(&Bv, A[:], message)
+
+	// V = aBv
+	var (
+		V           curve.EdwardsPoint
+		vCompressed curve.CompressedEdwardsY
+	)
+	V.Mul(&Bv, &a)
+	vCompressed.SetEdwardsPoint(&V)
+
+	// r = hash3(a || V || Z) (mod q)
+	var r scalar.Scalar
+	hashToScalar(&r, 3, aBytes, vCompressed[:], Z[:])
+
+	// R = rB, Rv = rBv
+	var (
+		R, Rv                     curve.EdwardsPoint
+		rCompressed, rvCompressed curve.CompressedEdwardsY
+	)
+	R.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &r)
+	rCompressed.SetEdwardsPoint(&R)
+	Rv.Mul(&Bv, &r)
+	rvCompressed.SetEdwardsPoint(&Rv)
+
+	// h = hash4(A || V || R || Rv || M) (mod q)
+	var h scalar.Scalar
+	hashToScalar(&h, 4, A[:], vCompressed[:], rCompressed[:], rvCompressed[:], message)
+
+	// s = r + ha (mod q)
+	var s scalar.Scalar
+	s.Mul(&h, &a)
+	s.Add(&s, &r)
+
+	signature := make([]byte, VRFSignatureSize)
+	copy(signature[:32], vCompressed[:])
+	if err = h.ToBytes(signature[32:64]); err != nil {
+		return nil, nil, err
+	}
+	if err = s.ToBytes(signature[64:]); err != nil {
+		return nil, nil, err
+	}
+
+	return signature, vrfOutput(&V), nil
+}
+
+// VRFVerify reports whether signature is a valid VXEdDSA signature of
+// message by publicKey, and returns the VRF output iff the signature is
+// valid.
+func VRFVerify(publicKey *x25519.PublicKey, message, signature []byte) (bool, []byte) {
+	if len(signature) != VRFSignatureSize {
+		return false, nil
+	}
+
+	// if u >= p or V.y >= 2^|p| or h >= 2^|q| or s >= 2^|q|: return false
+	if signature[63]&0xe0 != 0 || signature[95]&0xe0 != 0 {
+		return false, nil
+	}
+	var A curve.EdwardsPoint
+	if !convertMont(&A, publicKey, 0) {
+		return false, nil
+	}
+	var (
+		V           curve.EdwardsPoint
+		vCompressed curve.CompressedEdwardsY
+	)
+	if _, err := vCompressed.SetBytes(signature[:32]); err != nil || !vCompressed.IsCanonicalVartime() {
+		return false, nil
+	}
+	if _, err := V.SetCompressedY(&vCompressed); err != nil {
+		return false, nil
+	}
+	var h, s scalar.Scalar
+	if _, err := h.SetCanonicalBytes(signature[32:64]); err != nil {
+		return false, nil
+	}
+	if _, err := s.SetBytesModOrder(signature[64:]); err != nil {
+		return false, nil
+	}
+
+	var aCompressed curve.CompressedEdwardsY
+	aCompressed.SetEdwardsPoint(&A)
+
+	// Bv = hash_to_point(A || M)
+	var Bv curve.EdwardsPoint
+	hashToPoint(&Bv, aCompressed[:], message)
+
+	// if cA == I or cV == I or Bv == I: return false
+	if A.IsSmallOrder() || V.IsSmallOrder() || Bv.IsIdentity() {
+		return false, nil
+	}
+
+	// R = sB - hA, Rv = sBv - hV
+	var (
+		negH                      scalar.Scalar
+		R, Rv                     curve.EdwardsPoint
+		rCompressed, rvCompressed curve.CompressedEdwardsY
+	)
+	negH.Neg(&h)
+	R.DoubleScalarMulBasepointVartime(&negH, &A, &s)
+	rCompressed.SetEdwardsPoint(&R)
+	Rv.MultiscalarMulVartime([]*scalar.Scalar{&s, &negH}, []*curve.EdwardsPoint{&Bv, &V})
+	rvCompressed.SetEdwardsPoint(&Rv)
+
+	// hcheck = hash4(A || V || R || Rv || M) (mod q)
+	var hCheck scalar.Scalar
+	hashToScalar(&hCheck, 4, aCompressed[:], vCompressed[:], rCompressed[:], rvCompressed[:], message)
+	if hCheck.Equal(&h) != 1 {
+		return false, nil
+	}
+
+	return true, vrfOutput(&V)
+}
+
+// hashToPoint computes hash_to_point(X), which maps hash2(X) to a point
+// in the prime order subgroup with Elligator 2.
+func hashToPoint(p *curve.EdwardsPoint, inputs ...[]byte) {
+	var digest [sha512.Size]byte
+	hashI(&digest, 2, inputs...)
+
+	// r = h (mod 2^|p|), s = the next bit of h
+	sign := digest[31] >> 7
+	digest[31] &= 0x7f
+
+	var r field.Element
+	if _, err := r.SetBytes(digest[:field.ElementSize]); err != nil {
+		panic("xeddsa: failed to deserialize field element: " + err.Error())
+	}
+
+	u := elligator.MontgomeryFlavor(&r)
+	if _, err := p.SetMontgomery(u, sign); err != nil {
+		// Elligator 2 only produces points on the curve.
+		panic("xeddsa: failed to convert point: " + err.Error())
+	}
+	p.MulByCofactor(p)
+}
+
+// vrfOutput computes v = hash5(cV) (mod 2^b).
+func vrfOutput(V *curve.EdwardsPoint) []byte {
+	var (
+		cV           curve.EdwardsPoint
+		cvCompressed curve.CompressedEdwardsY
+		digest       [sha512.Size]byte
+	)
+	cV.MulByCofactor(V)
+	cvCompressed.SetEdwardsPoint(&cV)
+	hashI(&digest, 5, cvCompressed[:])
+
+	return append([]byte{}, digest[:VRFOutputSize]...)
+}
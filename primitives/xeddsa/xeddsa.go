@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package xeddsa implements the XEdDSA and VXEdDSA signature schemes,
+// which allow X25519 key pairs to be used to produce Ed25519 compatible
+// signatures and VRF outputs.  See https://signal.org/docs/specifications/xeddsa/.
+//
+// XEdDSA signatures produced by this package verify with libsignal, and
+// Verify accepts signatures produced by libsignal, including those that
+// store the Edwards sign bit of the public key in the most significant
+// bit of the signature.
+//
+// VXEdDSA is implemented as described in the specification, and is NOT
+// interoperable with libsignal, which instead implements the
+// "generalized" labelset-based variant (generalized_xveddsa_25519).  The
+// two constructions hash differently, so VRF outputs differ, and
+// signatures produced by one will not verify with the other.
+package xeddsa
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/field"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+const (
+	// SignatureSize is the size, in bytes, of XEdDSA signatures.
+	SignatureSize = 64
+
+	// RandomSize is the size, in bytes, of the random data (Z) mixed
+	// into the nonce by the signing process.
+	RandomSize = 64
+)
+
+// Sign signs the message with privateKey and returns an XEdDSA signature,
+// using entropy from rand.  If rand is nil, crypto/rand.Reader will be
+// used.
+func Sign(rand io.Reader, privateKey *x25519.PrivateKey, message []byte) ([]byte, error) {
+	var Z [RandomSize]byte
+	if err := readRandom(rand, Z[:]); err != nil {
+		return nil, err
+	}
+
+	var (
+		A curve.CompressedEdwardsY
+		a scalar.Scalar
+	)
+	calculateKeyPair(&A, &a, privateKey)
+	aBytes, err := a.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	// r = hash1(a || M || Z) (mod q)
+	var r scalar.Scalar
+	hashToScalar(&r, 1, aBytes, message, Z[:])
+
+	var (
+		R           curve.EdwardsPoint
+		rCompressed curve.CompressedEdwardsY
+	)
+	R.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, &r)
+	rCompressed.SetEdwardsPoint(&R)
+
+	// h = hash(R || A || M) (mod q)
+	var h scalar.Scalar
+	computeHram(&h, rCompressed[:], A[:], message)
+
+	// s = r + ha (mod q)
+	var s scalar.Scalar
+	s.Mul(&h, &a)
+	s.Add(&s, &r)
+
+	signature := make([]byte, SignatureSize)
+	copy(signature[:32], rCompressed[:])
+	if err = s.ToBytes(signature[32:]); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// Verify reports whether signature is a valid XEdDSA signature of message
+// by publicKey.
+func Verify(publicKey *x25519.PublicKey, message, signature []byte) bool {
+	if len(signature) != SignatureSize {
+		return false
+	}
+
+	// libsignal stores the sign bit of the Edwards public key in the
+	// otherwise unused most significant bit of s.  Signatures that
+	// conform to the specification always have the bit cleared.
+	var sBytes [scalar.ScalarSize]byte
+	copy(sBytes[:], signature[32:])
+	sign := sBytes[31] >> 7
+	sBytes[31] &= 0x7f
+
+	// if u >= p or R.y >= 2^|p| or s >= 2^|q|: return false
+	if sBytes[31]&0xe0 != 0 {
+		return false
+	}
+	var A curve.EdwardsPoint
+	if !convertMont(&A, publicKey, sign) {
+		return false
+	}
+
+	var s scalar.Scalar
+	if _, err := s.SetBytesModOrder(sBytes[:]); err != nil {
+		return false
+	}
+
+	var aCompressed curve.CompressedEdwardsY
+	aCompressed.SetEdwardsPoint(&A)
+
+	// h = hash(R || A || M) (mod q)
+	var h scalar.Scalar
+	computeHram(&h, signature[:32], aCompressed[:], message)
+
+	// Rcheck = sB - hA
+	var (
+		negA, rCheck curve.EdwardsPoint
+		rCompressed  curve.CompressedEdwardsY
+	)
+	negA.Neg(&A)
+	rCheck.DoubleScalarMulBasepointVartime(&h, &negA, &s)
+	rCompressed.SetEdwardsPoint(&rCheck)
+
+	return string(rCompressed[:]) == string(signature[:32])
+}
+
+// calculateKeyPair converts the Montgomery private key k into the Edwards
+// key pair (A, a), where the sign bit of A is forced to 0 by negating a
+// if required.
+func calculateKeyPair(A *curve.CompressedEdwardsY, a *scalar.Scalar, privateKey *x25519.PrivateKey) {
+	k := *privateKey
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+	if _, err := a.SetBytesModOrder(k[:]); err != nil {
+		panic("xeddsa: failed to deserialize private key: " + err.Error())
+	}
+	for i := range k {
+		k[i] = 0
+	}
+
+	var E curve.EdwardsPoint
+	E.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, a)
+	A.SetEdwardsPoint(&E)
+
+	// if E.s == 1: a = -k (mod q), else a = k (mod q)
+	var negA scalar.Scalar
+	negA.Neg(a)
+	a.ConditionalSelect(a, &negA, int(A[31]>>7))
+	A[31] &= 0x7f
+}
+
+// convertMont converts the Montgomery public key u into the Edwards
+// point with the requested sign.
+func convertMont(A *curve.EdwardsPoint, publicKey *x25519.PublicKey, sign byte) bool {
+	// Reject u >= p.
+	if publicKey[31]&0x80 != 0 {
+		return false
+	}
+	var (
+		u       field.Element
+		uBytes  [field.ElementSize]byte
+		montPub curve.MontgomeryPoint
+	)
+	if _, err := u.SetBytes(publicKey[:]); err != nil {
+		return false
+	}
+	_ = u.ToBytes(uBytes[:])
+	if uBytes != *publicKey {
+		return false
+	}
+
+	copy(montPub[:], publicKey[:])
+	if _, err := A.SetMontgomery(&montPub, sign); err != nil {
+		return false
+	}
+
+	return true
+}
+
+func computeHram(h *scalar.Scalar, R, A, message []byte) {
+	var digest [sha512.Size]byte
+	hash := sha512.New()
+	_, _ = hash.Write(R)
+	_, _ = hash.Write(A)
+	_, _ = hash.Write(message)
+	hash.Sum(digest[:0])
+	if _, err := h.SetBytesModOrderWide(digest[:]); err != nil {
+		panic("xeddsa: failed to deserialize H(R,A,m) scalar: " + err.Error())
+	}
+}
+
+// hashI computes hash_i(X) = hash(2^b - 1 - i || X).
+func hashI(digest *[sha512.Size]byte, i byte, inputs ...[]byte) {
+	var prefix [32]byte
+	for j := range prefix {
+		prefix[j] = 0xff
+	}
+	prefix[0] -= i
+
+	hash := sha512.New()
+	_, _ = hash.Write(prefix[:])
+	for _, v := range inputs {
+		_, _ = hash.Write(v)
+	}
+	hash.Sum(digest[:0])
+}
+
+func hashToScalar(s *scalar.Scalar, i byte, inputs ...[]byte) {
+	var digest [sha512.Size]byte
+	hashI(&digest, i, inputs...)
+	if _, err := s.SetBytesModOrderWide(digest[:]); err != nil {
+		panic("xeddsa: failed to deserialize hash scalar: " + err.Error())
+	}
+}
+
+func readRandom(rand io.Reader, b []byte) error {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	_, err := io.ReadFull(rand, b)
+	return err
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2026 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package xeddsa
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+	"github.com/oasisprotocol/curve25519-voi/internal/testhelpers"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+)
+
+func TestXEdDSA(t *testing.T) {
+	t.Run("libsignal", testXEdDSALibsignal)
+	t.Run("SignVerify", testXEdDSASignVerify)
+	t.Run("Malformed", testXEdDSAMalformed)
+}
+
+func testXEdDSALibsignal(t *testing.T) {
+	// Test vector taken from libsignal (Curve25519 testSignature).  This
+	// signature has the sign bit of the Edwards public key set in the
+	// most significant bit of s.
+	var (
+		privateKey x25519.PrivateKey
+		publicKey  x25519.PublicKey
+	)
+	copy(privateKey[:], testhelpers.MustUnhex(t, "c097248412e58bf05df487968205132794178e367637f5818f81e0e6ce73e865"))
+	copy(publicKey[:], testhelpers.MustUnhex(t, "ab7e717d4a163b7d9a1d8071dfe9dcf8cdcd1cea3339b6356be84d887e322c64"))
+	message := testhelpers.MustUnhex(t, "05edce9d9c415ca78cb7252e72c2c4a554d3eb29485a0e1d503118d1a82d99fb4a")
+	signature := testhelpers.MustUnhex(t, "5de88ca9a89b4a115da79109c67c9c7464a3e4180274f1cb8c63c2984e286dfbede82deb9dcd9fae0bfbb821569b3d9001bd8130cd11d486cef047bd60b86e88")
+
+	if !bytes.Equal(privateKey.Public()[:], publicKey[:]) {
+		t.Fatalf("privateKey.Public(): mismatch")
+	}
+	if !Verify(&publicKey, message, signature) {
+		t.Fatalf("Verify: false")
+	}
+
+	for i := range signature {
+		badSig := append([]byte{}, signature...)
+		badSig[i] ^= 0x01
+		if Verify(&publicKey, message, badSig) {
+			t.Fatalf("Verify(badSig[%d]): true", i)
+		}
+	}
+	badMsg := append([]byte{}, message...)
+	badMsg[0] ^= 0x01
+	if Verify(&publicKey, badMsg, signature) {
+		t.Fatalf("Verify(badMsg): true")
+	}
+
+	// Signatures that we produce with the same key must verify, and be
+	// in the form mandated by the specification.
+	sig, err := Sign(nil, &privateKey, message)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if sig[63]&0x80 != 0 {
+		t.Fatalf("Sign: sign bit set in signature")
+	}
+	if !Verify(&publicKey, message, sig) {
+		t.Fatalf("Verify(Sign): false")
+	}
+}
+
+func testXEdDSASignVerify(t *testing.T) {
+	message := []byte("test message")
+
+	// Exercise both signs of the Edwards public key.
+	var sawSign [2]bool
+	for i := 0; i < 32; i++ {
+		publicKey, privateKey, err := x25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+
+		var E curve.EdwardsPoint
+		var eCompressed curve.CompressedEdwardsY
+		k := *privateKey
+		k[0] &= 248
+		k[31] &= 127
+		k[31] |= 64
+		e, err := scalar.NewFromBytesModOrder(k[:])
+		if err != nil {
+			t.Fatalf("NewFromBytesModOrder: %v", err)
+		}
+		E.MulBasepoint(curve.ED25519_BASEPOINT_TABLE, e)
+		eCompressed.SetEdwardsPoint(&E)
+		sawSign[eCompressed[31]>>7] = true
+
+		sig, err := Sign(nil, privateKey, message)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if l := len(sig); l != SignatureSize {
+			t.Fatalf("Sign: invalid signature length: %d", l)
+		}
+		if !Verify(publicKey, message, sig) {
+			t.Fatalf("Verify: false")
+		}
+		if Verify(publicKey, []byte("wrong message"), sig) {
+			t.Fatalf("Verify(wrong message): true")
+		}
+
+		// XEdDSA signatures are Ed25519 signatures by the converted
+		// public key with the sign bit cleared.
+		var A curve.EdwardsPoint
+		if !convertMont(&A, publicKey, 0) {
+			t.Fatalf("convertMont: failed")
+		}
+		var aCompressed curve.CompressedEdwardsY
+		aCompressed.SetEdwardsPoint(&A)
+		if !ed25519.Verify(aCompressed[:], message, sig) {
+			t.Fatalf("ed25519.Verify: false")
+		}
+
+		// Signatures are randomized.
+		sig2, err := Sign(nil, privateKey, message)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if bytes.Equal(sig, sig2) {
+			t.Fatalf("Sign: signatures are not randomized")
+		}
+	}
+	if !sawSign[0] || !sawSign[1] {
+		t.Fatalf("failed to exercise both Edwards signs: %v", sawSign)
+	}
+}
+
+func testXEdDSAMalformed(t *testing.T) {
+	message := []byte("test message")
+	publicKey, privateKey, err := x25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	sig, err := Sign(nil, privateKey, message)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if Verify(publicKey, message, sig[:SignatureSize-1]) {
+		t.Fatalf("Verify(truncated): true")
+	}
+
+	// s >= 2^|q|
+	badSig := append([]byte{}, sig...)
+	badSig[63] |= 0x20
+	if Verify(publicKey, message, badSig) {
+		t.Fatalf("Verify(s >= 2^|q|): true")
+	}
+
+	// u >= p
+	var badPub x25519.PublicKey
+	badPub[0] = 0xed
+	for i := 1; i < 31; i++ {
+		badPub[i] = 0xff
+	}
+	badPub[31] = 0x7f
+	if Verify(&badPub, message, sig) {
+		t.Fatalf("Verify(u = p): true")
+	}
+	badPub = *publicKey
+	badPub[31] |= 0x80
+	if Verify(&badPub, message, sig) {
+		t.Fatalf("Verify(u >= 2^|p|): true")
+	}
+}
+
+func TestVXEdDSA(t *testing.T) {
+	t.Run("Vectors", testVXEdDSAVectors)
+	t.Run("SignVerify", testVXEdDSASignVerify)
+}
+
+func testVXEdDSAVectors(t *testing.T) {
+	// libsignal implements the "generalized" VXEdDSA variant, which uses
+	// labelsets and differs from the construction in the specification,
+	// so these vectors were instead generated with an independent
+	// big-integer implementation of the specification, with a fixed Z.
+	for i, vec := range []struct {
+		privateKey, publicKey, random, message, signature, output string
+	}{
+		{
+			privateKey: "8a5b8b714ab286d8c9350fefa0542edf387bd681fa4580dd4441576cacfd3a74",
+			publicKey:  "72f7275cc9f874a7e0291ac9ada977af16345797776e8d634fc96f7f00029f24",
+			random:     "6686a5b3ad54543d3612d65825d0b49e4a4bfd493f50d9221d8a1bbc9e0d9f17d70229968ba2a6ee1c392926cabe4cda687cd1b639a3c232409d26eeed6ebe52",
+			message:    "",
+			signature:  "465c429d7df770e14a3699b1549534e52f9379ffdf2afa6d9842738c5c29125945a212bb05629562f248767f49c6927bda14b4e8fa2833e59ea91e589090870c3473750d0bb920066369738f29d58bdf1f4981dbd462bd2b45fe67d1394ef60d",
+			output:     "bb3debf87b85d47b37ae6244ad46d8c90a8b347e4e6760b94ed4de490e3eda7c",
+		},
+		{
+			privateKey: "8f8e54a8da1fb3040f5bfead8d1428c446e4f4ce70c6cdba702bed4ae47532d0",
+			publicKey:  "03594090fe5cc16e7da63904d1de2436d7b81e06eaf6242869827dc03982e115",
+			random:     "6677d78c3e0f46a11d7fed693486cf71528242308f8d90094da9ab35f986623319261baa8c12eef27d2a3f4c4eefffa02eea9578e9f65332cc76ce48d1eb01d5",
+			message:    "74657374206d657373616765",
+			signature:  "5c3cf79cc771a2caa407c41783d8b858f2488ec7d935fc2da87310997de1d4f1c43d33cab9c49dae86635be9b54452af84e2669ce19da209623db62c9428de093bf0a4d37cb64f888d36684cbc578fa17d6ca198ab51a9d2e8dc06852dc42a0f",
+			output:     "0f6a8473cbdda443ff294b85fc8a8222b971958f563dcd6051c80f07b017a8c2",
+		},
+		{
+			privateKey: "e3e07e8dedc7f340d6f47e9d3b28b270a7f80f82e13db909dd4644d94c4f67ee",
+			publicKey:  "466807b5b609ec9a11b5f9bf3444fb26b57d1f0f690fccdfb126ba073d122029",
+			random:     "d4b3052fef463f970fa859ea3ed2a288c30af78f746eb94e8b98c7bf0aa065fb41b2af2c7911c435327cd090a87e6e15c31e70f72c6fbd0d864cece1c3dfe5b6",
+			message:    "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9fa0a1a2a3a4a5a6a7a8a9aaabacadaeafb0b1b2b3b4b5b6b7b8b9babbbcbdbebfc0c1c2c3c4c5c6c7",
+			signature:  "446c70965e21f4fecfc81e29abe875505357ab7c1826a755c41b874f3a3fe7e100c675e328be95174d103b352cedc6221d25b944fdf0c48e0c785523c56573090bc5d155b7a20fa8feda2f09e31f69c25dd0a2dd0ce8c1139ba2cfa4cf1a4909",
+			output:     "f7c039ef9c39c76323b33bd1ee114e38f9a674a1912f511b0a9d1d0f59837173",
+		},
+	} {
+		var (
+			privateKey x25519.PrivateKey
+			publicKey  x25519.PublicKey
+		)
+		copy(privateKey[:], testhelpers.MustUnhex(t, vec.privateKey))
+		copy(publicKey[:], testhelpers.MustUnhex(t, vec.publicKey))
+		message := testhelpers.MustUnhex(t, vec.message)
+		expectedSig := testhelpers.MustUnhex(t, vec.signature)
+		expectedOutput := testhelpers.MustUnhex(t, vec.output)
+
+		if !bytes.Equal(privateKey.Public()[:], publicKey[:]) {
+			t.Fatalf("%d: privateKey.Public(): mismatch", i)
+		}
+
+		sig, v, err := VRFSign(bytes.NewReader(testhelpers.MustUnhex(t, vec.random)), &privateKey, message)
+		if err != nil {
+			t.Fatalf("%d: VRFSign: %v", i, err)
+		}
+		if !bytes.Equal(sig, expectedSig) {
+			t.Fatalf("%d: VRFSign: signature mismatch (Got: %x, Expected: %x)", i, sig, expectedSig)
+		}
+		if !bytes.Equal(v, expectedOutput) {
+			t.Fatalf("%d: VRFSign: output mismatch (Got: %x, Expected: %x)", i, v, expectedOutput)
+		}
+
+		ok, v := VRFVerify(&publicKey, message, expectedSig)
+		if !ok {
+			t.Fatalf("%d: VRFVerify: false", i)
+		}
+		if !bytes.Equal(v, expectedOutput) {
+			t.Fatalf("%d: VRFVerify: output mismatch (Got: %x, Expected: %x)", i, v, expectedOutput)
+		}
+	}
+}
+
+func testVXEdDSASignVerify(t *testing.T) {
+	message := []byte("test message")
+
+	for i := 0; i < 8; i++ {
+		publicKey, privateKey, err := x25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+
+		sig, v, err := VRFSign(nil, privateKey, message)
+		if err != nil {
+			t.Fatalf("VRFSign: %v", err)
+		}
+		if l := len(sig); l != VRFSignatureSize {
+			t.Fatalf("VRFSign: invalid signature length: %d", l)
+		}
+		if l := len(v); l != VRFOutputSize {
+			t.Fatalf("VRFSign: invalid output length: %d", l)
+		}
+
+		ok, vCheck := VRFVerify(publicKey, message, sig)
+		if !ok {
+			t.Fatalf("VRFVerify: false")
+		}
+		if !bytes.Equal(v, vCheck) {
+			t.Fatalf("VRFVerify: output mismatch")
+		}
+
+		// The signature is randomized, the output is not.
+		sig2, v2, err := VRFSign(nil, privateKey, message)
+		if err != nil {
+			t.Fatalf("VRFSign: %v", err)
+		}
+		if bytes.Equal(sig, sig2) {
+			t.Fatalf("VRFSign: signatures are not randomized")
+		}
+		if !bytes.Equal(v, v2) {
+			t.Fatalf("VRFSign: output is not deterministic")
+		}
+
+		_, v3, err := VRFSign(nil, privateKey, []byte("other message"))
+		if err != nil {
+			t.Fatalf("VRFSign: %v", err)
+		}
+		if bytes.Equal(v, v3) {
+			t.Fatalf("VRFSign: output independent of message")
+		}
+
+		if ok, v := VRFVerify(publicKey, []byte("wrong message"), sig); ok || v != nil {
+			t.Fatalf("VRFVerify(wrong message): true")
+		}
+		otherPublicKey, _, err := x25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		if ok, _ := VRFVerify(otherPublicKey, message, sig); ok {
+			t.Fatalf("VRFVerify(wrong key): true")
+		}
+		for j := range sig {
+			badSig := append([]byte{}, sig...)
+			badSig[j] ^= 0x01
+			if ok, _ := VRFVerify(publicKey, message, badSig); ok {
+				t.Fatalf("VRFVerify(badSig[%d]): true", j)
+			}
+		}
+		if ok, _ := VRFVerify(publicKey, message, sig[:VRFSignatureSize-1]); ok {
+			t.Fatalf("VRFVerify(truncated): true")
+		}
+	}
+}